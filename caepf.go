@@ -0,0 +1,243 @@
+package br
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+// CAEPF represents a Brazilian CAEPF (Cadastro de Atividade Econômica da Pessoa Física),
+// the registration of an individual employer.
+//
+// It is made of the 9 base digits of the holder's CPF, a 3 digit sequence and 2 check digits.
+type CAEPF string
+
+// NewCAEPF creates a new CAEPF instance from a string representation.
+//
+// It verifies the CAEPF's validity using checksum digits.
+func NewCAEPF(s string) (CAEPF, error) {
+	caepf := CAEPF(s)
+	if !caepf.IsValid() {
+		return "", ErrInvalidCAEPF
+	}
+	return caepf, nil
+}
+
+// NewCAEPFFromCPF creates a new CAEPF for the holder's CPF and the given sequence,
+// which must be between 1 and 999.
+//
+// The CAEPF is returned formatted as XXX.XXX.XXX/XXX-XX.
+func NewCAEPFFromCPF(cpf CPF, seq int) (CAEPF, error) {
+	if !cpf.IsValid() {
+		return "", ErrInvalidCPF
+	}
+
+	if seq < 1 || seq > 999 {
+		return "", fmt.Errorf("%w: sequence out of range: %d", ErrInvalidCAEPF, seq)
+	}
+
+	formatted := cpf.String()
+
+	data := make([]byte, 18)
+	copy(data[:11], formatted[:11])
+	data[11] = '/'
+	data[12] = byte(seq/100) + '0'
+	data[13] = byte(seq/10%10) + '0'
+	data[14] = byte(seq%10) + '0'
+	data[15] = '-'
+
+	digits, _ := CAEPF(data).digits()
+	data[16], data[17] = caepfCheckDigits(digits)
+
+	return CAEPF(string(data)), nil
+}
+
+// GenerateCAEPF generates a pseudo-random valid CAEPF.
+func GenerateCAEPF() CAEPF {
+	seq := int(randomUint64n(999)) + 1
+	caepf, _ := NewCAEPFFromCPF(GenerateCPF(), seq)
+	return caepf
+}
+
+// ErrInvalidCAEPF is an error returned when an invalid CAEPF is encountered.
+var ErrInvalidCAEPF = errors.New("br: invalid caepf")
+
+// IsValid checks whether the provided CAEPF is valid based on its checksum digits.
+//
+// The formats accepted are: XXXXXXXXXXXXXX and XXX.XXX.XXX/XXX-XX.
+func (caepf CAEPF) IsValid() bool {
+	digits, ok := caepf.digits()
+	if !ok {
+		return false
+	}
+
+	d1, d2 := caepfCheckDigits(digits)
+	return digits[12] == d1 && digits[13] == d2
+}
+
+// digits strips the punctuation of the CAEPF and makes sure all remaining bytes are digits.
+func (caepf CAEPF) digits() (out [14]byte, ok bool) {
+	switch len(caepf) {
+	case 14:
+		copy(out[:], caepf)
+	case 18:
+		if caepf[3] != '.' || caepf[7] != '.' || caepf[11] != '/' || caepf[15] != '-' {
+			return out, false
+		}
+
+		copy(out[0:3], caepf[0:3])
+		copy(out[3:6], caepf[4:7])
+		copy(out[6:9], caepf[8:11])
+		copy(out[9:12], caepf[12:15])
+		copy(out[12:14], caepf[16:18])
+	default:
+		return out, false
+	}
+
+	for _, d := range out {
+		if !isDigit(d) {
+			return out, false
+		}
+	}
+
+	return out, true
+}
+
+// caepfCheckDigits calculates the CAEPF check digits.
+//
+// They are calculated as the CNPJ ones, then 12 is added to the resulting 2 digit number,
+// ignoring anything above 99.
+func caepfCheckDigits(digits [14]byte) (byte, byte) {
+	var sum int
+	for i, d := range cnpjFirstTable {
+		sum += d * int(digits[i]-'0')
+	}
+
+	d1 := 11 - sum%11
+	if d1 >= 10 {
+		d1 = 0
+	}
+
+	sum = 6 * int(digits[0]-'0')
+	for i, d := range cnpjFirstTable[:11] {
+		sum += d * int(digits[i+1]-'0')
+	}
+	sum += 2 * d1
+
+	d2 := 11 - sum%11
+	if d2 >= 10 {
+		d2 = 0
+	}
+
+	dv := (d1*10 + d2 + 12) % 100
+	return byte(dv/10) + '0', byte(dv%10) + '0'
+}
+
+// Owner returns the CPF of the CAEPF's holder, formatted as XXX.XXX.XXX-XX.
+//
+// If the CAEPF is invalid, an empty string is returned.
+func (caepf CAEPF) Owner() CPF {
+	if !caepf.IsValid() {
+		return ""
+	}
+
+	digits, _ := caepf.digits()
+
+	data := make([]byte, 14)
+	data[3] = '.'
+	data[7] = '.'
+	data[11] = '-'
+
+	copy(data[0:3], digits[0:3])
+	copy(data[4:7], digits[3:6])
+	copy(data[8:11], digits[6:9])
+
+	var cacheSum int
+	data[12], cacheSum, _ = cpfIterFirst14(data)
+	data[13], _ = cpfIterSecond14(data, cacheSum)
+
+	return CPF(string(data))
+}
+
+// Sequence returns the sequence of the CAEPF, which tells apart the activities of the same holder.
+//
+// If the CAEPF is invalid, 0 is returned.
+func (caepf CAEPF) Sequence() int {
+	if !caepf.IsValid() {
+		return 0
+	}
+
+	digits, _ := caepf.digits()
+	return int(digits[9]-'0')*100 + int(digits[10]-'0')*10 + int(digits[11]-'0')
+}
+
+// String returns the formatted CAEPF string with punctuation as XXX.XXX.XXX/XXX-XX.
+func (caepf CAEPF) String() string {
+	if !caepf.IsValid() {
+		return ""
+	}
+
+	if len(caepf) == 18 {
+		return string(caepf)
+	}
+
+	out := make([]byte, 18)
+	out[3] = '.'
+	out[7] = '.'
+	out[11] = '/'
+	out[15] = '-'
+
+	copy(out[0:3], caepf[0:3])
+	copy(out[4:7], caepf[3:6])
+	copy(out[8:11], caepf[6:9])
+	copy(out[12:15], caepf[9:12])
+	copy(out[16:18], caepf[12:14])
+
+	return string(out)
+}
+
+// Value implements the driver.Valuer interface for CAEPF.
+func (caepf CAEPF) Value() (driver.Value, error) {
+	return caepf.String(), nil
+}
+
+// Scan implements the sql.Scanner interface for CAEPF.
+func (caepf *CAEPF) Scan(value any) error {
+	str, err := scanString("CAEPF", value)
+	if err != nil {
+		return err
+	}
+
+	_caepf, err := NewCAEPF(str)
+	if err != nil {
+		return fmt.Errorf("br: can not scan %q into CAEPF: %w", str, err)
+	}
+
+	*caepf = _caepf
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for CAEPF.
+func (caepf CAEPF) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + caepf.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for CAEPF.
+func (caepf *CAEPF) UnmarshalJSON(b []byte) error {
+	str, ok, err := unmarshalJSONString(b)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %s into CAEPF: %w", b, err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	_caepf, err := NewCAEPF(str)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %q into CAEPF: %w", str, err)
+	}
+
+	*caepf = _caepf
+	return nil
+}
@@ -0,0 +1,158 @@
+package br
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGenerateCAEPF(t *testing.T) {
+	for range 1_000_000 {
+		if caepf := GenerateCAEPF(); !caepf.IsValid() {
+			t.Errorf("invalid CAEPF generated: %s", string(caepf))
+		}
+	}
+}
+
+func TestNewCAEPFFromCPF(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		cpf     CPF
+		seq     int
+		want    CAEPF
+		wantErr bool
+	}{
+		{
+			name: "raw CPF",
+			cpf:  CPF("45317828791"),
+			seq:  1,
+			want: CAEPF("453.178.287/001-03"),
+		},
+		{
+			name: "formatted CPF",
+			cpf:  CPF("453.178.287-91"),
+			seq:  1,
+			want: CAEPF("453.178.287/001-03"),
+		},
+		{
+			name:    "invalid CPF",
+			cpf:     CPF("45317828792"),
+			seq:     1,
+			wantErr: true,
+		},
+		{
+			name:    "zero sequence",
+			cpf:     CPF("45317828791"),
+			seq:     0,
+			wantErr: true,
+		},
+		{
+			name:    "sequence too big",
+			cpf:     CPF("45317828791"),
+			seq:     1000,
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			caepf, err := NewCAEPFFromCPF(tc.cpf, tc.seq)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\ncpf: %s\nshould err: %v\nerr: %v", tc.cpf, tc.wantErr, err)
+			}
+
+			if caepf != tc.want {
+				t.Errorf("\ncpf: %s\nwant: %s\ngot: %s", tc.cpf, tc.want, caepf)
+			}
+		})
+	}
+}
+
+func TestCAEPF_IsValid(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		caepf CAEPF
+		valid bool
+	}{
+		{
+			name:  "formatted CAEPF",
+			caepf: CAEPF("453.178.287/001-03"),
+			valid: true,
+		},
+		{
+			name:  "raw CAEPF",
+			caepf: CAEPF("45317828700103"),
+			valid: true,
+		},
+		{
+			name:  "raw CAEPF wrapping check digits",
+			caepf: CAEPF("12345678900100"),
+			valid: true,
+		},
+		{
+			name:  "cnpj check digits",
+			caepf: CAEPF("45317828700191"),
+			valid: false,
+		},
+		{
+			name:  "invalid digit",
+			caepf: CAEPF("453.178.287/001-04"),
+			valid: false,
+		},
+		{
+			name:  "invalid separators",
+			caepf: CAEPF("453.178.287-001/03"),
+			valid: false,
+		},
+		{
+			name:  "empty",
+			caepf: CAEPF(""),
+			valid: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.caepf.IsValid() != tc.valid {
+				t.Errorf(
+					"\ncaepf: %s\nshould be valid: %v\nis valid: %v",
+					tc.caepf, tc.valid, tc.caepf.IsValid(),
+				)
+			}
+		})
+	}
+}
+
+func TestCAEPF_Owner(t *testing.T) {
+	caepf := CAEPF("45317828700103")
+	if owner := caepf.Owner(); owner != CPF("453.178.287-91") {
+		t.Errorf("unexpected owner: %s", owner)
+	}
+
+	if seq := caepf.Sequence(); seq != 1 {
+		t.Errorf("unexpected sequence: %d", seq)
+	}
+
+	if caepf.String() != "453.178.287/001-03" {
+		t.Errorf("unexpected string: %s", caepf.String())
+	}
+
+	if owner := CAEPF("45317828700104").Owner(); owner != "" {
+		t.Errorf("invalid caepf with owner: %s", owner)
+	}
+}
+
+func TestCAEPF_JSON(t *testing.T) {
+	data, err := json.Marshal(CAEPF("45317828700103"))
+	if err != nil {
+		t.Fatalf("failed to marshal caepf: %v", err)
+	}
+
+	if want := `"453.178.287/001-03"`; string(data) != want {
+		t.Errorf("\nwant: %s\ngot: %s", want, data)
+	}
+
+	var caepf CAEPF
+	if err := json.Unmarshal(data, &caepf); err != nil {
+		t.Fatalf("failed to unmarshal caepf: %v", err)
+	}
+
+	if err := json.Unmarshal([]byte(`"45317828700104"`), &caepf); err == nil {
+		t.Error("invalid caepf unmarshaled without error")
+	}
+}
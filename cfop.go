@@ -0,0 +1,303 @@
+package br
+
+import (
+	"database/sql/driver"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/phenpessoa/br/x/address"
+)
+
+// CFOP represents a Código Fiscal de Operações e Prestações, the 4-digit code that tells the nature
+// of the operation of each item of a fiscal document.
+//
+// The first digit tells whether the operation is an entrada (1, 2 and 3) or a saída (5, 6 and 7),
+// and whether the other party is in the same UF, in another UF or abroad. Only the codes of the
+// embedded table, published in the Convênio s/nº de 1970 as amended by the Ajuste SINIEF 07/01,
+// are valid.
+type CFOP string
+
+// NewCFOP creates a new CFOP instance from a string representation, such as 5102 or 5.102.
+//
+// It verifies that the CFOP is in the embedded table.
+func NewCFOP(s string) (CFOP, error) {
+	cfop := CFOP(s)
+	if !cfop.IsValid() {
+		return "", ErrInvalidCFOP
+	}
+	return cfop, nil
+}
+
+var (
+	// ErrInvalidCFOP is an error returned when an invalid CFOP is encountered.
+	ErrInvalidCFOP = errors.New("br: invalid cfop")
+
+	// ErrCFOPScope is returned by CheckScope when the scope of the CFOP does not match the UFs of
+	// the parties of the operation.
+	ErrCFOPScope = errors.New("br: cfop scope does not match the ufs")
+)
+
+// cfopCSV is the table of CFOPs, with the code and the description of each one.
+//
+//go:embed tables/cfop.csv
+var cfopCSV string
+
+type cfopTable struct {
+	version      string
+	descriptions map[string]string
+}
+
+var loadCFOPTable = sync.OnceValue(func() cfopTable {
+	t, err := parseCFOPTable(cfopCSV)
+	if err != nil {
+		panic("br: invalid embedded cfop table: " + err.Error())
+	}
+	return t
+})
+
+// parseCFOPTable parses the embedded table. Its first line is a comment with the version of the
+// table, followed by a header and the rows.
+func parseCFOPTable(s string) (cfopTable, error) {
+	var t cfopTable
+
+	// line is the line of the header in s, used to report the line of an invalid row.
+	line := 1
+	if rest, ok := strings.CutPrefix(s, "# version: "); ok {
+		t.version, s, _ = strings.Cut(rest, "\n")
+		t.version = strings.TrimSpace(t.version)
+		line++
+	}
+
+	records, err := csv.NewReader(strings.NewReader(s)).ReadAll()
+	if err != nil {
+		return cfopTable{}, err
+	}
+
+	if len(records) == 0 || len(records[0]) != 2 || records[0][0] != "cfop" {
+		return cfopTable{}, errors.New("missing header")
+	}
+
+	t.descriptions = make(map[string]string, len(records)-1)
+	for i, r := range records[1:] {
+		if _, ok := CFOP(r[0]).digits(); !ok || len(r[0]) != 4 {
+			return cfopTable{}, fmt.Errorf("line %d: invalid cfop %q", line+1+i, r[0])
+		}
+		t.descriptions[r[0]] = r[1]
+	}
+
+	return t, nil
+}
+
+// CFOPTableVersion returns the version of the embedded table of CFOPs.
+func CFOPTableVersion() string {
+	return loadCFOPTable().version
+}
+
+// CFOPScope tells where the other party of the operation of a CFOP is.
+type CFOPScope uint8
+
+const (
+	// CFOPEstadual is an operation with a party in the same UF.
+	CFOPEstadual CFOPScope = iota + 1
+
+	// CFOPInterestadual is an operation with a party in another UF.
+	CFOPInterestadual
+
+	// CFOPExterior is an operation with a party abroad, an import or an export.
+	CFOPExterior
+)
+
+// String returns the name of the CFOPScope.
+func (s CFOPScope) String() string {
+	switch s {
+	case CFOPEstadual:
+		return "estadual"
+	case CFOPInterestadual:
+		return "interestadual"
+	case CFOPExterior:
+		return "exterior"
+	default:
+		return ""
+	}
+}
+
+// digits returns the 4 digits of the CFOP, ignoring the dot after the first one.
+func (c CFOP) digits() (string, bool) {
+	s := string(c)
+	if len(s) == 5 {
+		if s[1] != '.' {
+			return "", false
+		}
+		s = s[:1] + s[2:]
+	}
+
+	if len(s) != 4 {
+		return "", false
+	}
+
+	for i := range len(s) {
+		if !isDigit(s[i]) {
+			return "", false
+		}
+	}
+
+	return s, true
+}
+
+// IsValid checks whether the CFOP is in the embedded table.
+func (c CFOP) IsValid() bool {
+	d, ok := c.digits()
+	if !ok {
+		return false
+	}
+
+	_, ok = loadCFOPTable().descriptions[d]
+	return ok
+}
+
+// IsEntrada reports whether the CFOP is valid and is an entrada or an acquisition of a service.
+func (c CFOP) IsEntrada() bool {
+	if !c.IsValid() {
+		return false
+	}
+
+	d, _ := c.digits()
+	return d[0] <= '3'
+}
+
+// IsSaida reports whether the CFOP is valid and is a saída or a provision of a service.
+func (c CFOP) IsSaida() bool {
+	if !c.IsValid() {
+		return false
+	}
+
+	d, _ := c.digits()
+	return d[0] >= '5'
+}
+
+// Scope returns where the other party of the operation is.
+//
+// If the CFOP is not valid, 0 is returned.
+func (c CFOP) Scope() CFOPScope {
+	if !c.IsValid() {
+		return 0
+	}
+
+	switch d, _ := c.digits(); d[0] {
+	case '1', '5':
+		return CFOPEstadual
+	case '2', '6':
+		return CFOPInterestadual
+	default:
+		return CFOPExterior
+	}
+}
+
+// Description returns the description of the CFOP in the embedded table, such as
+// "Venda de mercadoria adquirida ou recebida de terceiros" for 5102.
+//
+// If the CFOP is not valid, an empty string is returned.
+func (c CFOP) Description() string {
+	d, ok := c.digits()
+	if !ok {
+		return ""
+	}
+
+	return loadCFOPTable().descriptions[d]
+}
+
+// CheckScope checks whether the scope of the CFOP matches the UFs of the emitter and of the
+// recipient of the document. address.ZZ stands for a party abroad.
+//
+// The CFOP must be CFOPExterior when either UF is address.ZZ, CFOPEstadual when both UFs are the
+// same and CFOPInterestadual otherwise.
+//
+// The returned error is ErrInvalidCFOP, address.ErrInvalidUF or ErrCFOPScope.
+func (c CFOP) CheckScope(emitente, destinatario address.UF) error {
+	scope := c.Scope()
+	if scope == 0 {
+		return ErrInvalidCFOP
+	}
+
+	for _, uf := range [...]address.UF{emitente, destinatario} {
+		if uf != address.ZZ && uf.String() == "" {
+			return address.ErrInvalidUF
+		}
+	}
+
+	want := CFOPInterestadual
+	switch {
+	case emitente == address.ZZ || destinatario == address.ZZ:
+		want = CFOPExterior
+	case emitente == destinatario:
+		want = CFOPEstadual
+	}
+
+	if scope != want {
+		return ErrCFOPScope
+	}
+
+	return nil
+}
+
+// String returns the CFOP formatted as X.XXX.
+//
+// If the CFOP is not valid, an empty string is returned.
+func (c CFOP) String() string {
+	if !c.IsValid() {
+		return ""
+	}
+
+	d, _ := c.digits()
+	return d[:1] + "." + d[1:]
+}
+
+// Value implements the driver.Valuer interface for CFOP.
+func (c CFOP) Value() (driver.Value, error) {
+	return c.String(), nil
+}
+
+// Scan implements the sql.Scanner interface for CFOP.
+func (c *CFOP) Scan(value any) error {
+	str, err := scanString("CFOP", value)
+	if err != nil {
+		return err
+	}
+
+	_c, err := NewCFOP(str)
+	if err != nil {
+		return fmt.Errorf("br: can not scan %q into CFOP: %w", str, err)
+	}
+
+	*c = _c
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for CFOP.
+func (c CFOP) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + c.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for CFOP.
+func (c *CFOP) UnmarshalJSON(b []byte) error {
+	str, ok, err := unmarshalJSONString(b)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %s into CFOP: %w", b, err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	_c, err := NewCFOP(str)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %q into CFOP: %w", str, err)
+	}
+
+	*c = _c
+	return nil
+}
@@ -0,0 +1,219 @@
+package br
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/phenpessoa/br/x/address"
+)
+
+func BenchmarkCFOP_IsValid(b *testing.B) {
+	cfop := CFOP("5.102")
+	b.ReportAllocs()
+	for range b.N {
+		_ = cfop.IsValid()
+	}
+}
+
+func TestCFOP_IsValid(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		cfop  CFOP
+		valid bool
+	}{
+		{"digits", "5102", true},
+		{"formatted", "5.102", true},
+		{"entrada", "1102", true},
+		{"importacao", "3102", true},
+		{"exportacao", "7101", true},
+		{"only interestadual", "6107", true},
+		{"only estadual", "5405", true},
+		{"not in table", "6405", false},
+		{"group title", "5100", false},
+		{"invalid first digit", "4102", false},
+		{"short", "510", false},
+		{"long", "51020", false},
+		{"misplaced dot", "51.02", false},
+		{"letter", "51O2", false},
+		{"empty", "", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.cfop.IsValid(); got != tc.valid {
+				t.Errorf("\nwant: %t\ngot: %t", tc.valid, got)
+			}
+
+			_, err := NewCFOP(string(tc.cfop))
+			if tc.valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tc.valid && !errors.Is(err, ErrInvalidCFOP) {
+				t.Errorf("\nwant: %s\ngot: %v", ErrInvalidCFOP, err)
+			}
+		})
+	}
+}
+
+func TestCFOP_Classification(t *testing.T) {
+	for _, tc := range []struct {
+		cfop    CFOP
+		entrada bool
+		saida   bool
+		scope   CFOPScope
+	}{
+		{"1102", true, false, CFOPEstadual},
+		{"2.102", true, false, CFOPInterestadual},
+		{"3102", true, false, CFOPExterior},
+		{"5102", false, true, CFOPEstadual},
+		{"6.108", false, true, CFOPInterestadual},
+		{"7101", false, true, CFOPExterior},
+		{"4102", false, false, 0},
+	} {
+		t.Run(string(tc.cfop), func(t *testing.T) {
+			if got := tc.cfop.IsEntrada(); got != tc.entrada {
+				t.Errorf("IsEntrada\nwant: %t\ngot: %t", tc.entrada, got)
+			}
+			if got := tc.cfop.IsSaida(); got != tc.saida {
+				t.Errorf("IsSaida\nwant: %t\ngot: %t", tc.saida, got)
+			}
+			if got := tc.cfop.Scope(); got != tc.scope {
+				t.Errorf("Scope\nwant: %s\ngot: %s", tc.scope, got)
+			}
+		})
+	}
+}
+
+func TestCFOP_Description(t *testing.T) {
+	for _, tc := range []struct {
+		cfop CFOP
+		want string
+	}{
+		{"5102", "Venda de mercadoria adquirida ou recebida de terceiros"},
+		{"1.202", "Devolução de venda de mercadoria adquirida ou recebida de terceiros"},
+		{"6107", "Venda de produção do estabelecimento, destinada a não contribuinte"},
+		{"7501", "Exportação de mercadorias recebidas com fim específico de exportação"},
+		{"5100", ""},
+	} {
+		t.Run(string(tc.cfop), func(t *testing.T) {
+			if got := tc.cfop.Description(); got != tc.want {
+				t.Errorf("\nwant: %s\ngot: %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCFOP_CheckScope(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		cfop         CFOP
+		emitente     address.UF
+		destinatario address.UF
+		err          error
+	}{
+		{"estadual", "5102", address.SP, address.SP, nil},
+		{"interestadual", "6102", address.SP, address.RJ, nil},
+		{"exportacao", "7101", address.SP, address.ZZ, nil},
+		{"importacao", "3102", address.ZZ, address.SP, nil},
+		{"entrada estadual", "1202", address.MG, address.MG, nil},
+		{"estadual between ufs", "5102", address.SP, address.RJ, ErrCFOPScope},
+		{"interestadual in the same uf", "6102", address.SP, address.SP, ErrCFOPScope},
+		{"interestadual abroad", "6102", address.SP, address.ZZ, ErrCFOPScope},
+		{"exterior in the same uf", "7101", address.SP, address.SP, ErrCFOPScope},
+		{"invalid cfop", "5100", address.SP, address.SP, ErrInvalidCFOP},
+		{"invalid uf", "5102", address.SP, 0, address.ErrInvalidUF},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.cfop.CheckScope(tc.emitente, tc.destinatario); !errors.Is(err, tc.err) {
+				t.Errorf("\nwant: %v\ngot: %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestCFOP_String(t *testing.T) {
+	if got := CFOP("5102").String(); got != "5.102" {
+		t.Errorf("\nwant: 5.102\ngot: %s", got)
+	}
+
+	if got := CFOP("5100").String(); got != "" {
+		t.Errorf("\nwant: \ngot: %s", got)
+	}
+}
+
+func TestCFOP_JSON(t *testing.T) {
+	data, err := json.Marshal(CFOP("6102"))
+	if err != nil {
+		t.Fatalf("failed to marshal cfop: %v", err)
+	}
+
+	if want := `"6.102"`; string(data) != want {
+		t.Errorf("\nwant: %s\ngot: %s", want, data)
+	}
+
+	var c CFOP
+	if err := json.Unmarshal(data, &c); err != nil {
+		t.Fatalf("failed to unmarshal cfop: %v", err)
+	}
+
+	if c != "6.102" {
+		t.Errorf("unmarshaled wrong cfop: %s", c)
+	}
+
+	if err := json.Unmarshal([]byte(`"6100"`), &c); err == nil {
+		t.Error("invalid cfop unmarshaled without error")
+	}
+}
+
+func TestCFOP_Scan(t *testing.T) {
+	var c CFOP
+	if err := c.Scan([]byte("5102")); err != nil {
+		t.Fatalf("failed to scan cfop: %v", err)
+	}
+
+	if c != "5102" {
+		t.Errorf("scanned wrong cfop: %s", c)
+	}
+
+	if err := c.Scan("5100"); err == nil {
+		t.Error("invalid cfop scanned without error")
+	}
+}
+
+func TestParseCFOPTable(t *testing.T) {
+	if v := CFOPTableVersion(); v == "" {
+		t.Error("missing version of the embedded table")
+	}
+
+	for _, tc := range []struct {
+		name string
+		data string
+	}{
+		{"missing header", "# version: 1\n5102,Venda\n"},
+		{"invalid code", "cfop,descricao\n510,Venda\n"},
+		{"non-numeric code", "# version: 1\ncfop,descricao\n51O2,Venda\n"},
+		{"wrong columns", "cfop,descricao\n5102\n"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := parseCFOPTable(tc.data); err == nil {
+				t.Error("invalid table parsed without error")
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		name string
+		data string
+		line string
+	}{
+		{"with version", "# version: 1\ncfop,descricao\n5102,Venda\n51O2,Venda\n", "line 4:"},
+		{"without version", "cfop,descricao\n5102,Venda\n51O2,Venda\n", "line 3:"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseCFOPTable(tc.data)
+			if err == nil || !strings.HasPrefix(err.Error(), tc.line) {
+				t.Errorf("\nwant: %s\ngot: %v", tc.line, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,568 @@
+package br
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/phenpessoa/br/x/address"
+)
+
+// ChaveAcesso represents the access key (chave de acesso) of a Brazilian electronic fiscal document,
+// such as the NF-e, the NFC-e, the CT-e and the MDF-e.
+//
+// It has 44 digits: the UF code (2), the year and month of emission as AAMM (4), the CNPJ or the CPF
+// of the emitter (14), the model (2), the series (3), the number (9), the emission type (1), the
+// numeric code (8) and a check digit.
+//
+// The CNPJ of the emitter may be alphanumeric.
+type ChaveAcesso string
+
+// NewChaveAcesso creates a new ChaveAcesso instance from a string representation.
+//
+// It verifies the ChaveAcesso's validity using its check digit.
+func NewChaveAcesso(s string) (ChaveAcesso, error) {
+	c := ChaveAcesso(s)
+	if !c.IsValid() {
+		return "", ErrInvalidChaveAcesso
+	}
+	return c, nil
+}
+
+// ChaveAcessoFields holds the fields encoded in a ChaveAcesso.
+type ChaveAcessoFields struct {
+	// UF is the state of the emitter.
+	UF address.UF
+
+	// Ano is the year of emission, from 2000 to 2099.
+	Ano int
+
+	// Mes is the month of emission.
+	Mes time.Month
+
+	// CNPJ is the CNPJ of the emitter. Either it or CPF must be set.
+	CNPJ CNPJ
+
+	// CPF is the CPF of the emitter, used when it has no CNPJ.
+	CPF CPF
+
+	// Modelo is the model of the document.
+	Modelo ModeloDocumento
+
+	// Serie is the series of the document, from 0 to 999.
+	Serie int
+
+	// Numero is the number of the document, from 1 to 999999999.
+	Numero int
+
+	// TipoEmissao is how the document was emitted.
+	TipoEmissao TipoEmissao
+
+	// CodigoNumerico is the random code chosen by the emitter, from 0 to 99999999.
+	CodigoNumerico int
+}
+
+// NewChaveAcessoFromFields creates a new ChaveAcesso from its fields, calculating its check digit.
+//
+// The returned error is always a *DocumentoError, matching ErrInvalidChaveAcesso. Its Err is
+// ErrDocumentoLength for the numbers that do not fit their digits, ErrDocumentoCheckDigit for an
+// invalid CNPJ or CPF and ErrDocumentoCharacter for the other fields.
+func NewChaveAcessoFromFields(f ChaveAcessoFields) (ChaveAcesso, error) {
+	var data [44]byte
+
+	if _, err := address.NewUF(f.UF.Codigo()); err != nil {
+		return "", newChaveAcessoError("uf", ErrDocumentoCharacter)
+	}
+	putDigits(data[0:2], f.UF.Codigo())
+
+	if f.Ano < 2000 || f.Ano > 2099 {
+		return "", newChaveAcessoError("ano", ErrDocumentoLength)
+	}
+	putDigits(data[2:4], f.Ano%100)
+
+	if f.Mes < time.January || f.Mes > time.December {
+		return "", newChaveAcessoError("mes", ErrDocumentoCharacter)
+	}
+	putDigits(data[4:6], int(f.Mes))
+
+	switch {
+	case f.CNPJ != "" && f.CPF != "":
+		return "", newChaveAcessoError("emitente", ErrDocumentoCharacter)
+	case f.CNPJ != "":
+		if !f.CNPJ.IsValid() {
+			return "", newChaveAcessoError("cnpj", ErrDocumentoCheckDigit)
+		}
+		copy(data[6:20], f.CNPJ.AlphaNumerical())
+		for i := 6; i < 20; i++ {
+			data[i] = asciiLowerToUpper(data[i])
+		}
+	case f.CPF != "":
+		if !f.CPF.IsValid() {
+			return "", newChaveAcessoError("cpf", ErrDocumentoCheckDigit)
+		}
+		copy(data[6:9], "000")
+		j := 9
+		for i := range len(f.CPF) {
+			if isDigit(f.CPF[i]) {
+				data[j] = f.CPF[i]
+				j++
+			}
+		}
+	default:
+		return "", newChaveAcessoError("emitente", ErrDocumentoLength)
+	}
+
+	if !f.Modelo.IsValid() {
+		return "", newChaveAcessoError("modelo", ErrDocumentoCharacter)
+	}
+	putDigits(data[20:22], int(f.Modelo))
+
+	if f.Serie < 0 || f.Serie > 999 {
+		return "", newChaveAcessoError("serie", ErrDocumentoLength)
+	}
+	putDigits(data[22:25], f.Serie)
+
+	if f.Numero < 1 || f.Numero > 999_999_999 {
+		return "", newChaveAcessoError("numero", ErrDocumentoLength)
+	}
+	putDigits(data[25:34], f.Numero)
+
+	if f.TipoEmissao < 1 || f.TipoEmissao > 9 {
+		return "", newChaveAcessoError("tipo emissao", ErrDocumentoCharacter)
+	}
+	data[34] = byte(f.TipoEmissao) + '0'
+
+	if f.CodigoNumerico < 0 || f.CodigoNumerico > 99_999_999 {
+		return "", newChaveAcessoError("codigo numerico", ErrDocumentoLength)
+	}
+	putDigits(data[35:43], f.CodigoNumerico)
+
+	data[43] = chaveAcessoCheckDigit(data[:43])
+
+	return ChaveAcesso(string(data[:])), nil
+}
+
+func newChaveAcessoError(field string, err error) error {
+	return &DocumentoError{Doc: ErrInvalidChaveAcesso, Field: field, Err: err}
+}
+
+// putDigits writes n to d, padded with zeros to the left. It does not check whether n fits.
+func putDigits(d []byte, n int) {
+	for i := len(d) - 1; i >= 0; i-- {
+		d[i] = byte(n%10) + '0'
+		n /= 10
+	}
+}
+
+// GenerateChaveAcesso generates a pseudo-random valid ChaveAcesso of an NF-e, an NFC-e, a CT-e or
+// an MDF-e, emitted normally by a random CNPJ.
+func GenerateChaveAcesso() ChaveAcesso {
+	c, err := NewChaveAcessoFromFields(ChaveAcessoFields{
+		UF:             chaveAcessoUFs[randomUint64n(uint64(len(chaveAcessoUFs)))],
+		Ano:            2006 + int(randomUint64n(94)),
+		Mes:            time.Month(randomUint64n(12) + 1),
+		CNPJ:           GenerateCNPJ(),
+		Modelo:         chaveAcessoModelos[randomUint64n(uint64(len(chaveAcessoModelos)))],
+		Serie:          int(randomUint64n(1000)),
+		Numero:         int(randomUint64n(999_999_999)) + 1,
+		TipoEmissao:    EmissaoNormal,
+		CodigoNumerico: int(randomUint64n(100_000_000)),
+	})
+	if err != nil {
+		panic("br: generated an invalid chave de acesso: " + err.Error())
+	}
+	return c
+}
+
+var chaveAcessoUFs = []address.UF{
+	address.RO, address.AC, address.AM, address.RR, address.PA, address.AP, address.TO,
+	address.MA, address.PI, address.CE, address.RN, address.PB, address.PE, address.AL,
+	address.SE, address.BA, address.MG, address.ES, address.RJ, address.SP, address.PR,
+	address.SC, address.RS, address.MS, address.MT, address.GO, address.DF,
+}
+
+var chaveAcessoModelos = []ModeloDocumento{ModeloNFe, ModeloCTe, ModeloMDFe, ModeloNFCe}
+
+// ErrInvalidChaveAcesso is an error returned when an invalid ChaveAcesso is encountered.
+var ErrInvalidChaveAcesso = errors.New("br: invalid chave de acesso")
+
+// ModeloDocumento is the model of an electronic fiscal document.
+type ModeloDocumento uint8
+
+const (
+	// ModeloNFe is the model 55, of the Nota Fiscal Eletrônica.
+	ModeloNFe ModeloDocumento = 55
+
+	// ModeloCTe is the model 57, of the Conhecimento de Transporte Eletrônico.
+	ModeloCTe ModeloDocumento = 57
+
+	// ModeloMDFe is the model 58, of the Manifesto Eletrônico de Documentos Fiscais.
+	ModeloMDFe ModeloDocumento = 58
+
+	// ModeloNFCe is the model 65, of the Nota Fiscal de Consumidor Eletrônica.
+	ModeloNFCe ModeloDocumento = 65
+)
+
+// IsValid checks whether the ModeloDocumento is one of the known models.
+func (m ModeloDocumento) IsValid() bool {
+	return m.String() != ""
+}
+
+// String returns the name of the ModeloDocumento, such as NF-e.
+func (m ModeloDocumento) String() string {
+	switch m {
+	case ModeloNFe:
+		return "NF-e"
+	case ModeloCTe:
+		return "CT-e"
+	case ModeloMDFe:
+		return "MDF-e"
+	case ModeloNFCe:
+		return "NFC-e"
+	default:
+		return ""
+	}
+}
+
+// TipoEmissao is how an electronic fiscal document was emitted (tpEmis).
+//
+// Some codes have different meanings for each model. The constants follow the NF-e.
+type TipoEmissao uint8
+
+const (
+	// EmissaoNormal is the normal emission, authorized by the SEFAZ of the emitter.
+	EmissaoNormal TipoEmissao = 1
+
+	// EmissaoContingenciaFSIA is the contingency emission with the security form for printing the DANFE.
+	EmissaoContingenciaFSIA TipoEmissao = 2
+
+	// EmissaoRegimeEspecialNFF is the emission of the special regime of the Nota Fiscal Fácil.
+	EmissaoRegimeEspecialNFF TipoEmissao = 3
+
+	// EmissaoContingenciaEPEC is the contingency emission with the prior electronic event (EPEC).
+	EmissaoContingenciaEPEC TipoEmissao = 4
+
+	// EmissaoContingenciaFSDA is the contingency emission with the security form for documents (FS-DA).
+	EmissaoContingenciaFSDA TipoEmissao = 5
+
+	// EmissaoContingenciaSVCAN is the contingency emission authorized by the SEFAZ Virtual of Ambiente Nacional.
+	EmissaoContingenciaSVCAN TipoEmissao = 6
+
+	// EmissaoContingenciaSVCRS is the contingency emission authorized by the SEFAZ Virtual of Rio Grande do Sul.
+	EmissaoContingenciaSVCRS TipoEmissao = 7
+
+	// EmissaoContingenciaOffline is the offline contingency emission of the NFC-e.
+	EmissaoContingenciaOffline TipoEmissao = 9
+)
+
+// IsValid checks whether the ChaveAcesso is valid based on its fields and check digit.
+//
+// The formats accepted are: 44 digits, or 44 digits separated by spaces, such as the 11 groups of 4
+// digits printed on the DANFE.
+func (c ChaveAcesso) IsValid() bool {
+	digits, ok := c.digits()
+	if !ok {
+		return false
+	}
+
+	if _, err := address.NewUF(digitsToInt(digits[0:2])); err != nil {
+		return false
+	}
+
+	if mes := digitsToInt(digits[4:6]); mes < 1 || mes > 12 {
+		return false
+	}
+
+	if !ModeloDocumento(digitsToInt(digits[20:22])).IsValid() || digits[34] == '0' {
+		return false
+	}
+
+	if chaveAcessoCNPJ(digits) == "" && chaveAcessoCPF(digits) == "" {
+		return false
+	}
+
+	return digits[43] == chaveAcessoCheckDigit(digits[:43])
+}
+
+// digits strips the spaces of the ChaveAcesso and makes sure all remaining bytes are digits,
+// except for the CNPJ of the emitter, which may be alphanumeric.
+func (c ChaveAcesso) digits() (out [44]byte, ok bool) {
+	var j int
+	for i := range len(c) {
+		b := asciiLowerToUpper(c[i])
+		switch {
+		case isSpace(b):
+			continue
+		case j == len(out):
+			return out, false
+		case j >= 6 && j < 18:
+			if !isAlphaNumericalUpper(b) {
+				return out, false
+			}
+		case !isDigit(b):
+			return out, false
+		}
+		out[j] = b
+		j++
+	}
+	return out, j == len(out)
+}
+
+// chaveAcessoCheckDigit calculates the check digit with mod 11 and the weights 2 to 9, from right
+// to left. A rest of 0 or 1 becomes 0. Letters are worth their ASCII code minus 48, as in the CNPJ.
+func chaveAcessoCheckDigit(d []byte) byte {
+	var sum int
+	weight := 2
+	for i := len(d) - 1; i >= 0; i-- {
+		sum += int(d[i]-'0') * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+
+	rest := sum % 11
+	if rest < 2 {
+		return '0'
+	}
+	return byte(11-rest) + '0'
+}
+
+func chaveAcessoCNPJ(digits [44]byte) CNPJ {
+	cnpj := CNPJ(digits[6:20])
+	if !cnpj.IsValid() {
+		return ""
+	}
+	return cnpj
+}
+
+func chaveAcessoCPF(digits [44]byte) CPF {
+	if string(digits[6:9]) != "000" {
+		return ""
+	}
+	cpf := CPF(digits[9:20])
+	if !cpf.IsValid() {
+		return ""
+	}
+	return cpf
+}
+
+// UF returns the UF of the emitter.
+//
+// If the ChaveAcesso is invalid, 0 is returned.
+func (c ChaveAcesso) UF() address.UF {
+	if !c.IsValid() {
+		return 0
+	}
+	digits, _ := c.digits()
+	return address.UF(digitsToInt(digits[0:2]))
+}
+
+// Ano returns the year of emission.
+//
+// If the ChaveAcesso is invalid, 0 is returned.
+func (c ChaveAcesso) Ano() int {
+	if !c.IsValid() {
+		return 0
+	}
+	digits, _ := c.digits()
+	return 2000 + digitsToInt(digits[2:4])
+}
+
+// Mes returns the month of emission.
+//
+// If the ChaveAcesso is invalid, 0 is returned.
+func (c ChaveAcesso) Mes() time.Month {
+	if !c.IsValid() {
+		return 0
+	}
+	digits, _ := c.digits()
+	return time.Month(digitsToInt(digits[4:6]))
+}
+
+// CNPJ returns the CNPJ of the emitter, without punctuation.
+//
+// A CNPJ starting with 000 may also be read as a CPF, as the key does not tell which one the emitter
+// has. In that case, both CNPJ and CPF return a value.
+//
+// If the ChaveAcesso is invalid or its emitter has no CNPJ, an empty string is returned.
+func (c ChaveAcesso) CNPJ() CNPJ {
+	if !c.IsValid() {
+		return ""
+	}
+	digits, _ := c.digits()
+	return chaveAcessoCNPJ(digits)
+}
+
+// CPF returns the CPF of the emitter, without punctuation.
+//
+// If the ChaveAcesso is invalid or its emitter has no CPF, an empty string is returned.
+func (c ChaveAcesso) CPF() CPF {
+	if !c.IsValid() {
+		return ""
+	}
+	digits, _ := c.digits()
+	return chaveAcessoCPF(digits)
+}
+
+// Modelo returns the model of the document.
+//
+// If the ChaveAcesso is invalid, 0 is returned.
+func (c ChaveAcesso) Modelo() ModeloDocumento {
+	if !c.IsValid() {
+		return 0
+	}
+	digits, _ := c.digits()
+	return ModeloDocumento(digitsToInt(digits[20:22]))
+}
+
+// Serie returns the series of the document.
+//
+// If the ChaveAcesso is invalid, 0 is returned.
+func (c ChaveAcesso) Serie() int {
+	if !c.IsValid() {
+		return 0
+	}
+	digits, _ := c.digits()
+	return digitsToInt(digits[22:25])
+}
+
+// Numero returns the number of the document.
+//
+// If the ChaveAcesso is invalid, 0 is returned.
+func (c ChaveAcesso) Numero() int {
+	if !c.IsValid() {
+		return 0
+	}
+	digits, _ := c.digits()
+	return digitsToInt(digits[25:34])
+}
+
+// TipoEmissao returns how the document was emitted.
+//
+// If the ChaveAcesso is invalid, 0 is returned.
+func (c ChaveAcesso) TipoEmissao() TipoEmissao {
+	if !c.IsValid() {
+		return 0
+	}
+	digits, _ := c.digits()
+	return TipoEmissao(digits[34] - '0')
+}
+
+// CodigoNumerico returns the random code chosen by the emitter (cNF).
+//
+// If the ChaveAcesso is invalid, 0 is returned.
+func (c ChaveAcesso) CodigoNumerico() int {
+	if !c.IsValid() {
+		return 0
+	}
+	digits, _ := c.digits()
+	return digitsToInt(digits[35:43])
+}
+
+// Fields returns all the fields of the ChaveAcesso.
+//
+// Only one of CNPJ and CPF is set: CPF is set when the emitter is not a valid CNPJ.
+// If the ChaveAcesso is invalid, the zero value is returned.
+func (c ChaveAcesso) Fields() ChaveAcessoFields {
+	if !c.IsValid() {
+		return ChaveAcessoFields{}
+	}
+
+	digits, _ := c.digits()
+	f := ChaveAcessoFields{
+		UF:             address.UF(digitsToInt(digits[0:2])),
+		Ano:            2000 + digitsToInt(digits[2:4]),
+		Mes:            time.Month(digitsToInt(digits[4:6])),
+		CNPJ:           chaveAcessoCNPJ(digits),
+		Modelo:         ModeloDocumento(digitsToInt(digits[20:22])),
+		Serie:          digitsToInt(digits[22:25]),
+		Numero:         digitsToInt(digits[25:34]),
+		TipoEmissao:    TipoEmissao(digits[34] - '0'),
+		CodigoNumerico: digitsToInt(digits[35:43]),
+	}
+	if f.CNPJ == "" {
+		f.CPF = chaveAcessoCPF(digits)
+	}
+	return f
+}
+
+// Digits returns the 44 digits of the ChaveAcesso, without spaces.
+//
+// If the ChaveAcesso is invalid, an empty string is returned.
+func (c ChaveAcesso) Digits() string {
+	if !c.IsValid() {
+		return ""
+	}
+	digits, _ := c.digits()
+	return string(digits[:])
+}
+
+// String returns the formatted ChaveAcesso in 11 groups of 4 digits separated by spaces, as printed
+// on the DANFE.
+//
+// If the ChaveAcesso is invalid, an empty string is returned.
+func (c ChaveAcesso) String() string {
+	if !c.IsValid() {
+		return ""
+	}
+
+	digits, _ := c.digits()
+
+	out := make([]byte, 0, 54)
+	for i := 0; i < len(digits); i += 4 {
+		if i > 0 {
+			out = append(out, ' ')
+		}
+		out = append(out, digits[i:i+4]...)
+	}
+
+	return string(out)
+}
+
+// Value implements the driver.Valuer interface for ChaveAcesso.
+func (c ChaveAcesso) Value() (driver.Value, error) {
+	return c.String(), nil
+}
+
+// Scan implements the sql.Scanner interface for ChaveAcesso.
+func (c *ChaveAcesso) Scan(value any) error {
+	str, err := scanString("ChaveAcesso", value)
+	if err != nil {
+		return err
+	}
+
+	_c, err := NewChaveAcesso(str)
+	if err != nil {
+		return fmt.Errorf("br: can not scan %q into ChaveAcesso: %w", str, err)
+	}
+
+	*c = _c
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for ChaveAcesso.
+func (c ChaveAcesso) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + c.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for ChaveAcesso.
+func (c *ChaveAcesso) UnmarshalJSON(b []byte) error {
+	str, ok, err := unmarshalJSONString(b)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %s into ChaveAcesso: %w", b, err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	_c, err := NewChaveAcesso(str)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %q into ChaveAcesso: %w", str, err)
+	}
+
+	*c = _c
+	return nil
+}
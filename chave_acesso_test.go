@@ -0,0 +1,348 @@
+package br
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/phenpessoa/br/x/address"
+)
+
+var chaveAcessoSink ChaveAcesso
+
+func BenchmarkGenerateChaveAcesso(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		chaveAcessoSink = GenerateChaveAcesso()
+	}
+}
+
+func TestGenerateChaveAcesso(t *testing.T) {
+	for range 100_000 {
+		c := GenerateChaveAcesso()
+		if !c.IsValid() {
+			t.Fatalf("invalid ChaveAcesso generated: %s", string(c))
+		}
+
+		got, err := NewChaveAcessoFromFields(c.Fields())
+		if err != nil {
+			t.Fatalf("failed to rebuild ChaveAcesso %s: %v", c, err)
+		}
+
+		if got != c {
+			t.Fatalf("\nwant: %s\ngot: %s", c, got)
+		}
+	}
+}
+
+func BenchmarkChaveAcesso_IsValid(b *testing.B) {
+	const c = ChaveAcesso("35170611222333000181550010000123451123456787")
+	if !c.IsValid() {
+		b.Error("invalid chave de acesso on benchmark")
+		b.FailNow()
+	}
+	b.ReportAllocs()
+	for range b.N {
+		boolSink = c.IsValid()
+	}
+}
+
+func TestChaveAcesso_IsValid(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		c     ChaveAcesso
+		valid bool
+	}{
+		{name: "nfe", c: "35170611222333000181550010000123451123456787", valid: true},
+		{name: "nfce with cpf", c: "43240100012345678909650010000000019123456781", valid: true},
+		{name: "alphanumeric cnpj", c: "35260712ABC34501DE35550010000000011000000014", valid: true},
+		{name: "lowercase alphanumeric cnpj", c: "35260712abc34501de35550010000000011000000014", valid: true},
+		{name: "formatted", c: "3517 0611 2223 3300 0181 5500 1000 0123 4511 2345 6787", valid: true},
+		{name: "invalid check digit", c: "35170611222333000181550010000123451123456788", valid: false},
+		{name: "invalid uf", c: "99170611222333000181550010000123451123456787", valid: false},
+		{name: "invalid month", c: "35171311222333000181550010000123451123456787", valid: false},
+		{name: "invalid model", c: "35170611222333000181560010000123451123456787", valid: false},
+		{name: "invalid emission type", c: "35170611222333000181550010000123450123456787", valid: false},
+		{name: "invalid cnpj", c: "35170611222333000182550010000123451123456787", valid: false},
+		{name: "letter outside cnpj", c: "3517061122233300018155001000012345112345678A", valid: false},
+		{name: "punctuated", c: "3517.0611.2223.3300.0181.5500.1000.0123.4511.2345.6787", valid: false},
+		{name: "short", c: "3517061122233300018155001000012345112345678", valid: false},
+		{name: "long", c: "351706112223330001815500100001234511234567870", valid: false},
+		{name: "empty", c: "", valid: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.c.IsValid() != tc.valid {
+				t.Errorf("\nchave de acesso: %s\nshould be valid: %v\nis valid: %v", tc.c, tc.valid, tc.c.IsValid())
+			}
+		})
+	}
+}
+
+func TestChaveAcesso_Fields(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		c    ChaveAcesso
+		want ChaveAcessoFields
+	}{
+		{
+			name: "nfe",
+			c:    "35170611222333000181550010000123451123456787",
+			want: ChaveAcessoFields{
+				UF: address.SP, Ano: 2017, Mes: time.June, CNPJ: "11222333000181", Modelo: ModeloNFe,
+				Serie: 1, Numero: 12345, TipoEmissao: EmissaoNormal, CodigoNumerico: 12345678,
+			},
+		},
+		{
+			name: "nfce with cpf",
+			c:    "43240100012345678909650010000000019123456781",
+			want: ChaveAcessoFields{
+				UF: address.RS, Ano: 2024, Mes: time.January, CPF: "12345678909", Modelo: ModeloNFCe,
+				Serie: 1, Numero: 1, TipoEmissao: EmissaoContingenciaOffline, CodigoNumerico: 12345678,
+			},
+		},
+		{
+			name: "alphanumeric cnpj",
+			c:    "35260712abc34501de35550010000000011000000014",
+			want: ChaveAcessoFields{
+				UF: address.SP, Ano: 2026, Mes: time.July, CNPJ: "12ABC34501DE35", Modelo: ModeloNFe,
+				Serie: 1, Numero: 1, TipoEmissao: EmissaoNormal, CodigoNumerico: 1,
+			},
+		},
+		{
+			name: "invalid",
+			c:    "35170611222333000181550010000123451123456788",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.c.Fields(); got != tc.want {
+				t.Errorf("\nwant: %+v\ngot: %+v", tc.want, got)
+			}
+
+			if got := tc.c.UF(); got != tc.want.UF {
+				t.Errorf("\nwant uf: %s\ngot uf: %s", tc.want.UF, got)
+			}
+
+			if got := tc.c.Ano(); got != tc.want.Ano {
+				t.Errorf("\nwant ano: %d\ngot ano: %d", tc.want.Ano, got)
+			}
+
+			if got := tc.c.Mes(); got != tc.want.Mes {
+				t.Errorf("\nwant mes: %d\ngot mes: %d", tc.want.Mes, got)
+			}
+
+			if got := tc.c.CNPJ(); got != tc.want.CNPJ {
+				t.Errorf("\nwant cnpj: %s\ngot cnpj: %s", tc.want.CNPJ, got)
+			}
+
+			if got := tc.c.CPF(); got != tc.want.CPF {
+				t.Errorf("\nwant cpf: %s\ngot cpf: %s", tc.want.CPF, got)
+			}
+
+			if got := tc.c.Modelo(); got != tc.want.Modelo {
+				t.Errorf("\nwant modelo: %s\ngot modelo: %s", tc.want.Modelo, got)
+			}
+
+			if got := tc.c.Serie(); got != tc.want.Serie {
+				t.Errorf("\nwant serie: %d\ngot serie: %d", tc.want.Serie, got)
+			}
+
+			if got := tc.c.Numero(); got != tc.want.Numero {
+				t.Errorf("\nwant numero: %d\ngot numero: %d", tc.want.Numero, got)
+			}
+
+			if got := tc.c.TipoEmissao(); got != tc.want.TipoEmissao {
+				t.Errorf("\nwant tipo emissao: %d\ngot tipo emissao: %d", tc.want.TipoEmissao, got)
+			}
+
+			if got := tc.c.CodigoNumerico(); got != tc.want.CodigoNumerico {
+				t.Errorf("\nwant codigo numerico: %d\ngot codigo numerico: %d", tc.want.CodigoNumerico, got)
+			}
+		})
+	}
+}
+
+func TestChaveAcesso_CNPJStartingWithZeros(t *testing.T) {
+	// The CNPJ of the Banco do Brasil is also read as the CPF 00000000191.
+	c, err := NewChaveAcessoFromFields(ChaveAcessoFields{
+		UF: address.DF, Ano: 2025, Mes: time.March, CNPJ: "00.000.000/0001-91", Modelo: ModeloNFe,
+		Serie: 1, Numero: 1, TipoEmissao: EmissaoNormal, CodigoNumerico: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := c.CNPJ(); got != "00000000000191" {
+		t.Errorf("\nwant: %s\ngot: %s", "00000000000191", got)
+	}
+
+	if got := c.CPF(); got != "00000000191" {
+		t.Errorf("\nwant: %s\ngot: %s", "00000000191", got)
+	}
+
+	if got := c.Fields(); got.CNPJ != "00000000000191" || got.CPF != "" {
+		t.Errorf("unexpected fields: %+v", got)
+	}
+}
+
+func TestNewChaveAcessoFromFields(t *testing.T) {
+	valid := ChaveAcessoFields{
+		UF: address.SP, Ano: 2017, Mes: time.June, CNPJ: "11.222.333/0001-81", Modelo: ModeloNFe,
+		Serie: 1, Numero: 12345, TipoEmissao: EmissaoNormal, CodigoNumerico: 12345678,
+	}
+
+	c, err := NewChaveAcessoFromFields(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := ChaveAcesso("35170611222333000181550010000123451123456787"); c != want {
+		t.Errorf("\nwant: %s\ngot: %s", want, c)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		edit  func(f *ChaveAcessoFields)
+		field string
+		err   error
+	}{
+		{"uf", func(f *ChaveAcessoFields) { f.UF = 0 }, "uf", ErrDocumentoCharacter},
+		{"uf zz", func(f *ChaveAcessoFields) { f.UF = address.ZZ }, "uf", ErrDocumentoCharacter},
+		{"ano", func(f *ChaveAcessoFields) { f.Ano = 17 }, "ano", ErrDocumentoLength},
+		{"mes", func(f *ChaveAcessoFields) { f.Mes = 13 }, "mes", ErrDocumentoCharacter},
+		{"no emitter", func(f *ChaveAcessoFields) { f.CNPJ = "" }, "emitente", ErrDocumentoLength},
+		{"cnpj and cpf", func(f *ChaveAcessoFields) { f.CPF = "12345678909" }, "emitente", ErrDocumentoCharacter},
+		{"cnpj", func(f *ChaveAcessoFields) { f.CNPJ = "11222333000182" }, "cnpj", ErrDocumentoCheckDigit},
+		{"cpf", func(f *ChaveAcessoFields) { f.CNPJ, f.CPF = "", "12345678900" }, "cpf", ErrDocumentoCheckDigit},
+		{"modelo", func(f *ChaveAcessoFields) { f.Modelo = 1 }, "modelo", ErrDocumentoCharacter},
+		{"serie", func(f *ChaveAcessoFields) { f.Serie = 1000 }, "serie", ErrDocumentoLength},
+		{"numero", func(f *ChaveAcessoFields) { f.Numero = 0 }, "numero", ErrDocumentoLength},
+		{"tipo emissao", func(f *ChaveAcessoFields) { f.TipoEmissao = 0 }, "tipo emissao", ErrDocumentoCharacter},
+		{"codigo numerico", func(f *ChaveAcessoFields) { f.CodigoNumerico = -1 }, "codigo numerico", ErrDocumentoLength},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := valid
+			tc.edit(&f)
+
+			_, err := NewChaveAcessoFromFields(f)
+			if !errors.Is(err, ErrInvalidChaveAcesso) || !errors.Is(err, tc.err) {
+				t.Fatalf("\nwant: %v\ngot: %v", tc.err, err)
+			}
+
+			var docErr *DocumentoError
+			if !errors.As(err, &docErr) || docErr.Field != tc.field {
+				t.Errorf("\nwant field: %s\ngot: %v", tc.field, err)
+			}
+		})
+	}
+
+	c, err = NewChaveAcessoFromFields(ChaveAcessoFields{
+		UF: address.BA, Ano: 2024, Mes: time.December, CPF: "123.456.789-09", Modelo: ModeloNFe,
+		Serie: 2, Numero: 777, TipoEmissao: EmissaoNormal, CodigoNumerico: 42,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := ChaveAcesso("29241200012345678909550020000007771000000420"); c != want {
+		t.Errorf("\nwant: %s\ngot: %s", want, c)
+	}
+}
+
+func TestChaveAcesso_String(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		c      ChaveAcesso
+		want   string
+		digits string
+	}{
+		{
+			name:   "raw",
+			c:      "35170611222333000181550010000123451123456787",
+			want:   "3517 0611 2223 3300 0181 5500 1000 0123 4511 2345 6787",
+			digits: "35170611222333000181550010000123451123456787",
+		},
+		{
+			name:   "formatted",
+			c:      "3517 0611 2223 3300 0181 5500 1000 0123 4511 2345 6787",
+			want:   "3517 0611 2223 3300 0181 5500 1000 0123 4511 2345 6787",
+			digits: "35170611222333000181550010000123451123456787",
+		},
+		{
+			name:   "alphanumeric cnpj",
+			c:      "35260712abc34501de35550010000000011000000014",
+			want:   "3526 0712 ABC3 4501 DE35 5500 1000 0000 0110 0000 0014",
+			digits: "35260712ABC34501DE35550010000000011000000014",
+		},
+		{
+			name: "invalid",
+			c:    "35170611222333000181550010000123451123456788",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.c.String(); got != tc.want {
+				t.Errorf(
+					"\nchave de acesso: %s\nshould be formatted like: %s\nis formatted like: %s",
+					tc.c, tc.want, got,
+				)
+			}
+
+			if got := tc.c.Digits(); got != tc.digits {
+				t.Errorf("\nwant: %s\ngot: %s", tc.digits, got)
+			}
+		})
+	}
+}
+
+func TestModeloDocumento_String(t *testing.T) {
+	for m, want := range map[ModeloDocumento]string{
+		ModeloNFe:  "NF-e",
+		ModeloCTe:  "CT-e",
+		ModeloMDFe: "MDF-e",
+		ModeloNFCe: "NFC-e",
+		1:          "",
+	} {
+		if got := m.String(); got != want {
+			t.Errorf("\nwant: %s\ngot: %s", want, got)
+		}
+	}
+}
+
+func TestChaveAcesso_JSON(t *testing.T) {
+	data, err := json.Marshal(ChaveAcesso("35170611222333000181550010000123451123456787"))
+	if err != nil {
+		t.Fatalf("failed to marshal chave de acesso: %v", err)
+	}
+
+	if want := `"3517 0611 2223 3300 0181 5500 1000 0123 4511 2345 6787"`; string(data) != want {
+		t.Errorf("\nwant: %s\ngot: %s", want, data)
+	}
+
+	var c ChaveAcesso
+	if err := json.Unmarshal(data, &c); err != nil {
+		t.Fatalf("failed to unmarshal chave de acesso: %v", err)
+	}
+
+	if c.Digits() != "35170611222333000181550010000123451123456787" {
+		t.Errorf("unmarshaled wrong chave de acesso: %s", c)
+	}
+
+	if err := json.Unmarshal([]byte(`"35170611222333000181550010000123451123456788"`), &c); err == nil {
+		t.Error("invalid chave de acesso unmarshaled without error")
+	}
+}
+
+func TestChaveAcesso_Scan(t *testing.T) {
+	var c ChaveAcesso
+	if err := c.Scan([]byte("35170611222333000181550010000123451123456787")); err != nil {
+		t.Fatalf("failed to scan chave de acesso: %v", err)
+	}
+
+	if c != ChaveAcesso("35170611222333000181550010000123451123456787") {
+		t.Errorf("scanned wrong chave de acesso: %s", c)
+	}
+
+	if err := c.Scan("35170611222333000181550010000123451123456788"); err == nil {
+		t.Error("invalid chave de acesso scanned without error")
+	}
+}
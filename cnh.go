@@ -1,7 +1,9 @@
 package br
 
 import (
+	"database/sql/driver"
 	"errors"
+	"fmt"
 )
 
 // CNH represents a Brazilian driver's license number.
@@ -39,26 +41,72 @@ var ErrInvalidCNH = errors.New("br: invalid cnh")
 var cnhFirstTable = []int{2, 3, 4, 5, 6, 7, 8, 9, 10}
 
 // IsValid checks whether the provided CNH is valid based on its checksum digits.
+//
+// The formats accepted are: XXXXXXXXXXX, XXXXXXXXX-XX, XXX.XXX.XXX-XX and XXX XXX XXX XX.
 func (cnh CNH) IsValid() bool {
 	switch len(cnh) {
 	case 11:
-		dByte, cacheSum, ok := cnhIterFirst(cnh)
+		return cnhIsValid11(cnh)
+	case 12, 14:
+		digits, ok := cnh.digits()
 		if !ok {
 			return false
 		}
+		return cnhIsValid11(digits[:])
+	default:
+		return false
+	}
+}
 
-		if cnh[len(cnh)-2] != dByte {
-			return false
+func cnhIsValid11[T string | CNH | []byte](cnh T) bool {
+	dByte, cacheSum, ok := cnhIterFirst(cnh)
+	if !ok {
+		return false
+	}
+
+	if cnh[len(cnh)-2] != dByte {
+		return false
+	}
+
+	dByte, ok = cnhIterSecond(cnh, cacheSum)
+	if !ok {
+		return false
+	}
+
+	return cnh[len(cnh)-1] == dByte
+}
+
+// digits strips the punctuation of a 12 or 14 length CNH.
+//
+// It does not validate that the returned bytes are digits, that is left to the checksum functions.
+func (cnh CNH) digits() (out [11]byte, ok bool) {
+	switch len(cnh) {
+	case 11:
+		copy(out[:], cnh)
+		return out, true
+	case 12:
+		if cnh[9] != '-' {
+			return out, false
 		}
 
-		dByte, ok = cnhIterSecond(cnh, cacheSum)
-		if !ok {
-			return false
+		copy(out[:9], cnh[:9])
+		copy(out[9:], cnh[10:])
+		return out, true
+	case 14:
+		switch {
+		case cnh[3] == '.' && cnh[7] == '.' && cnh[11] == '-':
+		case isSpace(cnh[3]) && isSpace(cnh[7]) && isSpace(cnh[11]):
+		default:
+			return out, false
 		}
 
-		return cnh[len(cnh)-1] == dByte
+		copy(out[0:3], cnh[0:3])
+		copy(out[3:6], cnh[4:7])
+		copy(out[6:9], cnh[8:11])
+		copy(out[9:11], cnh[12:14])
+		return out, true
 	default:
-		return false
+		return out, false
 	}
 }
 
@@ -110,10 +158,62 @@ func cnhIterSecond[T string | CNH | []byte](cnh T, sum int) (byte, bool) {
 	return byte(out) + '0', true
 }
 
-// String returns the string representation of CNH.
+// String returns the CNH as its 11 digits, without any punctuation.
 func (cnh CNH) String() string {
 	if !cnh.IsValid() {
 		return ""
 	}
-	return string(cnh)
+
+	if len(cnh) == 11 {
+		return string(cnh)
+	}
+
+	digits, _ := cnh.digits()
+	return string(digits[:])
+}
+
+// Value implements the driver.Valuer interface for CNH.
+func (cnh CNH) Value() (driver.Value, error) {
+	return cnh.String(), nil
+}
+
+// Scan implements the sql.Scanner interface for CNH.
+func (cnh *CNH) Scan(value any) error {
+	str, err := scanString("CNH", value)
+	if err != nil {
+		return err
+	}
+
+	_cnh, err := NewCNH(str)
+	if err != nil {
+		return fmt.Errorf("br: can not scan %q into CNH: %w", str, err)
+	}
+
+	*cnh = _cnh
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for CNH.
+func (cnh CNH) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + cnh.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for CNH.
+func (cnh *CNH) UnmarshalJSON(b []byte) error {
+	str, ok, err := unmarshalJSONString(b)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %s into CNH: %w", b, err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	_cnh, err := NewCNH(str)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %q into CNH: %w", str, err)
+	}
+
+	*cnh = _cnh
+	return nil
 }
@@ -1,6 +1,9 @@
 package br
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
+)
 
 var cnhSink CNH
 
@@ -101,6 +104,36 @@ func TestCNH_IsValid(t *testing.T) {
 			cnh:   CNH("aaaaaaaaaaa"),
 			valid: false,
 		},
+		{
+			name:  "dashed CNH",
+			cnh:   CNH("963006898-42"),
+			valid: true,
+		},
+		{
+			name:  "dotted CNH",
+			cnh:   CNH("963.006.898-42"),
+			valid: true,
+		},
+		{
+			name:  "spaced CNH",
+			cnh:   CNH("963 006 898 42"),
+			valid: true,
+		},
+		{
+			name:  "invalid digit dotted CNH",
+			cnh:   CNH("963.006.898-43"),
+			valid: false,
+		},
+		{
+			name:  "invalid separators",
+			cnh:   CNH("963-006-898.42"),
+			valid: false,
+		},
+		{
+			name:  "mixed separators",
+			cnh:   CNH("963.006 898-42"),
+			valid: false,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			if tc.cnh.IsValid() != tc.valid {
@@ -124,6 +157,21 @@ func TestCNH_String(t *testing.T) {
 			cnh:  CNH("96300689842"),
 			want: "96300689842",
 		},
+		{
+			name: "dashed CNH",
+			cnh:  CNH("963006898-42"),
+			want: "96300689842",
+		},
+		{
+			name: "dotted CNH",
+			cnh:  CNH("963.006.898-42"),
+			want: "96300689842",
+		},
+		{
+			name: "spaced CNH",
+			cnh:  CNH("963 006 898 42"),
+			want: "96300689842",
+		},
 		{
 			name: "empty CNH",
 			cnh:  CNH(""),
@@ -145,3 +193,76 @@ func TestCNH_String(t *testing.T) {
 		})
 	}
 }
+
+func TestCNH_Scan(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		value   any
+		want    CNH
+		wantErr bool
+	}{
+		{
+			name:  "string",
+			value: "96300689842",
+			want:  CNH("96300689842"),
+		},
+		{
+			name:  "bytes",
+			value: []byte("963.006.898-42"),
+			want:  CNH("963.006.898-42"),
+		},
+		{
+			name:    "invalid",
+			value:   "96300689843",
+			wantErr: true,
+		},
+		{
+			name:    "unknown type",
+			value:   int64(96300689842),
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cnh CNH
+			err := cnh.Scan(tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\nvalue: %v\nshould err: %v\nerr: %v", tc.value, tc.wantErr, err)
+			}
+			if cnh != tc.want {
+				t.Errorf("\nvalue: %v\nwant: %s\ngot: %s", tc.value, tc.want, cnh)
+			}
+		})
+	}
+}
+
+func TestCNH_JSON(t *testing.T) {
+	type person struct {
+		CNH CNH `json:"cnh"`
+	}
+
+	data, err := json.Marshal(person{CNH: CNH("963.006.898-42")})
+	if err != nil {
+		t.Fatalf("failed to marshal cnh: %v", err)
+	}
+
+	if want := `{"cnh":"96300689842"}`; string(data) != want {
+		t.Errorf("\nwant: %s\ngot: %s", want, data)
+	}
+
+	var p person
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("failed to unmarshal cnh: %v", err)
+	}
+
+	if p.CNH != CNH("96300689842") {
+		t.Errorf("unmarshaled wrong cnh: %s", p.CNH)
+	}
+
+	if err := json.Unmarshal([]byte(`{"cnh":"96300689843"}`), &p); err == nil {
+		t.Error("invalid cnh unmarshaled without error")
+	}
+
+	if err := json.Unmarshal([]byte(`{"cnh":null}`), &p); err != nil {
+		t.Errorf("failed to unmarshal null cnh: %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+package br
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+// CNO represents a Brazilian CNO (Cadastro Nacional de Obras), the registration of a construction site.
+type CNO string
+
+// CEI represents a Brazilian CEI (Cadastro Específico do INSS).
+//
+// The CNO replaced the CEI and kept its format and check digit, so they are the same type.
+type CEI = CNO
+
+// NewCNO creates a new CNO instance from a string representation.
+//
+// It verifies the CNO's validity using its checksum digit.
+func NewCNO(s string) (CNO, error) {
+	cno := CNO(s)
+	if !cno.IsValid() {
+		return "", ErrInvalidCNO
+	}
+	return cno, nil
+}
+
+// NewCEI creates a new CEI instance from a string representation.
+//
+// It is the same as NewCNO.
+func NewCEI(s string) (CEI, error) {
+	return NewCNO(s)
+}
+
+// GenerateCNO generates a pseudo-random valid CNO.
+func GenerateCNO() CNO {
+	data := make([]byte, 15)
+	data[2] = '.'
+	data[6] = '.'
+	data[12] = '/'
+
+	for i := range 2 {
+		data[i] = randomDigit()
+	}
+
+	for i := 3; i < 6; i++ {
+		data[i] = randomDigit()
+	}
+
+	for i := 7; i < 12; i++ {
+		data[i] = randomDigit()
+	}
+
+	data[13] = randomDigit()
+
+	digits, _ := CNO(data).digits()
+	data[14] = cnoCheckDigit(digits)
+
+	return CNO(string(data))
+}
+
+// GenerateCEI generates a pseudo-random valid CEI.
+//
+// It is the same as GenerateCNO.
+func GenerateCEI() CEI {
+	return GenerateCNO()
+}
+
+// ErrInvalidCNO is an error returned when an invalid CNO or CEI is encountered.
+var ErrInvalidCNO = errors.New("br: invalid cno")
+
+var cnoTable = []int{7, 4, 1, 8, 5, 2, 1, 6, 3, 7, 4}
+
+// IsValid checks whether the provided CNO is valid based on its checksum digit.
+//
+// The formats accepted are: XXXXXXXXXXXX and XX.XXX.XXXXX/XX.
+func (cno CNO) IsValid() bool {
+	digits, ok := cno.digits()
+	if !ok {
+		return false
+	}
+	return digits[11] == cnoCheckDigit(digits)
+}
+
+// digits strips the punctuation of the CNO and makes sure all remaining bytes are digits.
+func (cno CNO) digits() (out [12]byte, ok bool) {
+	switch len(cno) {
+	case 12:
+		copy(out[:], cno)
+	case 15:
+		if cno[2] != '.' || cno[6] != '.' || cno[12] != '/' {
+			return out, false
+		}
+
+		copy(out[0:2], cno[0:2])
+		copy(out[2:5], cno[3:6])
+		copy(out[5:10], cno[7:12])
+		copy(out[10:12], cno[13:15])
+	default:
+		return out, false
+	}
+
+	for _, d := range out {
+		if !isDigit(d) {
+			return out, false
+		}
+	}
+
+	return out, true
+}
+
+// cnoCheckDigit calculates the CNO check digit.
+//
+// The tens and units of the weighted sum are added, and the check digit is what is
+// left to reach the next multiple of 10.
+func cnoCheckDigit(digits [12]byte) byte {
+	var sum int
+	for i, d := range cnoTable {
+		sum += d * int(digits[i]-'0')
+	}
+
+	sum %= 100
+	sum = sum/10 + sum%10
+
+	return byte((10-sum%10)%10) + '0'
+}
+
+// String returns the formatted CNO string with punctuation as XX.XXX.XXXXX/XX.
+func (cno CNO) String() string {
+	if !cno.IsValid() {
+		return ""
+	}
+
+	if len(cno) == 15 {
+		return string(cno)
+	}
+
+	out := make([]byte, 15)
+	out[2] = '.'
+	out[6] = '.'
+	out[12] = '/'
+
+	copy(out[0:2], cno[0:2])
+	copy(out[3:6], cno[2:5])
+	copy(out[7:12], cno[5:10])
+	copy(out[13:15], cno[10:12])
+
+	return string(out)
+}
+
+// Value implements the driver.Valuer interface for CNO.
+func (cno CNO) Value() (driver.Value, error) {
+	return cno.String(), nil
+}
+
+// Scan implements the sql.Scanner interface for CNO.
+func (cno *CNO) Scan(value any) error {
+	str, err := scanString("CNO", value)
+	if err != nil {
+		return err
+	}
+
+	_cno, err := NewCNO(str)
+	if err != nil {
+		return fmt.Errorf("br: can not scan %q into CNO: %w", str, err)
+	}
+
+	*cno = _cno
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for CNO.
+func (cno CNO) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + cno.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for CNO.
+func (cno *CNO) UnmarshalJSON(b []byte) error {
+	str, ok, err := unmarshalJSONString(b)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %s into CNO: %w", b, err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	_cno, err := NewCNO(str)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %q into CNO: %w", str, err)
+	}
+
+	*cno = _cno
+	return nil
+}
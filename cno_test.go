@@ -0,0 +1,121 @@
+package br
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGenerateCNO(t *testing.T) {
+	for range 1_000_000 {
+		if cno := GenerateCNO(); !cno.IsValid() {
+			t.Errorf("invalid CNO generated: %s", string(cno))
+		}
+	}
+}
+
+func TestCNO_IsValid(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		cno   CNO
+		valid bool
+	}{
+		{
+			name:  "formatted CNO",
+			cno:   CNO("11.583.00249/85"),
+			valid: true,
+		},
+		{
+			name:  "raw CNO",
+			cno:   CNO("115830024985"),
+			valid: true,
+		},
+		{
+			name:  "raw CNO check digit zero",
+			cno:   CNO("123456789010"),
+			valid: true,
+		},
+		{
+			name:  "invalid digit",
+			cno:   CNO("11.583.00249/86"),
+			valid: false,
+		},
+		{
+			name:  "invalid separators",
+			cno:   CNO("11.583.00249-85"),
+			valid: false,
+		},
+		{
+			name:  "invalid characters",
+			cno:   CNO("aaaaaaaaaaaa"),
+			valid: false,
+		},
+		{
+			name:  "empty",
+			cno:   CNO(""),
+			valid: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.cno.IsValid() != tc.valid {
+				t.Errorf(
+					"\ncno: %s\nshould be valid: %v\nis valid: %v",
+					tc.cno, tc.valid, tc.cno.IsValid(),
+				)
+			}
+		})
+	}
+}
+
+func TestCNO_String(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		cno  CNO
+		want string
+	}{
+		{
+			name: "raw CNO",
+			cno:  CNO("115830024985"),
+			want: "11.583.00249/85",
+		},
+		{
+			name: "formatted CNO",
+			cno:  CNO("11.583.00249/85"),
+			want: "11.583.00249/85",
+		},
+		{
+			name: "invalid",
+			cno:  CNO("115830024986"),
+			want: "",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.cno.String() != tc.want {
+				t.Errorf(
+					"\ncno: %s\nshould be formatted like: %s\nis formatted like: %s",
+					tc.cno, tc.want, tc.cno.String(),
+				)
+			}
+		})
+	}
+}
+
+func TestCEI(t *testing.T) {
+	cei, err := NewCEI("11.583.00249/85")
+	if err != nil {
+		t.Fatalf("failed to create cei: %v", err)
+	}
+
+	data, err := json.Marshal(cei)
+	if err != nil {
+		t.Fatalf("failed to marshal cei: %v", err)
+	}
+
+	var cno CNO
+	if err := json.Unmarshal(data, &cno); err != nil {
+		t.Fatalf("failed to unmarshal cei into cno: %v", err)
+	}
+
+	if cno != cei {
+		t.Errorf("\nwant: %s\ngot: %s", cei, cno)
+	}
+}
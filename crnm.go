@@ -0,0 +1,145 @@
+package br
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+// CRNM represents the registration number of a foreign resident in Brazil, printed on the
+// Carteira de Registro Nacional Migratório and, before it, on the RNE.
+//
+// It has a letter, 6 digits and a check character, such as V123456-K.
+type CRNM string
+
+// RNE is the former name of the CRNM, which kept its number.
+type RNE = CRNM
+
+// NewCRNM creates a new CRNM instance from a string representation.
+//
+// The returned error is always a *DocumentoError.
+func NewCRNM(s string) (CRNM, error) {
+	crnm := CRNM(s)
+	if err := crnm.Validate(); err != nil {
+		return "", err
+	}
+	return crnm, nil
+}
+
+// ErrInvalidCRNM is an error returned when an invalid CRNM is encountered.
+var ErrInvalidCRNM = errors.New("br: invalid crnm")
+
+// IsValid checks whether the provided CRNM is valid.
+func (crnm CRNM) IsValid() bool {
+	return crnm.Validate() == nil
+}
+
+// Validate checks whether the provided CRNM is valid, reporting why it is not.
+//
+// Case, dots, dashes and spaces are ignored.
+//
+// The algorithm of the check character is not public, so only the structure is validated.
+func (crnm CRNM) Validate() error {
+	_, err := crnm.normalize()
+	return err
+}
+
+func (crnm CRNM) normalize() ([8]byte, error) {
+	var (
+		out [8]byte
+		j   int
+	)
+
+	for i := range len(crnm) {
+		c := asciiLowerToUpper(crnm[i])
+		switch {
+		case c == '-' || c == '.' || c == ' ':
+			continue
+		case j == len(out):
+			return out, &DocumentoError{Doc: ErrInvalidCRNM, Err: ErrDocumentoLength}
+		}
+		out[j] = c
+		j++
+	}
+
+	if j != len(out) {
+		return out, &DocumentoError{Doc: ErrInvalidCRNM, Err: ErrDocumentoLength}
+	}
+
+	if !isAlphaUpper(out[0]) {
+		return out, &DocumentoError{Doc: ErrInvalidCRNM, Field: "letra", Err: ErrDocumentoCharacter}
+	}
+
+	for _, c := range out[1:7] {
+		if !isDigit(c) {
+			return out, &DocumentoError{Doc: ErrInvalidCRNM, Field: "numero", Err: ErrDocumentoCharacter}
+		}
+	}
+
+	if !isAlphaNumericalUpper(out[7]) {
+		return out, &DocumentoError{Doc: ErrInvalidCRNM, Field: "digito", Err: ErrDocumentoCharacter}
+	}
+
+	return out, nil
+}
+
+// String returns the CRNM formatted as X999999-X, in uppercase.
+func (crnm CRNM) String() string {
+	d, err := crnm.normalize()
+	if err != nil {
+		return ""
+	}
+
+	out := make([]byte, 9)
+	copy(out[:7], d[:7])
+	out[7] = '-'
+	out[8] = d[7]
+
+	return string(out)
+}
+
+// Value implements the driver.Valuer interface for CRNM.
+func (crnm CRNM) Value() (driver.Value, error) {
+	return crnm.String(), nil
+}
+
+// Scan implements the sql.Scanner interface for CRNM.
+func (crnm *CRNM) Scan(value any) error {
+	str, err := scanString("CRNM", value)
+	if err != nil {
+		return err
+	}
+
+	_crnm, err := NewCRNM(str)
+	if err != nil {
+		return fmt.Errorf("br: can not scan %q into CRNM: %w", str, err)
+	}
+
+	*crnm = _crnm
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for CRNM.
+func (crnm CRNM) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + crnm.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for CRNM.
+func (crnm *CRNM) UnmarshalJSON(b []byte) error {
+	str, ok, err := unmarshalJSONString(b)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %s into CRNM: %w", b, err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	_crnm, err := NewCRNM(str)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %q into CRNM: %w", str, err)
+	}
+
+	*crnm = _crnm
+	return nil
+}
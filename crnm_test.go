@@ -0,0 +1,70 @@
+package br
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestCRNM_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		crnm  CRNM
+		want  string
+		field string
+		err   error
+	}{
+		{name: "formatted", crnm: "V123456-K", want: "V123456-K"},
+		{name: "raw", crnm: "V1234560", want: "V123456-0"},
+		{name: "lower", crnm: "v123456-k", want: "V123456-K"},
+		{name: "dotted", crnm: "V.123.456-K", want: "V123456-K"},
+		{name: "short", crnm: "V12345-K", err: ErrDocumentoLength},
+		{name: "long", crnm: "V1234567-K", err: ErrDocumentoLength},
+		{name: "digit first", crnm: "1123456-K", field: "letra", err: ErrDocumentoCharacter},
+		{name: "letter in numero", crnm: "V12A456-K", field: "numero", err: ErrDocumentoCharacter},
+		{name: "symbol check", crnm: "V123456-*", field: "digito", err: ErrDocumentoCharacter},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.crnm.Validate()
+			if tc.err == nil {
+				if err != nil {
+					t.Fatalf("\ncrnm: %s\nshould be valid, err: %v", tc.crnm, err)
+				}
+
+				if tc.crnm.String() != tc.want {
+					t.Errorf("\ncrnm: %s\nwant: %s\ngot: %s", tc.crnm, tc.want, tc.crnm.String())
+				}
+				return
+			}
+
+			if !errors.Is(err, tc.err) || !errors.Is(err, ErrInvalidCRNM) {
+				t.Fatalf("\ncrnm: %s\nwant err: %v\ngot err: %v", tc.crnm, tc.err, err)
+			}
+
+			var docErr *DocumentoError
+			if !errors.As(err, &docErr) || docErr.Field != tc.field {
+				t.Errorf("\ncrnm: %s\nwant field: %s\ngot err: %v", tc.crnm, tc.field, err)
+			}
+		})
+	}
+}
+
+func TestCRNM_JSON(t *testing.T) {
+	data, err := json.Marshal(RNE("v1234560"))
+	if err != nil {
+		t.Fatalf("failed to marshal crnm: %v", err)
+	}
+
+	if want := `"V123456-0"`; string(data) != want {
+		t.Errorf("\nwant: %s\ngot: %s", want, data)
+	}
+
+	var crnm CRNM
+	if err := json.Unmarshal(data, &crnm); err != nil {
+		t.Fatalf("failed to unmarshal crnm: %v", err)
+	}
+
+	if err := json.Unmarshal([]byte(`"V12345"`), &crnm); err == nil {
+		t.Error("invalid crnm unmarshaled without error")
+	}
+}
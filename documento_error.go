@@ -0,0 +1,42 @@
+package br
+
+import "errors"
+
+var (
+	// ErrDocumentoLength is returned when a document does not have the expected length.
+	ErrDocumentoLength = errors.New("br: invalid document length")
+
+	// ErrDocumentoCharacter is returned when a document has an unexpected character.
+	ErrDocumentoCharacter = errors.New("br: invalid document character")
+
+	// ErrDocumentoCheckDigit is returned when a check digit of a document does not match.
+	ErrDocumentoCheckDigit = errors.New("br: invalid document check digit")
+)
+
+// DocumentoError is the error returned when a CRNM, a Passaporte, an MRZ, a ChaveAcesso or a bank
+// account fails validation.
+//
+// It matches both Doc and Err with errors.Is.
+type DocumentoError struct {
+	// Doc is the error of the document, such as ErrInvalidPassaporte.
+	Doc error
+
+	// Field is the part of the document that failed validation, such as "numero".
+	Field string
+
+	// Err is the reason the validation failed.
+	//
+	// It is one of ErrDocumentoLength, ErrDocumentoCharacter or ErrDocumentoCheckDigit.
+	Err error
+}
+
+func (e *DocumentoError) Error() string {
+	if e.Field == "" {
+		return e.Err.Error()
+	}
+	return e.Err.Error() + " (" + e.Field + ")"
+}
+
+func (e *DocumentoError) Unwrap() []error {
+	return []error{e.Doc, e.Err}
+}
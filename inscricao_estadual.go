@@ -0,0 +1,538 @@
+package br
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/phenpessoa/br/x/address"
+)
+
+// InscricaoEstadual represents a Brazilian state tax registration number (Inscrição Estadual).
+//
+// Each UF has its own length, prefix and check digit rules, so an InscricaoEstadual
+// can only be validated against the UF that issued it.
+//
+// The value ISENTO, used by exempt taxpayers, is accepted for every UF.
+type InscricaoEstadual string
+
+// NewInscricaoEstadual creates a new InscricaoEstadual instance from a string representation.
+//
+// It verifies the InscricaoEstadual's validity for the given UF.
+// The returned error is always an *InscricaoEstadualError.
+func NewInscricaoEstadual(s string, uf address.UF) (InscricaoEstadual, error) {
+	ie := InscricaoEstadual(s)
+	if err := ie.Validate(uf); err != nil {
+		return "", err
+	}
+	return ie, nil
+}
+
+// GenerateInscricaoEstadual generates a pseudo-random valid InscricaoEstadual for the given UF.
+//
+// The InscricaoEstadual is returned formatted according to the UF's mask.
+func GenerateInscricaoEstadual(uf address.UF) (InscricaoEstadual, error) {
+	layouts := ieLayouts[uf]
+	if len(layouts) == 0 {
+		return "", &InscricaoEstadualError{UF: uf, Err: address.ErrInvalidUF}
+	}
+
+	layout := layouts[randomUint64n(uint64(len(layouts)))]
+	prefix := layout.prefixes[randomUint64n(uint64(len(layout.prefixes)))]
+
+	data := make([]byte, layout.length)
+	for i := range data {
+		if i < len(prefix) && prefix[i] != '#' {
+			data[i] = prefix[i]
+		} else {
+			data[i] = randomDigit()
+		}
+	}
+	layout.fill(data)
+
+	return InscricaoEstadual(ieFormat(data, layout.mask)), nil
+}
+
+var (
+	// ErrInvalidInscricaoEstadual is an error returned when an invalid InscricaoEstadual is encountered.
+	//
+	// Every *InscricaoEstadualError wraps it.
+	ErrInvalidInscricaoEstadual = errors.New("br: invalid inscricao estadual")
+
+	// ErrInscricaoEstadualLength is returned when the InscricaoEstadual does not have a length used by the UF.
+	ErrInscricaoEstadualLength = errors.New("br: invalid inscricao estadual length")
+
+	// ErrInscricaoEstadualCharacter is returned when the InscricaoEstadual has an unexpected character.
+	ErrInscricaoEstadualCharacter = errors.New("br: invalid inscricao estadual character")
+
+	// ErrInscricaoEstadualPrefix is returned when the InscricaoEstadual does not start with a prefix used by the UF.
+	ErrInscricaoEstadualPrefix = errors.New("br: invalid inscricao estadual prefix")
+
+	// ErrInscricaoEstadualCheckDigit is returned when a check digit of the InscricaoEstadual does not match.
+	ErrInscricaoEstadualCheckDigit = errors.New("br: invalid inscricao estadual check digit")
+)
+
+// InscricaoEstadualError is the error returned when an InscricaoEstadual fails validation.
+//
+// It matches both ErrInvalidInscricaoEstadual and Err with errors.Is.
+type InscricaoEstadualError struct {
+	// UF is the UF the InscricaoEstadual was validated against.
+	UF address.UF
+
+	// Err is the reason the validation failed.
+	//
+	// It is one of ErrInscricaoEstadualLength, ErrInscricaoEstadualCharacter, ErrInscricaoEstadualPrefix,
+	// ErrInscricaoEstadualCheckDigit or address.ErrInvalidUF.
+	Err error
+}
+
+func (e *InscricaoEstadualError) Error() string {
+	return fmt.Sprintf("%s (uf %s)", e.Err, e.UF)
+}
+
+func (e *InscricaoEstadualError) Unwrap() []error {
+	return []error{ErrInvalidInscricaoEstadual, e.Err}
+}
+
+const ieIsento = "ISENTO"
+
+// IsValid checks whether the InscricaoEstadual is valid for the given UF.
+func (ie InscricaoEstadual) IsValid(uf address.UF) bool {
+	return ie.Validate(uf) == nil
+}
+
+// Validate checks whether the InscricaoEstadual is valid for the given UF, reporting why it is not.
+//
+// Punctuation (dots, dashes, slashes and spaces) is ignored.
+// The returned error is always an *InscricaoEstadualError.
+func (ie InscricaoEstadual) Validate(uf address.UF) error {
+	_, err := ie.layout(uf)
+	return err
+}
+
+func (ie InscricaoEstadual) layout(uf address.UF) (*ieLayout, error) {
+	layouts := ieLayouts[uf]
+	if len(layouts) == 0 {
+		return nil, &InscricaoEstadualError{UF: uf, Err: address.ErrInvalidUF}
+	}
+
+	d := ie.normalize()
+	if string(d) == ieIsento {
+		return nil, nil
+	}
+
+	var layout *ieLayout
+	for i := range layouts {
+		if layouts[i].length == len(d) && (d[0] == 'P') == layouts[i].rural {
+			layout = &layouts[i]
+			break
+		}
+	}
+
+	if layout == nil {
+		return nil, &InscricaoEstadualError{UF: uf, Err: ErrInscricaoEstadualLength}
+	}
+
+	start := 0
+	if layout.rural {
+		start = 1
+	}
+
+	for _, c := range d[start:] {
+		if !isDigit(c) {
+			return nil, &InscricaoEstadualError{UF: uf, Err: ErrInscricaoEstadualCharacter}
+		}
+	}
+
+	if !layout.hasPrefix(d) {
+		return nil, &InscricaoEstadualError{UF: uf, Err: ErrInscricaoEstadualPrefix}
+	}
+
+	want := make([]byte, len(d))
+	copy(want, d)
+	layout.fill(want)
+
+	if string(want) != string(d) {
+		return nil, &InscricaoEstadualError{UF: uf, Err: ErrInscricaoEstadualCheckDigit}
+	}
+
+	return layout, nil
+}
+
+// normalize strips the punctuation of the InscricaoEstadual and converts it to uppercase.
+func (ie InscricaoEstadual) normalize() []byte {
+	out := make([]byte, 0, len(ie))
+	for i := range len(ie) {
+		switch c := ie[i]; c {
+		case '.', '-', '/', ' ':
+		default:
+			out = append(out, asciiLowerToUpper(c))
+		}
+	}
+	return out
+}
+
+// IsIsento reports whether the InscricaoEstadual is ISENTO, the value used by exempt taxpayers.
+func (ie InscricaoEstadual) IsIsento() bool {
+	return string(ie.normalize()) == ieIsento
+}
+
+// Format returns the InscricaoEstadual formatted according to the mask used by the given UF,
+// such as XXX.XXX.XXX.XXX for SP.
+//
+// If the InscricaoEstadual is not valid for the UF, an empty string is returned.
+func (ie InscricaoEstadual) Format(uf address.UF) string {
+	layout, err := ie.layout(uf)
+	if err != nil {
+		return ""
+	}
+
+	if layout == nil {
+		return ieIsento
+	}
+
+	return ieFormat(ie.normalize(), layout.mask)
+}
+
+// String returns the InscricaoEstadual without punctuation and in uppercase.
+//
+// Since validating an InscricaoEstadual requires its UF, String does not validate it.
+// Use Format to get a validated and formatted string.
+func (ie InscricaoEstadual) String() string {
+	return string(ie.normalize())
+}
+
+func ieFormat(d []byte, mask string) string {
+	out := make([]byte, len(mask))
+	var j int
+	for i := range len(mask) {
+		if mask[i] == '#' {
+			out[i] = d[j]
+			j++
+		} else {
+			out[i] = mask[i]
+		}
+	}
+	return string(out)
+}
+
+// ieLayout describes one of the InscricaoEstadual formats used by a UF.
+type ieLayout struct {
+	// length is the amount of characters, without punctuation.
+	length int
+
+	// rural is set for the SP producer rural format, which starts with the letter P.
+	rural bool
+
+	// prefixes lists the accepted prefixes. A # matches any digit.
+	prefixes []string
+
+	// mask is used to format the InscricaoEstadual, each # is replaced by a character.
+	mask string
+
+	// fill writes the check digits of d in place.
+	fill func(d []byte)
+}
+
+func (l *ieLayout) hasPrefix(d []byte) bool {
+	for _, prefix := range l.prefixes {
+		ok := true
+		for i := range len(prefix) {
+			if prefix[i] != '#' && prefix[i] != d[i] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+var ieAnyPrefix = []string{""}
+
+var ieLayouts = map[address.UF][]ieLayout{
+	address.AC: {{length: 13, prefixes: []string{"01"}, mask: "##.###.###/###-##", fill: ieFillAC}},
+	address.AL: {{length: 9, prefixes: []string{"240", "243", "245", "247", "248"}, mask: "#########", fill: ieFillAL}},
+	address.AP: {{length: 9, prefixes: []string{"03"}, mask: "#########", fill: ieFillAP}},
+	address.AM: {{length: 9, prefixes: ieAnyPrefix, mask: "##.###.###-#", fill: ieFillMod11(9)}},
+	address.BA: {
+		{length: 8, prefixes: ieAnyPrefix, mask: "######-##", fill: ieFillBA},
+		{length: 9, prefixes: ieAnyPrefix, mask: "#######-##", fill: ieFillBA},
+	},
+	address.CE: {{length: 9, prefixes: ieAnyPrefix, mask: "########-#", fill: ieFillMod11(9)}},
+	address.DF: {{length: 13, prefixes: []string{"07", "08"}, mask: "##.###.###/###-##", fill: ieFillAC}},
+	address.ES: {{length: 9, prefixes: ieAnyPrefix, mask: "###.###.##-#", fill: ieFillMod11(9)}},
+	address.GO: {{
+		length:   9,
+		prefixes: []string{"10", "11", "15", "20", "21", "22", "23", "24", "25", "26", "27", "28", "29"},
+		mask:     "##.###.###-#",
+		fill:     ieFillGO,
+	}},
+	address.MA: {{length: 9, prefixes: []string{"12"}, mask: "##.###.###-#", fill: ieFillMod11(9)}},
+	address.MT: {{length: 11, prefixes: ieAnyPrefix, mask: "##########-#", fill: ieFillMT}},
+	address.MS: {{length: 9, prefixes: []string{"28", "50"}, mask: "##.###.###-#", fill: ieFillMod11(9)}},
+	address.MG: {{length: 13, prefixes: ieAnyPrefix, mask: "###.###.###/####", fill: ieFillMG}},
+	address.PA: {{length: 9, prefixes: []string{"15"}, mask: "##-######-#", fill: ieFillMod11(9)}},
+	address.PB: {{length: 9, prefixes: ieAnyPrefix, mask: "##.###.###-#", fill: ieFillMod11(9)}},
+	address.PR: {{length: 10, prefixes: ieAnyPrefix, mask: "###.#####-##", fill: ieFillPR}},
+	address.PE: {
+		{length: 9, prefixes: ieAnyPrefix, mask: "#######-##", fill: ieFillPE},
+		{length: 14, prefixes: ieAnyPrefix, mask: "##.#.###.#######-#", fill: ieFillPE14},
+	},
+	address.PI: {{length: 9, prefixes: ieAnyPrefix, mask: "##.###.###-#", fill: ieFillMod11(9)}},
+	address.RJ: {{length: 8, prefixes: ieAnyPrefix, mask: "##.###.##-#", fill: ieFillRJ}},
+	address.RN: {
+		{length: 9, prefixes: []string{"20"}, mask: "##.###.###-#", fill: ieFillRN},
+		{length: 10, prefixes: []string{"20"}, mask: "##.#.###.###-#", fill: ieFillRN},
+	},
+	address.RS: {{length: 10, prefixes: ieAnyPrefix, mask: "###/#######", fill: ieFillRS}},
+	address.RO: {
+		{length: 9, prefixes: ieAnyPrefix, mask: "###.#####-#", fill: ieFillRO9},
+		{length: 14, prefixes: ieAnyPrefix, mask: "#############-#", fill: ieFillRO14},
+	},
+	address.RR: {{length: 9, prefixes: []string{"24"}, mask: "########-#", fill: ieFillRR}},
+	address.SC: {{length: 9, prefixes: ieAnyPrefix, mask: "###.###.###", fill: ieFillMod11(9)}},
+	address.SP: {
+		{length: 12, prefixes: ieAnyPrefix, mask: "###.###.###.###", fill: ieFillSP},
+		{length: 13, rural: true, prefixes: []string{"P0"}, mask: "#-########.#/###", fill: ieFillSPRural},
+	},
+	address.SE: {{length: 9, prefixes: ieAnyPrefix, mask: "########-#", fill: ieFillMod11(9)}},
+	address.TO: {
+		{length: 9, prefixes: ieAnyPrefix, mask: "##.###.###-#", fill: ieFillMod11(9)},
+		{length: 11, prefixes: []string{"##01", "##02", "##03", "##99"}, mask: "##.##.######-#", fill: ieFillTO11},
+	},
+}
+
+func ieWeightedSum(d []byte, weights ...int) int {
+	var sum int
+	for i, w := range weights {
+		sum += w * int(d[i]-'0')
+	}
+	return sum
+}
+
+// ieMod11 is the most common check digit rule: 11 minus the rest of the division by 11,
+// where 10 and 11 become 0.
+func ieMod11(sum int) byte {
+	rest := sum % 11
+	if rest < 2 {
+		return '0'
+	}
+	return byte(11-rest) + '0'
+}
+
+// ieMod11Weights returns the weights from length+1 down to 2, used by most UFs.
+func ieMod11Weights(length int) []int {
+	weights := make([]int, length)
+	for i := range weights {
+		weights[i] = length + 1 - i
+	}
+	return weights
+}
+
+// ieFillMod11 returns a fill function for a single check digit, in the last position,
+// calculated with ieMod11 over the weights length..2.
+func ieFillMod11(length int) func(d []byte) {
+	weights := ieMod11Weights(length - 1)
+	return func(d []byte) {
+		d[length-1] = ieMod11(ieWeightedSum(d, weights...))
+	}
+}
+
+// ieFillAC is also used by DF.
+func ieFillAC(d []byte) {
+	d[11] = ieMod11(ieWeightedSum(d, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2))
+	d[12] = ieMod11(ieWeightedSum(d, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2))
+}
+
+// ieFillMT calculates the MT check digit with the weights 3 and 2 followed by 9..2, not 11..2.
+func ieFillMT(d []byte) {
+	d[10] = ieMod11(ieWeightedSum(d, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2))
+}
+
+func ieFillAL(d []byte) {
+	d[8] = ieTimes10Mod11(ieWeightedSum(d, ieMod11Weights(8)...))
+}
+
+// ieTimes10Mod11 is used by AL and RN.
+func ieTimes10Mod11(sum int) byte {
+	rest := (sum * 10) % 11
+	if rest == 10 {
+		return '0'
+	}
+	return byte(rest) + '0'
+}
+
+func ieFillAP(d []byte) {
+	var number int
+	for _, c := range d[:8] {
+		number = number*10 + int(c-'0')
+	}
+
+	var p, dv int
+	switch {
+	case number >= 3000001 && number <= 3017000:
+		p, dv = 5, 0
+	case number >= 3017001 && number <= 3019022:
+		p, dv = 9, 1
+	}
+
+	rest := 11 - (p+ieWeightedSum(d, ieMod11Weights(8)...))%11
+	switch rest {
+	case 10:
+		rest = 0
+	case 11:
+		rest = dv
+	}
+
+	d[8] = byte(rest) + '0'
+}
+
+// ieFillBA calculates the BA check digits. The last digit is calculated first, and is then used
+// to calculate the second to last one. The modulo is 10 or 11 depending on the first digit,
+// or on the second digit for the 9 digit format.
+func ieFillBA(d []byte) {
+	n := len(d) - 2
+
+	mod := 10
+	switch d[len(d)-8] {
+	case '6', '7', '9':
+		mod = 11
+	}
+
+	baDigit := func(sum int) byte {
+		rest := sum % mod
+		if mod == 10 {
+			if rest == 0 {
+				return '0'
+			}
+			return byte(10-rest) + '0'
+		}
+		if rest < 2 {
+			return '0'
+		}
+		return byte(11-rest) + '0'
+	}
+
+	d[n+1] = baDigit(ieWeightedSum(d, ieMod11Weights(n)...))
+
+	sum := ieWeightedSum(d, ieMod11Weights(n + 1)[:n]...) + 2*int(d[n+1]-'0')
+	d[n] = baDigit(sum)
+}
+
+func ieFillGO(d []byte) {
+	rest := ieWeightedSum(d, ieMod11Weights(8)...) % 11
+
+	switch rest {
+	case 0:
+		d[8] = '0'
+	case 1:
+		var number int
+		for _, c := range d[:8] {
+			number = number*10 + int(c-'0')
+		}
+
+		if number >= 10103105 && number <= 10119997 {
+			d[8] = '1'
+		} else {
+			d[8] = '0'
+		}
+	default:
+		d[8] = byte(11-rest) + '0'
+	}
+}
+
+// ieFillMG calculates the MG check digits. The first one is calculated by inserting a 0 after
+// the municipality code and summing the digits of the products by the alternating weights 1 and 2.
+func ieFillMG(d []byte) {
+	var sum int
+	for i := range 12 {
+		var c int
+		switch {
+		case i < 3:
+			c = int(d[i] - '0')
+		case i == 3:
+			c = 0
+		default:
+			c = int(d[i-1] - '0')
+		}
+
+		product := c * (1 + i%2)
+		sum += product/10 + product%10
+	}
+
+	d[11] = byte((10-sum%10)%10) + '0'
+	d[12] = ieMod11(ieWeightedSum(d, 3, 2, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2))
+}
+
+func ieFillPR(d []byte) {
+	d[8] = ieMod11(ieWeightedSum(d, 3, 2, 7, 6, 5, 4, 3, 2))
+	d[9] = ieMod11(ieWeightedSum(d, 4, 3, 2, 7, 6, 5, 4, 3, 2))
+}
+
+// ieFillPE calculates the check digits of the current PE format (eFisco).
+func ieFillPE(d []byte) {
+	d[7] = ieMod11(ieWeightedSum(d, ieMod11Weights(7)...))
+	d[8] = ieMod11(ieWeightedSum(d, ieMod11Weights(8)...))
+}
+
+// ieFillPE14 calculates the check digit of the old PE format.
+func ieFillPE14(d []byte) {
+	d[13] = ieMod11Minus10(ieWeightedSum(d, 5, 4, 3, 2, 1, 9, 8, 7, 6, 5, 4, 3, 2))
+}
+
+// ieMod11Minus10 is used by the old PE format and by RO, where 10 and 11 become 0 and 1.
+func ieMod11Minus10(sum int) byte {
+	dv := 11 - sum%11
+	if dv >= 10 {
+		dv -= 10
+	}
+	return byte(dv) + '0'
+}
+
+func ieFillRJ(d []byte) {
+	d[7] = ieMod11(ieWeightedSum(d, 2, 7, 6, 5, 4, 3, 2))
+}
+
+func ieFillRN(d []byte) {
+	n := len(d) - 1
+	d[n] = ieTimes10Mod11(ieWeightedSum(d, ieMod11Weights(n)...))
+}
+
+func ieFillRS(d []byte) {
+	d[9] = ieMod11(ieWeightedSum(d, 2, 9, 8, 7, 6, 5, 4, 3, 2))
+}
+
+// ieFillRO9 calculates the check digit of the old RO format,
+// where the first 3 digits are the municipality and are not part of the calculation.
+func ieFillRO9(d []byte) {
+	d[8] = ieMod11Minus10(ieWeightedSum(d[3:], 6, 5, 4, 3, 2))
+}
+
+func ieFillRO14(d []byte) {
+	d[13] = ieMod11Minus10(ieWeightedSum(d, 6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2))
+}
+
+func ieFillRR(d []byte) {
+	d[8] = byte(ieWeightedSum(d, 1, 2, 3, 4, 5, 6, 7, 8)%9) + '0'
+}
+
+// ieFillSP calculates the SP check digits, which are in the 9th and 12th positions.
+func ieFillSP(d []byte) {
+	d[8] = byte(ieWeightedSum(d, 1, 3, 4, 5, 6, 7, 8, 10)%11%10) + '0'
+	d[11] = byte(ieWeightedSum(d, 3, 2, 10, 9, 8, 7, 6, 5, 4, 3, 2)%11%10) + '0'
+}
+
+// ieFillSPRural calculates the check digit of the SP producer rural format, P0MMMSSSSD000,
+// which is in the 10th position.
+func ieFillSPRural(d []byte) {
+	d[9] = byte(ieWeightedSum(d[1:], 1, 3, 4, 5, 6, 7, 8, 10)%11%10) + '0'
+}
+
+// ieFillTO11 calculates the check digit of the old TO format,
+// where the 3rd and 4th digits are the company type and are not part of the calculation.
+func ieFillTO11(d []byte) {
+	sum := ieWeightedSum(d, 9, 8) + ieWeightedSum(d[4:], 7, 6, 5, 4, 3, 2)
+	d[10] = ieMod11(sum)
+}
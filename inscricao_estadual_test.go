@@ -0,0 +1,160 @@
+package br
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/phenpessoa/br/x/address"
+)
+
+var ieUFs = []address.UF{
+	address.AC, address.AL, address.AP, address.AM, address.BA, address.CE, address.DF,
+	address.ES, address.GO, address.MA, address.MT, address.MS, address.MG, address.PA,
+	address.PB, address.PR, address.PE, address.PI, address.RJ, address.RN, address.RS,
+	address.RO, address.RR, address.SC, address.SP, address.SE, address.TO,
+}
+
+func TestGenerateInscricaoEstadual(t *testing.T) {
+	for _, uf := range ieUFs {
+		for range 10_000 {
+			ie, err := GenerateInscricaoEstadual(uf)
+			if err != nil {
+				t.Fatalf("failed to generate InscricaoEstadual for %s: %v", uf, err)
+			}
+
+			if err := ie.Validate(uf); err != nil {
+				t.Errorf("invalid InscricaoEstadual generated for %s: %s: %v", uf, string(ie), err)
+			}
+		}
+	}
+
+	if _, err := GenerateInscricaoEstadual(address.ZZ); !errors.Is(err, address.ErrInvalidUF) {
+		t.Errorf("generated InscricaoEstadual for ZZ, err: %v", err)
+	}
+}
+
+func BenchmarkInscricaoEstadual_IsValid(b *testing.B) {
+	const ie = InscricaoEstadual("110.042.490.114")
+	if !ie.IsValid(address.SP) {
+		b.Error("invalid inscricao estadual on benchmark")
+		b.FailNow()
+	}
+	b.ReportAllocs()
+	for range b.N {
+		boolSink = ie.IsValid(address.SP)
+	}
+}
+
+func TestInscricaoEstadual_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ie   InscricaoEstadual
+		uf   address.UF
+		err  error
+	}{
+		{name: "AC", ie: "01.004.823/001-12", uf: address.AC},
+		{name: "AL", ie: "240000048", uf: address.AL},
+		{name: "AP", ie: "030123459", uf: address.AP},
+		{name: "AM", ie: "99.999.999-0", uf: address.AM},
+		{name: "BA 8 mod 10", ie: "123456-63", uf: address.BA},
+		{name: "BA 8 mod 11", ie: "612345-57", uf: address.BA},
+		{name: "BA 9", ie: "1000003-06", uf: address.BA},
+		{name: "CE", ie: "06000001-5", uf: address.CE},
+		{name: "DF", ie: "07.300.001/001-09", uf: address.DF},
+		{name: "ES", ie: "999.999.99-0", uf: address.ES},
+		{name: "GO", ie: "10.987.654-7", uf: address.GO},
+		{name: "MA", ie: "12.000.038-5", uf: address.MA},
+		{name: "MT", ie: "0013000001-9", uf: address.MT},
+		{name: "MT raw", ie: "13125148005", uf: address.MT},
+		{name: "MT formatted", ie: "2345678901-3", uf: address.MT},
+		{name: "MS", ie: "28.311.594-7", uf: address.MS},
+		{name: "MG", ie: "062.307.904/0081", uf: address.MG},
+		{name: "PA", ie: "15-999999-5", uf: address.PA},
+		{name: "PB", ie: "06.000.001-5", uf: address.PB},
+		{name: "PR", ie: "123.45678-50", uf: address.PR},
+		{name: "PE", ie: "0321418-40", uf: address.PE},
+		{name: "PE old", ie: "18.1.001.0000004-9", uf: address.PE},
+		{name: "PI", ie: "01.234.567-9", uf: address.PI},
+		{name: "RJ", ie: "99.999.99-3", uf: address.RJ},
+		{name: "RN 9", ie: "20.040.040-1", uf: address.RN},
+		{name: "RN 10", ie: "20.0.040.040-0", uf: address.RN},
+		{name: "RS", ie: "224/3658792", uf: address.RS},
+		{name: "RO", ie: "0000000062521-3", uf: address.RO},
+		{name: "RO old", ie: "101.62521-3", uf: address.RO},
+		{name: "RR", ie: "24006628-1", uf: address.RR},
+		{name: "SC", ie: "251.040.852", uf: address.SC},
+		{name: "SP", ie: "110.042.490.114", uf: address.SP},
+		{name: "SP raw", ie: "110042490114", uf: address.SP},
+		{name: "SP rural", ie: "P-01100424.3/002", uf: address.SP},
+		{name: "SP rural lower", ie: "p011004243002", uf: address.SP},
+		{name: "SE", ie: "27123456-3", uf: address.SE},
+		{name: "TO old", ie: "29.01.022783-6", uf: address.TO},
+		{name: "isento", ie: "ISENTO", uf: address.SP},
+		{name: "isento lower", ie: "isento", uf: address.RJ},
+		{name: "isento ZZ", ie: "ISENTO", uf: address.ZZ, err: address.ErrInvalidUF},
+		{name: "invalid uf", ie: "110.042.490.114", uf: 0, err: address.ErrInvalidUF},
+		{name: "other uf", ie: "110.042.490.114", uf: address.RJ, err: ErrInscricaoEstadualLength},
+		{name: "invalid check digit", ie: "110.042.490.115", uf: address.SP, err: ErrInscricaoEstadualCheckDigit},
+		{name: "invalid first check digit", ie: "110.042.491.114", uf: address.SP, err: ErrInscricaoEstadualCheckDigit},
+		{name: "invalid MT check digit", ie: "13125148006", uf: address.MT, err: ErrInscricaoEstadualCheckDigit},
+		{name: "invalid character", ie: "110.042.49A.114", uf: address.SP, err: ErrInscricaoEstadualCharacter},
+		{name: "invalid prefix", ie: "250000048", uf: address.AL, err: ErrInscricaoEstadualPrefix},
+		{name: "rural outside SP", ie: "P011004243002", uf: address.RJ, err: ErrInscricaoEstadualLength},
+		{name: "empty", ie: "", uf: address.SP, err: ErrInscricaoEstadualLength},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.ie.Validate(tc.uf)
+			if tc.err == nil {
+				if err != nil {
+					t.Errorf("\ninscricao estadual: %s\nuf: %s\nshould be valid, err: %v", tc.ie, tc.uf, err)
+				}
+				return
+			}
+
+			if !errors.Is(err, tc.err) || !errors.Is(err, ErrInvalidInscricaoEstadual) {
+				t.Errorf("\ninscricao estadual: %s\nuf: %s\nwant err: %v\ngot err: %v", tc.ie, tc.uf, tc.err, err)
+			}
+
+			var ieErr *InscricaoEstadualError
+			if !errors.As(err, &ieErr) || ieErr.UF != tc.uf {
+				t.Errorf("\ninscricao estadual: %s\nerr is not an *InscricaoEstadualError for %s: %v", tc.ie, tc.uf, err)
+			}
+		})
+	}
+}
+
+func TestInscricaoEstadual_Format(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ie   InscricaoEstadual
+		uf   address.UF
+		want string
+	}{
+		{name: "SP", ie: "110042490114", uf: address.SP, want: "110.042.490.114"},
+		{name: "SP rural", ie: "p011004243002", uf: address.SP, want: "P-01100424.3/002"},
+		{name: "MG", ie: "0623079040081", uf: address.MG, want: "062.307.904/0081"},
+		{name: "BA 8", ie: "12345663", uf: address.BA, want: "123456-63"},
+		{name: "RS", ie: "2243658792", uf: address.RS, want: "224/3658792"},
+		{name: "isento", ie: "isento", uf: address.SP, want: "ISENTO"},
+		{name: "invalid", ie: "110042490115", uf: address.SP, want: ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.ie.Format(tc.uf); got != tc.want {
+				t.Errorf(
+					"\ninscricao estadual: %s\nshould be formatted like: %s\nis formatted like: %s",
+					tc.ie, tc.want, got,
+				)
+			}
+		})
+	}
+}
+
+func TestInscricaoEstadual_String(t *testing.T) {
+	if got := InscricaoEstadual("p-01100424.3/002").String(); got != "P011004243002" {
+		t.Errorf("unexpected string: %s", got)
+	}
+
+	if !InscricaoEstadual("Isento").IsIsento() {
+		t.Error("Isento is not isento")
+	}
+}
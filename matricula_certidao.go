@@ -0,0 +1,271 @@
+package br
+
+import (
+	"database/sql/driver"
+	"errors"
+)
+
+// MatriculaCertidao represents the registration number (matrícula) of a Brazilian civil registry
+// certificate, such as birth, marriage and death certificates issued since 2010.
+//
+// It has 32 digits: the serventia's CNS (6), the acervo (2), the service code (2), the year (4),
+// the book type (1), the book (5), the page (3), the term (7) and 2 check digits.
+type MatriculaCertidao string
+
+// NewMatriculaCertidao creates a new MatriculaCertidao instance from a string representation.
+//
+// It verifies the MatriculaCertidao's validity using checksum digits.
+func NewMatriculaCertidao(s string) (MatriculaCertidao, error) {
+	m := MatriculaCertidao(s)
+	if !m.IsValid() {
+		return "", ErrInvalidMatriculaCertidao
+	}
+	return m, nil
+}
+
+// ErrInvalidMatriculaCertidao is an error returned when an invalid MatriculaCertidao is encountered.
+var ErrInvalidMatriculaCertidao = errors.New("br: invalid matricula de certidao")
+
+// Acervo tells whether the book belongs to the serventia or was incorporated from another one.
+type Acervo uint8
+
+const (
+	// AcervoProprio is a book of the serventia itself.
+	AcervoProprio Acervo = 1
+
+	// AcervoIncorporado is a book incorporated from another serventia.
+	AcervoIncorporado Acervo = 2
+)
+
+// String returns the name of the Acervo.
+func (a Acervo) String() string {
+	switch a {
+	case AcervoProprio:
+		return "próprio"
+	case AcervoIncorporado:
+		return "incorporado"
+	default:
+		return ""
+	}
+}
+
+// TipoLivro is the type of the book the act was registered in.
+type TipoLivro uint8
+
+const (
+	// LivroNascimento is the book A, of births.
+	LivroNascimento TipoLivro = 1
+
+	// LivroCasamento is the book B, of marriages.
+	LivroCasamento TipoLivro = 2
+
+	// LivroCasamentoReligioso is the book B Auxiliar, of religious marriages with civil effects.
+	LivroCasamentoReligioso TipoLivro = 3
+
+	// LivroObito is the book C, of deaths.
+	LivroObito TipoLivro = 4
+
+	// LivroNatimorto is the book C Auxiliar, of stillbirths.
+	LivroNatimorto TipoLivro = 5
+
+	// LivroProclamas is the book D, of marriage banns.
+	LivroProclamas TipoLivro = 6
+
+	// LivroDemaisAtos is the book E, of the other acts.
+	LivroDemaisAtos TipoLivro = 7
+)
+
+// String returns the name of the TipoLivro.
+func (t TipoLivro) String() string {
+	switch t {
+	case LivroNascimento:
+		return "nascimento"
+	case LivroCasamento:
+		return "casamento"
+	case LivroCasamentoReligioso:
+		return "casamento religioso"
+	case LivroObito:
+		return "óbito"
+	case LivroNatimorto:
+		return "natimorto"
+	case LivroProclamas:
+		return "proclamas"
+	case LivroDemaisAtos:
+		return "demais atos"
+	default:
+		return ""
+	}
+}
+
+// matriculaCertidaoServico is the service code of the civil registry of natural persons (RCPN).
+const matriculaCertidaoServico = "55"
+
+// IsValid checks whether the provided MatriculaCertidao is valid based on its structure and checksum digits.
+//
+// Spaces, dots and dashes are ignored.
+func (m MatriculaCertidao) IsValid() bool {
+	digits, ok := m.digits()
+	if !ok {
+		return false
+	}
+
+	switch Acervo(digitsToInt(digits[6:8])) {
+	case AcervoProprio, AcervoIncorporado:
+	default:
+		return false
+	}
+
+	if string(digits[8:10]) != matriculaCertidaoServico || digits[14] == '0' {
+		return false
+	}
+
+	d1 := matriculaCertidaoCheckDigit(digits[:30])
+	if digits[30] != d1 {
+		return false
+	}
+
+	return digits[31] == matriculaCertidaoCheckDigit(digits[:31])
+}
+
+// digits strips the punctuation of the MatriculaCertidao and makes sure all remaining bytes are digits.
+func (m MatriculaCertidao) digits() (out [32]byte, ok bool) {
+	var j int
+	for i := range len(m) {
+		c := m[i]
+		switch {
+		case c == ' ' || c == '.' || c == '-':
+			continue
+		case !isDigit(c) || j == len(out):
+			return out, false
+		}
+		out[j] = c
+		j++
+	}
+	return out, j == len(out)
+}
+
+// matriculaCertidaoCheckDigit calculates a check digit with mod 11. The weights start at 32 minus
+// the amount of digits and increase by 1, going back to 0 after 10. A rest of 10 becomes 1.
+func matriculaCertidaoCheckDigit(d []byte) byte {
+	var sum int
+	weight := 32 - len(d)
+	for _, c := range d {
+		sum += int(c-'0') * weight
+		weight++
+		if weight > 10 {
+			weight = 0
+		}
+	}
+
+	rest := sum % 11
+	if rest == 10 {
+		rest = 1
+	}
+	return byte(rest) + '0'
+}
+
+// Serventia returns the CNS (Cadastro Nacional de Serventias) of the registry office.
+//
+// If the MatriculaCertidao is invalid, an empty string is returned.
+func (m MatriculaCertidao) Serventia() string {
+	if !m.IsValid() {
+		return ""
+	}
+	digits, _ := m.digits()
+	return string(digits[:6])
+}
+
+// Acervo returns whether the book belongs to the serventia or was incorporated.
+//
+// If the MatriculaCertidao is invalid, 0 is returned.
+func (m MatriculaCertidao) Acervo() Acervo {
+	if !m.IsValid() {
+		return 0
+	}
+	digits, _ := m.digits()
+	return Acervo(digitsToInt(digits[6:8]))
+}
+
+// Ano returns the year the act was registered.
+//
+// If the MatriculaCertidao is invalid, 0 is returned.
+func (m MatriculaCertidao) Ano() int {
+	if !m.IsValid() {
+		return 0
+	}
+	digits, _ := m.digits()
+	return digitsToInt(digits[10:14])
+}
+
+// TipoLivro returns the type of the book the act was registered in.
+//
+// If the MatriculaCertidao is invalid, 0 is returned.
+func (m MatriculaCertidao) TipoLivro() TipoLivro {
+	if !m.IsValid() {
+		return 0
+	}
+	digits, _ := m.digits()
+	return TipoLivro(digits[14] - '0')
+}
+
+// Livro returns the number of the book the act was registered in.
+//
+// If the MatriculaCertidao is invalid, 0 is returned.
+func (m MatriculaCertidao) Livro() int {
+	if !m.IsValid() {
+		return 0
+	}
+	digits, _ := m.digits()
+	return digitsToInt(digits[15:20])
+}
+
+// Folha returns the page the act was registered in.
+//
+// If the MatriculaCertidao is invalid, 0 is returned.
+func (m MatriculaCertidao) Folha() int {
+	if !m.IsValid() {
+		return 0
+	}
+	digits, _ := m.digits()
+	return digitsToInt(digits[20:23])
+}
+
+// Termo returns the term number of the act.
+//
+// If the MatriculaCertidao is invalid, 0 is returned.
+func (m MatriculaCertidao) Termo() int {
+	if !m.IsValid() {
+		return 0
+	}
+	digits, _ := m.digits()
+	return digitsToInt(digits[23:30])
+}
+
+// matriculaCertidaoGroups holds the end of each group of digits of a formatted MatriculaCertidao.
+var matriculaCertidaoGroups = []int{6, 8, 10, 14, 15, 20, 23, 30, 32}
+
+// String returns the formatted MatriculaCertidao as XXXXXX XX XX XXXX X XXXXX XXX XXXXXXX XX.
+func (m MatriculaCertidao) String() string {
+	if !m.IsValid() {
+		return ""
+	}
+
+	digits, _ := m.digits()
+
+	out := make([]byte, 0, 40)
+	var start int
+	for _, end := range matriculaCertidaoGroups {
+		if start > 0 {
+			out = append(out, ' ')
+		}
+		out = append(out, digits[start:end]...)
+		start = end
+	}
+
+	return string(out)
+}
+
+// Value implements the driver.Valuer interface for MatriculaCertidao.
+func (m MatriculaCertidao) Value() (driver.Value, error) {
+	return m.String(), nil
+}
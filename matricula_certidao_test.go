@@ -0,0 +1,122 @@
+package br
+
+import "testing"
+
+func TestMatriculaCertidao_IsValid(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		m     MatriculaCertidao
+		valid bool
+	}{
+		{
+			name:  "raw",
+			m:     MatriculaCertidao("10453901552013100012021000012321"),
+			valid: true,
+		},
+		{
+			name:  "formatted",
+			m:     MatriculaCertidao("104539 01 55 2013 1 00012 021 0000123 21"),
+			valid: true,
+		},
+		{
+			name:  "dotted",
+			m:     MatriculaCertidao("104539.01.55.2013.1.00012.021.0000123-21"),
+			valid: true,
+		},
+		{
+			name:  "incorporated marriage",
+			m:     MatriculaCertidao("12345602552020400001015000123417"),
+			valid: true,
+		},
+		{
+			name:  "invalid first digit",
+			m:     MatriculaCertidao("10453901552013100012021000012331"),
+			valid: false,
+		},
+		{
+			name:  "invalid second digit",
+			m:     MatriculaCertidao("10453901552013100012021000012322"),
+			valid: false,
+		},
+		{
+			name:  "invalid acervo",
+			m:     MatriculaCertidao("10453903552013100012021000012321"),
+			valid: false,
+		},
+		{
+			name:  "invalid service",
+			m:     MatriculaCertidao("10453901562013100012021000012321"),
+			valid: false,
+		},
+		{
+			name:  "too long",
+			m:     MatriculaCertidao("104539015520131000120210000123211"),
+			valid: false,
+		},
+		{
+			name:  "too short",
+			m:     MatriculaCertidao("1045390155201310001202100001232"),
+			valid: false,
+		},
+		{
+			name:  "letters",
+			m:     MatriculaCertidao("A0453901552013100012021000012321"),
+			valid: false,
+		},
+		{
+			name:  "empty",
+			m:     MatriculaCertidao(""),
+			valid: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.m.IsValid() != tc.valid {
+				t.Errorf(
+					"\nmatricula: %s\nshould be valid: %v\nis valid: %v",
+					tc.m, tc.valid, tc.m.IsValid(),
+				)
+			}
+		})
+	}
+}
+
+func TestMatriculaCertidao_Fields(t *testing.T) {
+	m := MatriculaCertidao("10453901552013100012021000012321")
+
+	if got := m.Serventia(); got != "104539" {
+		t.Errorf("unexpected serventia: %s", got)
+	}
+
+	if got := m.Acervo(); got != AcervoProprio {
+		t.Errorf("unexpected acervo: %s", got)
+	}
+
+	if got := m.Ano(); got != 2013 {
+		t.Errorf("unexpected ano: %d", got)
+	}
+
+	if got := m.TipoLivro(); got != LivroNascimento {
+		t.Errorf("unexpected tipo livro: %s", got)
+	}
+
+	if got := m.Livro(); got != 12 {
+		t.Errorf("unexpected livro: %d", got)
+	}
+
+	if got := m.Folha(); got != 21 {
+		t.Errorf("unexpected folha: %d", got)
+	}
+
+	if got := m.Termo(); got != 123 {
+		t.Errorf("unexpected termo: %d", got)
+	}
+
+	if got := m.String(); got != "104539 01 55 2013 1 00012 021 0000123 21" {
+		t.Errorf("unexpected string: %s", got)
+	}
+
+	invalid := MatriculaCertidao("10453901552013100012021000012322")
+	if invalid.Ano() != 0 || invalid.String() != "" || invalid.TipoLivro() != 0 {
+		t.Error("invalid matricula returned fields")
+	}
+}
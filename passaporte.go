@@ -0,0 +1,286 @@
+package br
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Passaporte represents the number of a Brazilian passport, made of 2 letters and 6 digits, such as FZ123456.
+type Passaporte string
+
+// NewPassaporte creates a new Passaporte instance from a string representation.
+//
+// The returned error is always a *DocumentoError.
+func NewPassaporte(s string) (Passaporte, error) {
+	p := Passaporte(s)
+	if err := p.Validate(); err != nil {
+		return "", err
+	}
+	return p, nil
+}
+
+// GeneratePassaporte generates a pseudo-random valid Passaporte.
+func GeneratePassaporte() Passaporte {
+	data := make([]byte, 8)
+
+	for i := range 2 {
+		data[i] = randomAlphaUpper()
+	}
+
+	for i := 2; i < 8; i++ {
+		data[i] = randomDigit()
+	}
+
+	return Passaporte(string(data))
+}
+
+// ErrInvalidPassaporte is an error returned when an invalid Passaporte is encountered.
+var ErrInvalidPassaporte = errors.New("br: invalid passaporte")
+
+// IsValid checks whether the provided Passaporte is valid.
+func (p Passaporte) IsValid() bool {
+	return p.Validate() == nil
+}
+
+// Validate checks whether the provided Passaporte is valid, reporting why it is not.
+//
+// Case, dots, dashes and spaces are ignored.
+func (p Passaporte) Validate() error {
+	_, err := p.normalize()
+	return err
+}
+
+func (p Passaporte) normalize() ([8]byte, error) {
+	var (
+		out [8]byte
+		j   int
+	)
+
+	for i := range len(p) {
+		c := asciiLowerToUpper(p[i])
+		switch {
+		case c == '-' || c == '.' || c == ' ':
+			continue
+		case j == len(out):
+			return out, &DocumentoError{Doc: ErrInvalidPassaporte, Err: ErrDocumentoLength}
+		}
+		out[j] = c
+		j++
+	}
+
+	if j != len(out) {
+		return out, &DocumentoError{Doc: ErrInvalidPassaporte, Err: ErrDocumentoLength}
+	}
+
+	if !isAlphaUpper(out[0]) || !isAlphaUpper(out[1]) {
+		return out, &DocumentoError{Doc: ErrInvalidPassaporte, Field: "serie", Err: ErrDocumentoCharacter}
+	}
+
+	for _, c := range out[2:] {
+		if !isDigit(c) {
+			return out, &DocumentoError{Doc: ErrInvalidPassaporte, Field: "numero", Err: ErrDocumentoCharacter}
+		}
+	}
+
+	return out, nil
+}
+
+// String returns the Passaporte without separators and in uppercase, such as FZ123456.
+func (p Passaporte) String() string {
+	d, err := p.normalize()
+	if err != nil {
+		return ""
+	}
+	return string(d[:])
+}
+
+// Value implements the driver.Valuer interface for Passaporte.
+func (p Passaporte) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// Scan implements the sql.Scanner interface for Passaporte.
+func (p *Passaporte) Scan(value any) error {
+	str, err := scanString("Passaporte", value)
+	if err != nil {
+		return err
+	}
+
+	_p, err := NewPassaporte(str)
+	if err != nil {
+		return fmt.Errorf("br: can not scan %q into Passaporte: %w", str, err)
+	}
+
+	*p = _p
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for Passaporte.
+func (p Passaporte) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + p.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Passaporte.
+func (p *Passaporte) UnmarshalJSON(b []byte) error {
+	str, ok, err := unmarshalJSONString(b)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %s into Passaporte: %w", b, err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	_p, err := NewPassaporte(str)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %q into Passaporte: %w", str, err)
+	}
+
+	*p = _p
+	return nil
+}
+
+// ErrInvalidMRZ is an error returned when an invalid MRZ is encountered.
+var ErrInvalidMRZ = errors.New("br: invalid mrz")
+
+// MRZ holds the data of the machine readable zone of a Brazilian passport (ICAO 9303 TD3).
+//
+// Sexo is M, F or empty when unspecified.
+type MRZ struct {
+	Sobrenome      string
+	Nomes          string
+	Numero         Passaporte
+	Nacionalidade  string
+	Nascimento     time.Time
+	Sexo           string
+	Validade       time.Time
+	DadosOpcionais string
+}
+
+// ParseMRZ parses the 2 lines, of 44 characters each, of the machine readable zone of a Brazilian passport.
+//
+// The lines may be separated by a line break or concatenated. Every check digit is verified.
+//
+// Birth years in the future are considered to be in the previous century.
+// The returned error is always a *DocumentoError.
+func ParseMRZ(s string) (MRZ, error) {
+	s = strings.NewReplacer("\r", "", "\n", "", " ", "").Replace(strings.TrimSpace(s))
+	if len(s) != 88 {
+		return MRZ{}, &DocumentoError{Doc: ErrInvalidMRZ, Err: ErrDocumentoLength}
+	}
+
+	for i := range len(s) {
+		if c := s[i]; !isAlphaNumericalUpper(c) && c != '<' {
+			return MRZ{}, &DocumentoError{Doc: ErrInvalidMRZ, Err: ErrDocumentoCharacter}
+		}
+	}
+
+	line1, line2 := s[:44], s[44:]
+
+	if line1[0] != 'P' {
+		return MRZ{}, &DocumentoError{Doc: ErrInvalidMRZ, Field: "tipo", Err: ErrDocumentoCharacter}
+	}
+
+	if line1[2:5] != "BRA" {
+		return MRZ{}, &DocumentoError{Doc: ErrInvalidMRZ, Field: "emissor", Err: ErrDocumentoCharacter}
+	}
+
+	for _, field := range []struct {
+		name  string
+		value string
+		check byte
+	}{
+		{"numero", line2[0:9], line2[9]},
+		{"nascimento", line2[13:19], line2[19]},
+		{"validade", line2[21:27], line2[27]},
+		{"dados opcionais", line2[28:42], line2[42]},
+		{"composto", line2[0:10] + line2[13:20] + line2[21:43], line2[43]},
+	} {
+		// The check digit of the optional data may be a filler when the field is empty.
+		if field.check == '<' && field.name == "dados opcionais" && strings.Trim(field.value, "<") == "" {
+			continue
+		}
+
+		if mrzCheckDigit(field.value) != field.check {
+			return MRZ{}, &DocumentoError{Doc: ErrInvalidMRZ, Field: field.name, Err: ErrDocumentoCheckDigit}
+		}
+	}
+
+	numero, err := NewPassaporte(strings.TrimRight(line2[0:9], "<"))
+	if err != nil {
+		return MRZ{}, &DocumentoError{Doc: ErrInvalidMRZ, Field: "numero", Err: ErrDocumentoCharacter}
+	}
+
+	nascimento, ok := mrzDate(line2[13:19])
+	if !ok {
+		return MRZ{}, &DocumentoError{Doc: ErrInvalidMRZ, Field: "nascimento", Err: ErrDocumentoCharacter}
+	}
+
+	if nascimento.After(time.Now()) {
+		nascimento = nascimento.AddDate(-100, 0, 0)
+	}
+
+	validade, ok := mrzDate(line2[21:27])
+	if !ok {
+		return MRZ{}, &DocumentoError{Doc: ErrInvalidMRZ, Field: "validade", Err: ErrDocumentoCharacter}
+	}
+
+	var sexo string
+	switch line2[20] {
+	case 'M', 'F':
+		sexo = line2[20:21]
+	case '<':
+	default:
+		return MRZ{}, &DocumentoError{Doc: ErrInvalidMRZ, Field: "sexo", Err: ErrDocumentoCharacter}
+	}
+
+	sobrenome, nomes, _ := strings.Cut(strings.TrimRight(line1[5:], "<"), "<<")
+
+	return MRZ{
+		Sobrenome:      strings.ReplaceAll(sobrenome, "<", " "),
+		Nomes:          strings.ReplaceAll(nomes, "<", " "),
+		Numero:         numero,
+		Nacionalidade:  line2[10:13],
+		Nascimento:     nascimento,
+		Sexo:           sexo,
+		Validade:       validade,
+		DadosOpcionais: strings.TrimRight(line2[28:42], "<"),
+	}, nil
+}
+
+// mrzCheckDigit calculates an ICAO 9303 check digit, using the weights 7, 3 and 1.
+// Letters are worth 10 to 35 and the filler character is worth 0.
+func mrzCheckDigit(s string) byte {
+	weights := [3]int{7, 3, 1}
+
+	var sum int
+	for i := range len(s) {
+		var v int
+		switch c := s[i]; {
+		case isDigit(c):
+			v = int(c - '0')
+		case isAlphaUpper(c):
+			v = int(c-'A') + 10
+		}
+		sum += v * weights[i%3]
+	}
+
+	return byte(sum%10) + '0'
+}
+
+// mrzDate parses a YYMMDD date in the 2000s.
+func mrzDate(s string) (time.Time, bool) {
+	t, err := time.Parse("060102", s)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	if t.Year() < 2000 {
+		t = t.AddDate(100, 0, 0)
+	}
+
+	return t, true
+}
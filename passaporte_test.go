@@ -0,0 +1,124 @@
+package br
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGeneratePassaporte(t *testing.T) {
+	for range 1_000_000 {
+		if p := GeneratePassaporte(); !p.IsValid() {
+			t.Errorf("invalid Passaporte generated: %s", string(p))
+		}
+	}
+}
+
+func TestPassaporte_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		p     Passaporte
+		want  string
+		field string
+		err   error
+	}{
+		{name: "raw", p: "FZ123456", want: "FZ123456"},
+		{name: "lower", p: "fz123456", want: "FZ123456"},
+		{name: "separated", p: "FZ-123.456", want: "FZ123456"},
+		{name: "spaced", p: "FZ 123456", want: "FZ123456"},
+		{name: "short", p: "FZ12345", err: ErrDocumentoLength},
+		{name: "long", p: "FZ1234567", err: ErrDocumentoLength},
+		{name: "digit in serie", p: "F1123456", field: "serie", err: ErrDocumentoCharacter},
+		{name: "letter in numero", p: "FZ12345A", field: "numero", err: ErrDocumentoCharacter},
+		{name: "empty", p: "", err: ErrDocumentoLength},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.p.Validate()
+			if tc.err == nil {
+				if err != nil {
+					t.Fatalf("\npassaporte: %s\nshould be valid, err: %v", tc.p, err)
+				}
+
+				if tc.p.String() != tc.want {
+					t.Errorf("\npassaporte: %s\nwant: %s\ngot: %s", tc.p, tc.want, tc.p.String())
+				}
+				return
+			}
+
+			if !errors.Is(err, tc.err) || !errors.Is(err, ErrInvalidPassaporte) {
+				t.Fatalf("\npassaporte: %s\nwant err: %v\ngot err: %v", tc.p, tc.err, err)
+			}
+
+			var docErr *DocumentoError
+			if !errors.As(err, &docErr) || docErr.Field != tc.field {
+				t.Errorf("\npassaporte: %s\nwant field: %s\ngot err: %v", tc.p, tc.field, err)
+			}
+		})
+	}
+}
+
+func TestParseMRZ(t *testing.T) {
+	const (
+		line1 = "P<BRASILVA<SANTOS<<JOAO<CARLOS<<<<<<<<<<<<<<"
+		line2 = "FZ123456<1BRA8504124M3001156<<<<<<<<<<<<<<<8"
+	)
+
+	for _, input := range []string{line1 + "\n" + line2, line1 + line2, line1 + "\r\n" + line2 + "\n"} {
+		mrz, err := ParseMRZ(input)
+		if err != nil {
+			t.Fatalf("failed to parse mrz: %v", err)
+		}
+
+		want := MRZ{
+			Sobrenome:     "SILVA SANTOS",
+			Nomes:         "JOAO CARLOS",
+			Numero:        "FZ123456",
+			Nacionalidade: "BRA",
+			Nascimento:    time.Date(1985, time.April, 12, 0, 0, 0, 0, time.UTC),
+			Sexo:          "M",
+			Validade:      time.Date(2030, time.January, 15, 0, 0, 0, 0, time.UTC),
+		}
+
+		if mrz != want {
+			t.Errorf("\nwant: %+v\ngot: %+v", want, mrz)
+		}
+	}
+
+	mrz, err := ParseMRZ(line1 + "\n" + "FZ123456<1BRA0501013F300115612345678901<<<04")
+	if err != nil {
+		t.Fatalf("failed to parse mrz with optional data: %v", err)
+	}
+
+	if mrz.DadosOpcionais != "12345678901" || mrz.Sexo != "F" || mrz.Nascimento.Year() != 2005 {
+		t.Errorf("unexpected mrz: %+v", mrz)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		line1 string
+		line2 string
+		field string
+		err   error
+	}{
+		{name: "numero check digit", line1: line1, line2: "FZ123456<2BRA8504124M3001156<<<<<<<<<<<<<<<8", field: "numero", err: ErrDocumentoCheckDigit},
+		{name: "nascimento check digit", line1: line1, line2: "FZ123456<1BRA8504125M3001156<<<<<<<<<<<<<<<8", field: "nascimento", err: ErrDocumentoCheckDigit},
+		{name: "validade check digit", line1: line1, line2: "FZ123456<1BRA8504124M3001157<<<<<<<<<<<<<<<8", field: "validade", err: ErrDocumentoCheckDigit},
+		{name: "optional check digit", line1: line1, line2: "FZ123456<1BRA0501013F300115612345678901<<<14", field: "dados opcionais", err: ErrDocumentoCheckDigit},
+		{name: "composite check digit", line1: line1, line2: "FZ123456<1BRA8504124M3001156<<<<<<<<<<<<<<<9", field: "composto", err: ErrDocumentoCheckDigit},
+		{name: "not brazilian", line1: "P<PRTSILVA<<JOAO<<<<<<<<<<<<<<<<<<<<<<<<<<<<", line2: line2, field: "emissor", err: ErrDocumentoCharacter},
+		{name: "short", line1: line1, line2: line2[:43], err: ErrDocumentoLength},
+		{name: "lowercase", line1: line1, line2: "fz123456<1BRA8504124M3001156<<<<<<<<<<<<<<<8", err: ErrDocumentoCharacter},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseMRZ(tc.line1 + "\n" + tc.line2)
+			if !errors.Is(err, tc.err) || !errors.Is(err, ErrInvalidMRZ) {
+				t.Fatalf("\nwant err: %v\ngot err: %v", tc.err, err)
+			}
+
+			var docErr *DocumentoError
+			if !errors.As(err, &docErr) || docErr.Field != tc.field {
+				t.Errorf("\nwant field: %s\ngot err: %v", tc.field, err)
+			}
+		})
+	}
+}
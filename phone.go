@@ -0,0 +1,321 @@
+package br
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"github.com/phenpessoa/br/x/address"
+)
+
+// DDD represents a Brazilian area code (Discagem Direta a Distância).
+type DDD uint8
+
+// ddds maps every DDD in the ANATEL numbering plan to its UF.
+var ddds = map[DDD]address.UF{
+	11: address.SP, 12: address.SP, 13: address.SP, 14: address.SP, 15: address.SP,
+	16: address.SP, 17: address.SP, 18: address.SP, 19: address.SP,
+	21: address.RJ, 22: address.RJ, 24: address.RJ,
+	27: address.ES, 28: address.ES,
+	31: address.MG, 32: address.MG, 33: address.MG, 34: address.MG, 35: address.MG, 37: address.MG, 38: address.MG,
+	41: address.PR, 42: address.PR, 43: address.PR, 44: address.PR, 45: address.PR, 46: address.PR,
+	47: address.SC, 48: address.SC, 49: address.SC,
+	51: address.RS, 53: address.RS, 54: address.RS, 55: address.RS,
+	61: address.DF,
+	62: address.GO, 64: address.GO,
+	63: address.TO,
+	65: address.MT, 66: address.MT,
+	67: address.MS,
+	68: address.AC,
+	69: address.RO,
+	71: address.BA, 73: address.BA, 74: address.BA, 75: address.BA, 77: address.BA,
+	79: address.SE,
+	81: address.PE, 87: address.PE,
+	82: address.AL,
+	83: address.PB,
+	84: address.RN,
+	85: address.CE, 88: address.CE,
+	86: address.PI, 89: address.PI,
+	91: address.PA, 93: address.PA, 94: address.PA,
+	92: address.AM, 97: address.AM,
+	95: address.RR,
+	96: address.AP,
+	98: address.MA, 99: address.MA,
+}
+
+// IsValid checks whether the DDD is part of the ANATEL numbering plan.
+func (ddd DDD) IsValid() bool {
+	_, ok := ddds[ddd]
+	return ok
+}
+
+// UF returns the UF the DDD belongs to.
+//
+// If the DDD is invalid, 0 is returned.
+func (ddd DDD) UF() address.UF {
+	return ddds[ddd]
+}
+
+// String returns the DDD as 2 digits.
+func (ddd DDD) String() string {
+	if !ddd.IsValid() {
+		return ""
+	}
+	return string([]byte{byte(ddd/10) + '0', byte(ddd%10) + '0'})
+}
+
+// PhoneKind is the kind of a Brazilian phone number.
+type PhoneKind uint8
+
+const (
+	// PhoneMobile is a mobile number, with a DDD and 9 digits starting with 9.
+	PhoneMobile PhoneKind = iota + 1
+
+	// PhoneLandline is a landline number, with a DDD and 8 digits starting with 2 to 5.
+	PhoneLandline
+
+	// PhoneTollFree is a 0800 number, paid by the called party.
+	PhoneTollFree
+
+	// PhoneSharedCost is a 0300 number, whose cost is shared by both parties.
+	PhoneSharedCost
+
+	// PhoneUnique is a nationwide number without a DDD, such as 4004 XXXX and 3003 XXXX.
+	PhoneUnique
+)
+
+// String returns the name of the PhoneKind.
+func (k PhoneKind) String() string {
+	switch k {
+	case PhoneMobile:
+		return "mobile"
+	case PhoneLandline:
+		return "landline"
+	case PhoneTollFree:
+		return "0800"
+	case PhoneSharedCost:
+		return "0300"
+	case PhoneUnique:
+		return "unique"
+	default:
+		return ""
+	}
+}
+
+// Phone represents a Brazilian phone number.
+type Phone string
+
+// NewPhone creates a new Phone instance from a string representation.
+//
+// It accepts the common forms, such as "(11) 91234-5678", "+55 11 912345678", "011912345678",
+// with a carrier selection code as in "0 15 11 91234-5678", "0800 123 4567" and "4004-1234".
+func NewPhone(s string) (Phone, error) {
+	p := Phone(s)
+	if !p.IsValid() {
+		return "", ErrInvalidPhone
+	}
+	return p, nil
+}
+
+// GeneratePhone generates a pseudo-random valid mobile Phone, formatted as (XX) 9XXXX-XXXX.
+func GeneratePhone() Phone {
+	var ddd DDD
+	for !ddd.IsValid() {
+		ddd = DDD(randomUint64n(90) + 10)
+	}
+
+	data := make([]byte, 15)
+	data[0] = '('
+	data[1] = byte(ddd/10) + '0'
+	data[2] = byte(ddd%10) + '0'
+	data[3] = ')'
+	data[4] = ' '
+	data[5] = '9'
+	data[10] = '-'
+
+	for i := 6; i < 10; i++ {
+		data[i] = randomDigit()
+	}
+
+	for i := 11; i < 15; i++ {
+		data[i] = randomDigit()
+	}
+
+	return Phone(string(data))
+}
+
+// ErrInvalidPhone is an error returned when an invalid Phone is encountered.
+var ErrInvalidPhone = errors.New("br: invalid phone")
+
+type phone struct {
+	kind   PhoneKind
+	ddd    DDD
+	number string
+}
+
+// parse strips the punctuation, the country code, the trunk prefix and the carrier selection code of the Phone.
+func (p Phone) parse() (phone, bool) {
+	var (
+		digits = make([]byte, 0, len(p))
+		plus   bool
+	)
+
+	for i := range len(p) {
+		switch c := p[i]; {
+		case isDigit(c):
+			digits = append(digits, c)
+		case c == '+' && len(digits) == 0 && !plus:
+			plus = true
+		case c == ' ' || c == '(' || c == ')' || c == '-' || c == '.':
+		default:
+			return phone{}, false
+		}
+	}
+
+	d := string(digits)
+
+	switch {
+	case plus:
+		if len(d) < 2 || d[:2] != "55" {
+			return phone{}, false
+		}
+		d = d[2:]
+	case len(d) == 11 && (d[:4] == "0800" || d[:4] == "0300"):
+		kind := PhoneTollFree
+		if d[1] == '3' {
+			kind = PhoneSharedCost
+		}
+		return phone{kind: kind, number: d}, true
+	case len(d) == 8 && (d[:3] == "300" || d[:3] == "400"):
+		return phone{kind: PhoneUnique, number: d}, true
+	case (len(d) == 12 || len(d) == 13) && d[:2] == "55":
+		d = d[2:]
+	case len(d) > 0 && d[0] == '0':
+		d = d[1:]
+		if len(d) == 12 || len(d) == 13 {
+			// Carrier selection code.
+			d = d[2:]
+		}
+	}
+
+	if len(d) != 10 && len(d) != 11 {
+		return phone{}, false
+	}
+
+	ddd := DDD(d[0]-'0')*10 + DDD(d[1]-'0')
+	if !ddd.IsValid() {
+		return phone{}, false
+	}
+
+	number := d[2:]
+	switch {
+	case len(number) == 9 && number[0] == '9':
+		return phone{kind: PhoneMobile, ddd: ddd, number: number}, true
+	case len(number) == 8 && number[0] >= '2' && number[0] <= '5':
+		return phone{kind: PhoneLandline, ddd: ddd, number: number}, true
+	default:
+		return phone{}, false
+	}
+}
+
+// IsValid checks whether the provided Phone is valid.
+//
+// Mobile numbers must have 9 digits starting with 9 and landline numbers must have
+// 8 digits starting with 2 to 5. The DDD must be part of the ANATEL numbering plan.
+func (p Phone) IsValid() bool {
+	_, ok := p.parse()
+	return ok
+}
+
+// Kind returns the kind of the Phone.
+//
+// If the Phone is invalid, 0 is returned.
+func (p Phone) Kind() PhoneKind {
+	parsed, _ := p.parse()
+	return parsed.kind
+}
+
+// DDD returns the area code of the Phone.
+//
+// If the Phone is invalid or has no DDD, such as 0800 numbers, 0 is returned.
+func (p Phone) DDD() DDD {
+	parsed, _ := p.parse()
+	return parsed.ddd
+}
+
+// String returns the Phone in the national format, such as (11) 91234-5678, (11) 3123-4567,
+// 0800 123 4567 or 4004-1234.
+func (p Phone) String() string {
+	parsed, ok := p.parse()
+	if !ok {
+		return ""
+	}
+
+	n := parsed.number
+	switch parsed.kind {
+	case PhoneTollFree, PhoneSharedCost:
+		return n[:4] + " " + n[4:7] + " " + n[7:]
+	case PhoneUnique:
+		return n[:4] + "-" + n[4:]
+	default:
+		return "(" + parsed.ddd.String() + ") " + n[:len(n)-4] + "-" + n[len(n)-4:]
+	}
+}
+
+// E164 returns the Phone in the E.164 format, such as +5511912345678.
+//
+// If the Phone is invalid or can not be dialed from abroad, such as 0800 numbers,
+// an empty string is returned.
+func (p Phone) E164() string {
+	parsed, ok := p.parse()
+	if !ok || parsed.ddd == 0 {
+		return ""
+	}
+	return "+55" + parsed.ddd.String() + parsed.number
+}
+
+// Value implements the driver.Valuer interface for Phone.
+func (p Phone) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// Scan implements the sql.Scanner interface for Phone.
+func (p *Phone) Scan(value any) error {
+	str, err := scanString("Phone", value)
+	if err != nil {
+		return err
+	}
+
+	_p, err := NewPhone(str)
+	if err != nil {
+		return fmt.Errorf("br: can not scan %q into Phone: %w", str, err)
+	}
+
+	*p = _p
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for Phone.
+func (p Phone) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + p.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Phone.
+func (p *Phone) UnmarshalJSON(b []byte) error {
+	str, ok, err := unmarshalJSONString(b)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %s into Phone: %w", b, err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	_p, err := NewPhone(str)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %q into Phone: %w", str, err)
+	}
+
+	*p = _p
+	return nil
+}
@@ -0,0 +1,100 @@
+package br
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/phenpessoa/br/x/address"
+)
+
+func TestGeneratePhone(t *testing.T) {
+	for range 100_000 {
+		if p := GeneratePhone(); p.Kind() != PhoneMobile {
+			t.Errorf("invalid Phone generated: %s", string(p))
+		}
+	}
+}
+
+func TestPhone_Parse(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		phone Phone
+		kind  PhoneKind
+		ddd   DDD
+		uf    address.UF
+		want  string
+		e164  string
+		valid bool
+	}{
+		{name: "formatted mobile", phone: "(11) 91234-5678", kind: PhoneMobile, ddd: 11, uf: address.SP, want: "(11) 91234-5678", e164: "+5511912345678", valid: true},
+		{name: "raw mobile", phone: "11912345678", kind: PhoneMobile, ddd: 11, uf: address.SP, want: "(11) 91234-5678", e164: "+5511912345678", valid: true},
+		{name: "e164 mobile", phone: "+5511912345678", kind: PhoneMobile, ddd: 11, uf: address.SP, want: "(11) 91234-5678", e164: "+5511912345678", valid: true},
+		{name: "international spaced", phone: "+55 11 912345678", kind: PhoneMobile, ddd: 11, uf: address.SP, want: "(11) 91234-5678", e164: "+5511912345678", valid: true},
+		{name: "country code without plus", phone: "5521987654321", kind: PhoneMobile, ddd: 21, uf: address.RJ, want: "(21) 98765-4321", e164: "+5521987654321", valid: true},
+		{name: "trunk prefix", phone: "011912345678", kind: PhoneMobile, ddd: 11, uf: address.SP, want: "(11) 91234-5678", e164: "+5511912345678", valid: true},
+		{name: "carrier selection", phone: "0 15 11 91234-5678", kind: PhoneMobile, ddd: 11, uf: address.SP, want: "(11) 91234-5678", e164: "+5511912345678", valid: true},
+		{name: "carrier selection landline", phone: "0xx21 3123-4567", valid: false},
+		{name: "landline", phone: "(61) 3123-4567", kind: PhoneLandline, ddd: 61, uf: address.DF, want: "(61) 3123-4567", e164: "+556131234567", valid: true},
+		{name: "landline DDD 55", phone: "5533334444", kind: PhoneLandline, ddd: 55, uf: address.RS, want: "(55) 3333-4444", e164: "+555533334444", valid: true},
+		{name: "landline with country code", phone: "555533334444", kind: PhoneLandline, ddd: 55, uf: address.RS, want: "(55) 3333-4444", e164: "+555533334444", valid: true},
+		{name: "landline with carrier", phone: "0211131234567", kind: PhoneLandline, ddd: 11, uf: address.SP, want: "(11) 3123-4567", e164: "+551131234567", valid: true},
+		{name: "0800", phone: "0800 123 4567", kind: PhoneTollFree, want: "0800 123 4567", valid: true},
+		{name: "0300", phone: "0300-123-4567", kind: PhoneSharedCost, want: "0300 123 4567", valid: true},
+		{name: "4004", phone: "4004-1234", kind: PhoneUnique, want: "4004-1234", valid: true},
+		{name: "3003", phone: "3003 1234", kind: PhoneUnique, want: "3003-1234", valid: true},
+		{name: "mobile without ninth digit", phone: "(11) 8123-4567", valid: false},
+		{name: "nine digits not starting with 9", phone: "(11) 81234-5678", valid: false},
+		{name: "landline starting with 1", phone: "(11) 1123-4567", valid: false},
+		{name: "invalid DDD", phone: "(20) 91234-5678", valid: false},
+		{name: "other country", phone: "+1 212 555 1234", valid: false},
+		{name: "no DDD", phone: "91234-5678", valid: false},
+		{name: "letters", phone: "(11) 9123A-5678", valid: false},
+		{name: "empty", phone: "", valid: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.phone.IsValid() != tc.valid {
+				t.Fatalf("\nphone: %s\nshould be valid: %v\nis valid: %v", tc.phone, tc.valid, tc.phone.IsValid())
+			}
+
+			if got := tc.phone.Kind(); got != tc.kind {
+				t.Errorf("\nphone: %s\nwant kind: %s\ngot kind: %s", tc.phone, tc.kind, got)
+			}
+
+			if got := tc.phone.DDD(); got != tc.ddd {
+				t.Errorf("\nphone: %s\nwant ddd: %d\ngot ddd: %d", tc.phone, tc.ddd, got)
+			}
+
+			if got := tc.phone.DDD().UF(); got != tc.uf {
+				t.Errorf("\nphone: %s\nwant uf: %s\ngot uf: %s", tc.phone, tc.uf, got)
+			}
+
+			if got := tc.phone.String(); got != tc.want {
+				t.Errorf("\nphone: %s\nshould be formatted like: %s\nis formatted like: %s", tc.phone, tc.want, got)
+			}
+
+			if got := tc.phone.E164(); got != tc.e164 {
+				t.Errorf("\nphone: %s\nwant e164: %s\ngot e164: %s", tc.phone, tc.e164, got)
+			}
+		})
+	}
+}
+
+func TestPhone_JSON(t *testing.T) {
+	data, err := json.Marshal(Phone("+5511912345678"))
+	if err != nil {
+		t.Fatalf("failed to marshal phone: %v", err)
+	}
+
+	if want := `"(11) 91234-5678"`; string(data) != want {
+		t.Errorf("\nwant: %s\ngot: %s", want, data)
+	}
+
+	var p Phone
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("failed to unmarshal phone: %v", err)
+	}
+
+	if err := json.Unmarshal([]byte(`"(20) 91234-5678"`), &p); err == nil {
+		t.Error("invalid phone unmarshaled without error")
+	}
+}
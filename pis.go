@@ -0,0 +1,209 @@
+package br
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+// PIS represents a Brazilian PIS/PASEP/NIT/NIS number.
+//
+// All of them share the same format and check digit algorithm.
+type PIS string
+
+// NewPIS creates a new PIS instance from a string representation.
+//
+// It verifies the PIS's validity using its checksum digit.
+func NewPIS(s string) (PIS, error) {
+	pis := PIS(s)
+	if !pis.IsValid() {
+		return "", ErrInvalidPIS
+	}
+	return pis, nil
+}
+
+// GeneratePIS generates a pseudo-random valid PIS.
+func GeneratePIS() PIS {
+	data := make([]byte, 14)
+	data[3] = '.'
+	data[9] = '.'
+	data[12] = '-'
+
+	for i := range 3 {
+		data[i] = randomDigit()
+	}
+
+	for i := 4; i < 9; i++ {
+		data[i] = randomDigit()
+	}
+
+	for i := 10; i < 12; i++ {
+		data[i] = randomDigit()
+	}
+
+	data[13], _ = pisIter14(data)
+
+	return PIS(string(data))
+}
+
+// ErrInvalidPIS is an error returned when an invalid PIS is encountered.
+var ErrInvalidPIS = errors.New("br: invalid pis")
+
+var pisTable = []int{3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// IsValid checks whether the provided PIS is valid based on its checksum digit.
+//
+// The formats accepted are: XXXXXXXXXXX and XXX.XXXXX.XX-X.
+func (pis PIS) IsValid() bool {
+	switch len(pis) {
+	case 11:
+		dByte, ok := pisIter11(pis)
+		if !ok {
+			return false
+		}
+
+		return pis[len(pis)-1] == dByte
+	case 14:
+		if pis[3] != '.' || pis[9] != '.' || pis[12] != '-' {
+			return false
+		}
+
+		dByte, ok := pisIter14(pis)
+		if !ok {
+			return false
+		}
+
+		return pis[len(pis)-1] == dByte
+	default:
+		return false
+	}
+}
+
+func pisIter14[T string | PIS | []byte](pis T) (byte, bool) {
+	if len(pis) != 14 || len(pisTable) != 10 {
+		panic("not 14 or 10 - pis")
+	}
+
+	var sum int
+
+	for i, d := range pisTable[:3] {
+		cur := pis[i]
+		if !isDigit(cur) {
+			return 0, false
+		}
+		sum += d * int(cur-'0')
+	}
+
+	for i, d := range pisTable[3:8] {
+		cur := pis[4:9][i]
+		if !isDigit(cur) {
+			return 0, false
+		}
+		sum += d * int(cur-'0')
+	}
+
+	for i, d := range pisTable[8:10] {
+		cur := pis[10:12][i]
+		if !isDigit(cur) {
+			return 0, false
+		}
+		sum += d * int(cur-'0')
+	}
+
+	return pisSumToDigit(sum), true
+}
+
+func pisIter11[T string | PIS | []byte](pis T) (byte, bool) {
+	if len(pis) != 11 || len(pisTable) != 10 {
+		panic("not 11 or 10 - pis")
+	}
+
+	var sum int
+
+	for i, d := range pisTable {
+		cur := pis[i]
+		if !isDigit(cur) {
+			return 0, false
+		}
+		sum += d * int(cur-'0')
+	}
+
+	return pisSumToDigit(sum), true
+}
+
+func pisSumToDigit(sum int) byte {
+	out := 11 - sum%11
+	if out >= 10 {
+		out = 0
+	}
+	return byte(out) + '0'
+}
+
+// String returns the formatted PIS string with punctuation as XXX.XXXXX.XX-X.
+func (pis PIS) String() string {
+	if !pis.IsValid() {
+		return ""
+	}
+
+	if len(pis) == 14 {
+		return string(pis)
+	}
+
+	out := make([]byte, 14)
+	out[3] = '.'
+	out[9] = '.'
+	out[12] = '-'
+
+	copy(out[0:3], pis[0:3])
+	copy(out[4:9], pis[3:8])
+	copy(out[10:12], pis[8:10])
+	out[13] = pis[10]
+
+	return string(out)
+}
+
+// Value implements the driver.Valuer interface for PIS.
+func (pis PIS) Value() (driver.Value, error) {
+	return pis.String(), nil
+}
+
+// Scan implements the sql.Scanner interface for PIS.
+func (pis *PIS) Scan(value any) error {
+	str, err := scanString("PIS", value)
+	if err != nil {
+		return err
+	}
+
+	_pis, err := NewPIS(str)
+	if err != nil {
+		return fmt.Errorf("br: can not scan %q into PIS: %w", str, err)
+	}
+
+	*pis = _pis
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for PIS.
+func (pis PIS) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + pis.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for PIS.
+func (pis *PIS) UnmarshalJSON(b []byte) error {
+	str, ok, err := unmarshalJSONString(b)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %s into PIS: %w", b, err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	_pis, err := NewPIS(str)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %q into PIS: %w", str, err)
+	}
+
+	*pis = _pis
+	return nil
+}
@@ -0,0 +1,194 @@
+package br
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+var pisSink PIS
+
+func BenchmarkGeneratePIS(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		pisSink = GeneratePIS()
+	}
+}
+
+func TestGeneratePIS(t *testing.T) {
+	for range 1_000_000 {
+		if pis := GeneratePIS(); !pis.IsValid() {
+			t.Errorf("invalid PIS generated: %s", string(pis))
+		}
+	}
+}
+
+func BenchmarkPIS_IsValid14(b *testing.B) {
+	const pis = PIS("120.54376.71-1")
+	if !pis.IsValid() {
+		b.Error("invalid pis on benchmark")
+		b.FailNow()
+	}
+	b.ReportAllocs()
+	for range b.N {
+		boolSink = pis.IsValid()
+	}
+}
+
+func BenchmarkPIS_IsValid11(b *testing.B) {
+	const pis = PIS("12054376711")
+	if !pis.IsValid() {
+		b.Error("invalid pis on benchmark")
+		b.FailNow()
+	}
+	b.ReportAllocs()
+	for range b.N {
+		boolSink = pis.IsValid()
+	}
+}
+
+func BenchmarkPIS_String11(b *testing.B) {
+	const pis = PIS("12054376711")
+	if !pis.IsValid() {
+		b.Error("invalid pis on benchmark")
+		b.FailNow()
+	}
+	b.ReportAllocs()
+	for range b.N {
+		stringSink = pis.String()
+	}
+}
+
+func TestPIS_IsValid(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		pis   PIS
+		valid bool
+	}{
+		{
+			name:  "formatted PIS",
+			pis:   PIS("120.54376.71-1"),
+			valid: true,
+		},
+		{
+			name:  "raw PIS",
+			pis:   PIS("12054376711"),
+			valid: true,
+		},
+		{
+			name:  "raw PIS check digit zero",
+			pis:   PIS("17028942200"),
+			valid: true,
+		},
+		{
+			name:  "invalid digit formatted PIS",
+			pis:   PIS("120.54376.71-2"),
+			valid: false,
+		},
+		{
+			name:  "invalid digit raw PIS",
+			pis:   PIS("12054376712"),
+			valid: false,
+		},
+		{
+			name:  "empty pis",
+			pis:   PIS(""),
+			valid: false,
+		},
+		{
+			name:  "incorrect length pis",
+			pis:   PIS("123"),
+			valid: false,
+		},
+		{
+			name:  "invalid characters",
+			pis:   PIS("abc.defgh.ij-k"),
+			valid: false,
+		},
+		{
+			name:  "cpf separators",
+			pis:   PIS("120.543.767-11"),
+			valid: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.pis.IsValid() != tc.valid {
+				t.Errorf(
+					"\npis: %s\nshould be valid: %v\nis valid: %v",
+					tc.pis, tc.valid, tc.pis.IsValid(),
+				)
+			}
+		})
+	}
+}
+
+func TestPIS_String(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		pis  PIS
+		want string
+	}{
+		{
+			name: "formatted PIS",
+			pis:  PIS("120.54376.71-1"),
+			want: "120.54376.71-1",
+		},
+		{
+			name: "raw PIS",
+			pis:  PIS("12054376711"),
+			want: "120.54376.71-1",
+		},
+		{
+			name: "invalid",
+			pis:  PIS("12054376712"),
+			want: "",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.pis.String() != tc.want {
+				t.Errorf(
+					"\npis: %s\nshould be formatted like: %s\nis formatted like: %s",
+					tc.pis, tc.want, tc.pis.String(),
+				)
+			}
+		})
+	}
+}
+
+func TestPIS_JSON(t *testing.T) {
+	data, err := json.Marshal(PIS("12054376711"))
+	if err != nil {
+		t.Fatalf("failed to marshal pis: %v", err)
+	}
+
+	if want := `"120.54376.71-1"`; string(data) != want {
+		t.Errorf("\nwant: %s\ngot: %s", want, data)
+	}
+
+	var pis PIS
+	if err := json.Unmarshal(data, &pis); err != nil {
+		t.Fatalf("failed to unmarshal pis: %v", err)
+	}
+
+	if pis != PIS("120.54376.71-1") {
+		t.Errorf("unmarshaled wrong pis: %s", pis)
+	}
+
+	if err := json.Unmarshal([]byte(`"12054376712"`), &pis); err == nil {
+		t.Error("invalid pis unmarshaled without error")
+	}
+}
+
+func TestPIS_Scan(t *testing.T) {
+	var pis PIS
+	if err := pis.Scan("12054376711"); err != nil {
+		t.Fatalf("failed to scan pis: %v", err)
+	}
+
+	if pis != PIS("12054376711") {
+		t.Errorf("scanned wrong pis: %s", pis)
+	}
+
+	if err := pis.Scan(12054376711); err == nil {
+		t.Error("scanned pis from int without error")
+	}
+}
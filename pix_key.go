@@ -0,0 +1,239 @@
+package br
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PixKeyKind is the kind of a PixKey, as defined by the DICT (Diretório de Identificadores de Contas Transacionais).
+type PixKeyKind uint8
+
+const (
+	// PixKeyCPF is a key made of a CPF.
+	PixKeyCPF PixKeyKind = iota + 1
+
+	// PixKeyCNPJ is a key made of a CNPJ.
+	PixKeyCNPJ
+
+	// PixKeyPhone is a key made of a mobile phone, in the E.164 format.
+	PixKeyPhone
+
+	// PixKeyEmail is a key made of an email address.
+	PixKeyEmail
+
+	// PixKeyEVP is a random key (Endereço Virtual de Pagamento), made of a UUID.
+	PixKeyEVP
+)
+
+// String returns the name of the PixKeyKind as used by the DICT, such as CPF or EVP.
+func (k PixKeyKind) String() string {
+	switch k {
+	case PixKeyCPF:
+		return "CPF"
+	case PixKeyCNPJ:
+		return "CNPJ"
+	case PixKeyPhone:
+		return "PHONE"
+	case PixKeyEmail:
+		return "EMAIL"
+	case PixKeyEVP:
+		return "EVP"
+	default:
+		return ""
+	}
+}
+
+// PixKey represents a key of the Brazilian instant payment system, Pix.
+type PixKey string
+
+// NewPixKey creates a new PixKey instance from a string representation.
+//
+// See PixKey.Kind for how the kind of the key is detected.
+func NewPixKey(s string) (PixKey, error) {
+	k := PixKey(s)
+	if !k.IsValid() {
+		return "", ErrInvalidPixKey
+	}
+	return k, nil
+}
+
+// ErrInvalidPixKey is an error returned when an invalid PixKey is encountered.
+var ErrInvalidPixKey = errors.New("br: invalid pix key")
+
+// pixKeyEmailMaxLen is the maximum length of an email key accepted by the DICT.
+const pixKeyEmailMaxLen = 77
+
+// parse detects the kind of the PixKey and returns it normalized to the form expected by the DICT.
+func (k PixKey) parse() (PixKeyKind, string) {
+	s := string(k)
+
+	switch {
+	case len(s) == 0:
+		return 0, ""
+	case s[0] == '+':
+		p := Phone(s)
+		if p.Kind() != PhoneMobile {
+			return 0, ""
+		}
+		return PixKeyPhone, p.E164()
+	case strings.IndexByte(s, '@') >= 0:
+		s = strings.ToLower(s)
+		if !pixKeyIsEmail(s) {
+			return 0, ""
+		}
+		return PixKeyEmail, s
+	case len(s) == 36 && s[8] == '-' && s[13] == '-' && s[18] == '-' && s[23] == '-':
+		s = strings.ToLower(s)
+		if !pixKeyIsUUID(s) {
+			return 0, ""
+		}
+		return PixKeyEVP, s
+	}
+
+	if cpf := CPF(s); cpf.IsValid() {
+		if len(s) == 11 {
+			return PixKeyCPF, s
+		}
+		return PixKeyCPF, s[0:3] + s[4:7] + s[8:11] + s[12:14]
+	}
+
+	if cnpj := CNPJ(s); cnpj.IsValid() {
+		return PixKeyCNPJ, strings.ToUpper(cnpj.AlphaNumerical())
+	}
+
+	return 0, ""
+}
+
+// pixKeyIsEmail checks a lowercase email address against the rules of the DICT.
+func pixKeyIsEmail(s string) bool {
+	if len(s) > pixKeyEmailMaxLen {
+		return false
+	}
+
+	local, domain, ok := strings.Cut(s, "@")
+	if !ok || local == "" || domain == "" {
+		return false
+	}
+
+	for i := range len(local) {
+		c := local[i]
+		if isDigit(c) || (c >= 'a' && c <= 'z') {
+			continue
+		}
+		if strings.IndexByte(".!#$%&'*+/=?^_`{|}~-", c) < 0 {
+			return false
+		}
+	}
+
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+
+		for i := range len(label) {
+			if c := label[i]; !isDigit(c) && (c < 'a' || c > 'z') && c != '-' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// pixKeyIsUUID checks whether s is a lowercase UUID, such as 123e4567-e89b-12d3-a456-426614174000.
+func pixKeyIsUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+
+	for i := range len(s) {
+		c := s[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !isDigit(c) && (c < 'a' || c > 'f') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// IsValid checks whether the provided PixKey is valid.
+func (k PixKey) IsValid() bool {
+	kind, _ := k.parse()
+	return kind != 0
+}
+
+// Kind returns the kind of the PixKey.
+//
+// Following the DICT rules, keys starting with + are phones, keys with an @ are emails and
+// keys formatted as a UUID are random keys. Any other key must be a CPF or a CNPJ, so an
+// 11-digit key is always a CPF, never a phone without its country code.
+//
+// If the PixKey is invalid, 0 is returned.
+func (k PixKey) Kind() PixKeyKind {
+	kind, _ := k.parse()
+	return kind
+}
+
+// String returns the PixKey normalized to the exact form expected by the DICT: CPFs and CNPJs
+// without punctuation, phones as +55DDNNNNNNNNN, and emails and random keys in lowercase.
+//
+// If the PixKey is invalid, an empty string is returned.
+func (k PixKey) String() string {
+	_, s := k.parse()
+	return s
+}
+
+// Value implements the driver.Valuer interface for PixKey.
+func (k PixKey) Value() (driver.Value, error) {
+	return k.String(), nil
+}
+
+// Scan implements the sql.Scanner interface for PixKey.
+func (k *PixKey) Scan(value any) error {
+	str, err := scanString("PixKey", value)
+	if err != nil {
+		return err
+	}
+
+	_k, err := NewPixKey(str)
+	if err != nil {
+		return fmt.Errorf("br: can not scan %q into PixKey: %w", str, err)
+	}
+
+	*k = _k
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for PixKey.
+func (k PixKey) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + k.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for PixKey.
+func (k *PixKey) UnmarshalJSON(b []byte) error {
+	str, ok, err := unmarshalJSONString(b)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %s into PixKey: %w", b, err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	_k, err := NewPixKey(str)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %q into PixKey: %w", str, err)
+	}
+
+	*k = _k
+	return nil
+}
@@ -0,0 +1,82 @@
+package br
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func BenchmarkPixKey_Kind(b *testing.B) {
+	b.ReportAllocs()
+	k := PixKey("529.982.247-25")
+	for range b.N {
+		_ = k.Kind()
+	}
+}
+
+func TestPixKey(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		key  PixKey
+		kind PixKeyKind
+		want string
+	}{
+		{name: "cpf", key: "52998224725", kind: PixKeyCPF, want: "52998224725"},
+		{name: "formatted cpf", key: "529.982.247-25", kind: PixKeyCPF, want: "52998224725"},
+		{name: "cnpj", key: "11222333000181", kind: PixKeyCNPJ, want: "11222333000181"},
+		{name: "formatted cnpj", key: "11.222.333/0001-81", kind: PixKeyCNPJ, want: "11222333000181"},
+		{name: "alphanumerical cnpj", key: "12.abc.345/01de-35", kind: PixKeyCNPJ, want: "12ABC34501DE35"},
+		{name: "phone", key: "+5511912345678", kind: PixKeyPhone, want: "+5511912345678"},
+		{name: "formatted phone", key: "+55 (11) 91234-5678", kind: PixKeyPhone, want: "+5511912345678"},
+		{name: "landline phone", key: "+551131234567", want: ""},
+		{name: "foreign phone", key: "+12125551234", want: ""},
+		{name: "phone without country code is a cpf", key: "11912345678", want: ""},
+		{name: "email", key: "fulano.de-tal+pix@example.com.br", kind: PixKeyEmail, want: "fulano.de-tal+pix@example.com.br"},
+		{name: "email uppercase", key: "Fulano@Example.COM", kind: PixKeyEmail, want: "fulano@example.com"},
+		{name: "email without local part", key: "@example.com", want: ""},
+		{name: "email with two at signs", key: "a@b@example.com", want: ""},
+		{name: "email with space", key: "fulano de tal@example.com", want: ""},
+		{name: "email with invalid domain", key: "fulano@-example.com", want: ""},
+		{name: "email with empty label", key: "fulano@example..com", want: ""},
+		{name: "email too long", key: "fulano@b2345678901234567890123456789012345678901234567890123456789012.b2345678.com", want: ""},
+		{name: "evp", key: "123e4567-e89b-12d3-a456-426614174000", kind: PixKeyEVP, want: "123e4567-e89b-12d3-a456-426614174000"},
+		{name: "evp uppercase", key: "123E4567-E89B-12D3-A456-426614174000", kind: PixKeyEVP, want: "123e4567-e89b-12d3-a456-426614174000"},
+		{name: "evp invalid character", key: "123e4567-e89b-12d3-a456-42661417400g", want: ""},
+		{name: "evp without dashes", key: "123e4567e89b12d3a456426614174000", want: ""},
+		{name: "invalid cpf", key: "52998224724", want: ""},
+		{name: "empty", key: "", want: ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.key.Kind(); got != tc.kind {
+				t.Errorf("\nkey: %s\nwant kind: %s\ngot kind: %s", tc.key, tc.kind, got)
+			}
+
+			if got := tc.key.IsValid(); got != (tc.kind != 0) {
+				t.Errorf("\nkey: %s\nshould be valid: %v\nis valid: %v", tc.key, tc.kind != 0, got)
+			}
+
+			if got := tc.key.String(); got != tc.want {
+				t.Errorf("\nkey: %s\nwant: %s\ngot: %s", tc.key, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPixKey_JSON(t *testing.T) {
+	data, err := json.Marshal(PixKey("529.982.247-25"))
+	if err != nil {
+		t.Fatalf("failed to marshal pix key: %v", err)
+	}
+
+	if want := `"52998224725"`; string(data) != want {
+		t.Errorf("\nwant: %s\ngot: %s", want, data)
+	}
+
+	var k PixKey
+	if err := json.Unmarshal(data, &k); err != nil {
+		t.Fatalf("failed to unmarshal pix key: %v", err)
+	}
+
+	if err := json.Unmarshal([]byte(`"11912345678"`), &k); err == nil {
+		t.Error("invalid pix key unmarshaled without error")
+	}
+}
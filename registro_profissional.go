@@ -0,0 +1,260 @@
+package br
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/phenpessoa/br/x/address"
+)
+
+// Conselho represents a Brazilian professional council.
+type Conselho uint8
+
+const (
+	// CRM is the Conselho Regional de Medicina.
+	CRM Conselho = iota + 1
+
+	// OAB is the Ordem dos Advogados do Brasil.
+	OAB
+
+	// CRO is the Conselho Regional de Odontologia.
+	CRO
+
+	// CREA is the Conselho Regional de Engenharia e Agronomia.
+	CREA
+
+	// CRC is the Conselho Regional de Contabilidade.
+	CRC
+)
+
+// String returns the abbreviation of the Conselho, such as CRM.
+func (c Conselho) String() string {
+	switch c {
+	case CRM:
+		return "CRM"
+	case OAB:
+		return "OAB"
+	case CRO:
+		return "CRO"
+	case CREA:
+		return "CREA"
+	case CRC:
+		return "CRC"
+	default:
+		return ""
+	}
+}
+
+// maxDigits returns the maximum amount of digits of a registration number in the Conselho.
+//
+// CREA numbers can be the national registration (RNP), which has 10 digits.
+func (c Conselho) maxDigits() int {
+	switch c {
+	case CREA:
+		return 10
+	default:
+		return 6
+	}
+}
+
+func parseConselho(s string) (Conselho, bool) {
+	for c := CRM; c <= CRC; c++ {
+		if c.String() == s {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+// RegistroProfissional represents a registration in a Brazilian professional council,
+// made of the council, the issuing UF and a number, such as CRM/SP 123456.
+type RegistroProfissional string
+
+// NewRegistroProfissional creates a new RegistroProfissional instance from a string representation.
+//
+// It accepts the common textual forms, such as "CRM/SP 123456", "OAB-RJ 123.456",
+// "CRO SP: 12345" and "CREA 1234567/SP".
+func NewRegistroProfissional(s string) (RegistroProfissional, error) {
+	rp := RegistroProfissional(s)
+	if _, err := rp.parse(); err != nil {
+		return "", err
+	}
+	return rp, nil
+}
+
+var (
+	// ErrInvalidRegistroProfissional is an error returned when an invalid RegistroProfissional is encountered.
+	//
+	// It is always returned together with a more specific error.
+	ErrInvalidRegistroProfissional = errors.New("br: invalid registro profissional")
+
+	// ErrRegistroProfissionalConselho is returned when the council is unknown.
+	ErrRegistroProfissionalConselho = errors.New("br: unknown registro profissional conselho")
+
+	// ErrRegistroProfissionalNumero is returned when the number is missing or does not follow the council's rules.
+	ErrRegistroProfissionalNumero = errors.New("br: invalid registro profissional number")
+)
+
+type registroProfissional struct {
+	conselho Conselho
+	uf       address.UF
+	numero   string
+}
+
+func registroProfissionalError(err error) error {
+	return fmt.Errorf("%w: %w", ErrInvalidRegistroProfissional, err)
+}
+
+func (rp RegistroProfissional) parse() (registroProfissional, error) {
+	fields := strings.FieldsFunc(strings.ToUpper(strings.ReplaceAll(string(rp), ".", "")), func(r rune) bool {
+		switch r {
+		case ' ', '/', '-', ':', ',':
+			return true
+		default:
+			return false
+		}
+	})
+
+	if len(fields) == 0 {
+		return registroProfissional{}, registroProfissionalError(ErrRegistroProfissionalConselho)
+	}
+
+	conselho, ok := parseConselho(fields[0])
+	if !ok {
+		return registroProfissional{}, registroProfissionalError(ErrRegistroProfissionalConselho)
+	}
+
+	var (
+		out    = registroProfissional{conselho: conselho}
+		suffix string
+	)
+
+	for _, field := range fields[1:] {
+		switch {
+		case len(field) == 2 && isAlphaUpper(field[0]) && isAlphaUpper(field[1]):
+			uf, err := address.NewUFFromStr(field)
+			if err != nil || out.uf != 0 {
+				return registroProfissional{}, registroProfissionalError(address.ErrInvalidUF)
+			}
+			out.uf = uf
+		case len(field) == 1 && isAlphaUpper(field[0]):
+			// OAB uses a letter after the number for supplementary registrations and interns.
+			if conselho != OAB || out.numero == "" || suffix != "" {
+				return registroProfissional{}, registroProfissionalError(ErrRegistroProfissionalNumero)
+			}
+			suffix = field
+		default:
+			if out.numero != "" || len(field) > conselho.maxDigits() {
+				return registroProfissional{}, registroProfissionalError(ErrRegistroProfissionalNumero)
+			}
+
+			for i := range len(field) {
+				if !isDigit(field[i]) {
+					return registroProfissional{}, registroProfissionalError(ErrRegistroProfissionalNumero)
+				}
+			}
+
+			out.numero = field
+		}
+	}
+
+	if out.uf == 0 {
+		return registroProfissional{}, registroProfissionalError(address.ErrInvalidUF)
+	}
+
+	if out.numero == "" || strings.Trim(out.numero, "0") == "" {
+		return registroProfissional{}, registroProfissionalError(ErrRegistroProfissionalNumero)
+	}
+
+	out.numero += suffix
+	return out, nil
+}
+
+// IsValid checks whether the RegistroProfissional has a known council, a valid UF and a number
+// that follows the council's length rules.
+func (rp RegistroProfissional) IsValid() bool {
+	_, err := rp.parse()
+	return err == nil
+}
+
+// Conselho returns the council of the RegistroProfissional.
+//
+// If the RegistroProfissional is invalid, 0 is returned.
+func (rp RegistroProfissional) Conselho() Conselho {
+	parsed, _ := rp.parse()
+	return parsed.conselho
+}
+
+// UF returns the UF of the RegistroProfissional.
+//
+// If the RegistroProfissional is invalid, 0 is returned.
+func (rp RegistroProfissional) UF() address.UF {
+	parsed, _ := rp.parse()
+	return parsed.uf
+}
+
+// Numero returns the number of the RegistroProfissional without punctuation,
+// including the OAB suffix letter, if any.
+//
+// If the RegistroProfissional is invalid, an empty string is returned.
+func (rp RegistroProfissional) Numero() string {
+	parsed, _ := rp.parse()
+	return parsed.numero
+}
+
+// String returns the RegistroProfissional in its canonical form, such as CRM/SP 123456.
+func (rp RegistroProfissional) String() string {
+	parsed, err := rp.parse()
+	if err != nil {
+		return ""
+	}
+	return parsed.conselho.String() + "/" + parsed.uf.String() + " " + parsed.numero
+}
+
+// Value implements the driver.Valuer interface for RegistroProfissional.
+func (rp RegistroProfissional) Value() (driver.Value, error) {
+	return rp.String(), nil
+}
+
+// Scan implements the sql.Scanner interface for RegistroProfissional.
+func (rp *RegistroProfissional) Scan(value any) error {
+	str, err := scanString("RegistroProfissional", value)
+	if err != nil {
+		return err
+	}
+
+	_rp, err := NewRegistroProfissional(str)
+	if err != nil {
+		return fmt.Errorf("br: can not scan %q into RegistroProfissional: %w", str, err)
+	}
+
+	*rp = _rp
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for RegistroProfissional.
+func (rp RegistroProfissional) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + rp.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for RegistroProfissional.
+func (rp *RegistroProfissional) UnmarshalJSON(b []byte) error {
+	str, ok, err := unmarshalJSONString(b)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %s into RegistroProfissional: %w", b, err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	_rp, err := NewRegistroProfissional(str)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %q into RegistroProfissional: %w", str, err)
+	}
+
+	*rp = _rp
+	return nil
+}
@@ -0,0 +1,109 @@
+package br
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/phenpessoa/br/x/address"
+)
+
+func TestRegistroProfissional_Parse(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		rp       RegistroProfissional
+		conselho Conselho
+		uf       address.UF
+		numero   string
+		want     string
+		err      error
+	}{
+		{name: "CRM slash", rp: "CRM/SP 123456", conselho: CRM, uf: address.SP, numero: "123456", want: "CRM/SP 123456"},
+		{name: "CRM dash", rp: "CRM-SP 123456", conselho: CRM, uf: address.SP, numero: "123456", want: "CRM/SP 123456"},
+		{name: "CRM lower", rp: "crm/sp 123456", conselho: CRM, uf: address.SP, numero: "123456", want: "CRM/SP 123456"},
+		{name: "OAB dotted", rp: "OAB/RJ 123.456", conselho: OAB, uf: address.RJ, numero: "123456", want: "OAB/RJ 123456"},
+		{name: "OAB suffix", rp: "OAB/SP 123.456-A", conselho: OAB, uf: address.SP, numero: "123456A", want: "OAB/SP 123456A"},
+		{name: "CRO colon", rp: "CRO SP: 12345", conselho: CRO, uf: address.SP, numero: "12345", want: "CRO/SP 12345"},
+		{name: "CREA number first", rp: "CREA 1234567/SP", conselho: CREA, uf: address.SP, numero: "1234567", want: "CREA/SP 1234567"},
+		{name: "CREA national", rp: "CREA-MG 1234567890", conselho: CREA, uf: address.MG, numero: "1234567890", want: "CREA/MG 1234567890"},
+		{name: "CRC", rp: "CRC/RS 54321", conselho: CRC, uf: address.RS, numero: "54321", want: "CRC/RS 54321"},
+		{name: "unknown conselho", rp: "CRF/SP 123456", err: ErrRegistroProfissionalConselho},
+		{name: "empty", rp: "", err: ErrRegistroProfissionalConselho},
+		{name: "missing uf", rp: "CRM 123456", err: address.ErrInvalidUF},
+		{name: "invalid uf", rp: "CRM/XX 123456", err: address.ErrInvalidUF},
+		{name: "abroad", rp: "CRM/ZZ 123456", err: address.ErrInvalidUF},
+		{name: "two ufs", rp: "CRM/SP/RJ 123456", err: address.ErrInvalidUF},
+		{name: "missing number", rp: "CRM/SP", err: ErrRegistroProfissionalNumero},
+		{name: "zero number", rp: "CRM/SP 000", err: ErrRegistroProfissionalNumero},
+		{name: "CRM too long", rp: "CRM/SP 1234567", err: ErrRegistroProfissionalNumero},
+		{name: "CRM suffix", rp: "CRM/SP 123456-A", err: ErrRegistroProfissionalNumero},
+		{name: "letters in number", rp: "CRM/SP 12A456", err: ErrRegistroProfissionalNumero},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewRegistroProfissional(string(tc.rp))
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) || !errors.Is(err, ErrInvalidRegistroProfissional) {
+					t.Errorf("\nregistro profissional: %s\nwant err: %v\ngot err: %v", tc.rp, tc.err, err)
+				}
+
+				if tc.rp.String() != "" {
+					t.Errorf("\nregistro profissional: %s\ninvalid with non empty string: %s", tc.rp, tc.rp.String())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("\nregistro profissional: %s\nshould be valid, err: %v", tc.rp, err)
+			}
+
+			if tc.rp.Conselho() != tc.conselho || tc.rp.UF() != tc.uf || tc.rp.Numero() != tc.numero {
+				t.Errorf(
+					"\nregistro profissional: %s\nwant: %s %s %s\ngot: %s %s %s",
+					tc.rp, tc.conselho, tc.uf, tc.numero, tc.rp.Conselho(), tc.rp.UF(), tc.rp.Numero(),
+				)
+			}
+
+			if tc.rp.String() != tc.want {
+				t.Errorf(
+					"\nregistro profissional: %s\nshould be formatted like: %s\nis formatted like: %s",
+					tc.rp, tc.want, tc.rp.String(),
+				)
+			}
+		})
+	}
+}
+
+func TestRegistroProfissional_JSON(t *testing.T) {
+	data, err := json.Marshal(RegistroProfissional("oab-rj 123.456"))
+	if err != nil {
+		t.Fatalf("failed to marshal registro profissional: %v", err)
+	}
+
+	if want := `"OAB/RJ 123456"`; string(data) != want {
+		t.Errorf("\nwant: %s\ngot: %s", want, data)
+	}
+
+	var rp RegistroProfissional
+	if err := json.Unmarshal(data, &rp); err != nil {
+		t.Fatalf("failed to unmarshal registro profissional: %v", err)
+	}
+
+	if rp.Conselho() != OAB {
+		t.Errorf("unmarshaled wrong registro profissional: %s", rp)
+	}
+
+	if err := json.Unmarshal([]byte(`"OAB/XX 1"`), &rp); err == nil {
+		t.Error("invalid registro profissional unmarshaled without error")
+	}
+}
+
+func TestRegistroProfissional_Scan(t *testing.T) {
+	var rp RegistroProfissional
+	if err := rp.Scan([]byte("CRM/SP 123456")); err != nil {
+		t.Fatalf("failed to scan registro profissional: %v", err)
+	}
+
+	if err := rp.Scan("CRM/SP"); err == nil {
+		t.Error("invalid registro profissional scanned without error")
+	}
+}
@@ -0,0 +1,230 @@
+package br
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/phenpessoa/br/x/address"
+)
+
+// RG represents a Brazilian identity card number (Registro Geral).
+//
+// The RG is issued by each UF and there is no national format, so an RG can only be
+// validated against the UF that issued it. Only some UFs define a check digit, for the
+// others the validation is structural. Validate reports which one was performed.
+type RG string
+
+// NewRG creates a new RG instance from a string representation.
+//
+// It verifies the RG's validity for the given UF.
+// The returned error is always an *RGError.
+func NewRG(s string, uf address.UF) (RG, error) {
+	rg := RG(s)
+	if _, err := rg.Validate(uf); err != nil {
+		return "", err
+	}
+	return rg, nil
+}
+
+// GenerateRG generates a pseudo-random valid RG in the SP format, XX.XXX.XXX-X.
+func GenerateRG() RG {
+	data := make([]byte, 12)
+	data[2] = '.'
+	data[6] = '.'
+	data[10] = '-'
+
+	for i := range 2 {
+		data[i] = randomDigit()
+	}
+
+	for i := 3; i < 6; i++ {
+		data[i] = randomDigit()
+	}
+
+	for i := 7; i < 10; i++ {
+		data[i] = randomDigit()
+	}
+
+	var digits [8]byte
+	copy(digits[0:2], data[0:2])
+	copy(digits[2:5], data[3:6])
+	copy(digits[5:8], data[7:10])
+	data[11] = rgSPCheckDigit(digits[:])
+
+	return RG(string(data))
+}
+
+var (
+	// ErrInvalidRG is an error returned when an invalid RG is encountered.
+	//
+	// Every *RGError wraps it.
+	ErrInvalidRG = errors.New("br: invalid rg")
+
+	// ErrRGLength is returned when the RG does not have a length used by the UF.
+	ErrRGLength = errors.New("br: invalid rg length")
+
+	// ErrRGCharacter is returned when the RG has an unexpected character.
+	ErrRGCharacter = errors.New("br: invalid rg character")
+
+	// ErrRGCheckDigit is returned when the check digit of the RG does not match.
+	ErrRGCheckDigit = errors.New("br: invalid rg check digit")
+)
+
+// RGError is the error returned when an RG fails validation.
+//
+// It matches both ErrInvalidRG and Err with errors.Is.
+type RGError struct {
+	// UF is the UF the RG was validated against.
+	UF address.UF
+
+	// Err is the reason the validation failed.
+	//
+	// It is one of ErrRGLength, ErrRGCharacter, ErrRGCheckDigit or address.ErrInvalidUF.
+	Err error
+}
+
+func (e *RGError) Error() string {
+	return fmt.Sprintf("%s (uf %s)", e.Err, e.UF)
+}
+
+func (e *RGError) Unwrap() []error {
+	return []error{ErrInvalidRG, e.Err}
+}
+
+// RGValidation reports how an RG was validated.
+type RGValidation uint8
+
+const (
+	// RGStructural means only the length and characters of the RG were checked,
+	// because the UF does not define a check digit that can be verified.
+	RGStructural RGValidation = iota + 1
+
+	// RGCheckDigit means the RG's check digit was also verified.
+	RGCheckDigit
+)
+
+// String returns the name of the RGValidation.
+func (v RGValidation) String() string {
+	switch v {
+	case RGStructural:
+		return "structural"
+	case RGCheckDigit:
+		return "check digit"
+	default:
+		return ""
+	}
+}
+
+// IsValid checks whether the RG is valid for the given UF.
+func (rg RG) IsValid(uf address.UF) bool {
+	_, err := rg.Validate(uf)
+	return err == nil
+}
+
+// Validate checks whether the RG is valid for the given UF, reporting how it was validated.
+//
+// For SP, the RG must have 8 digits followed by a mod 11 check digit, which may be an X.
+// For the other UFs, the RG must have between 5 and 14 digits, optionally followed by an X.
+//
+// RJ RGs also end with a check digit, but the Detran-RJ, which issues them, does not publish
+// how it is calculated, and the rules that circulate disagree with each other on real
+// documents. Rejecting a valid RG is worse than accepting a mistyped one, so RJ RGs are
+// validated structurally, like the RGs of the UFs without a check digit.
+// A leading UF abbreviation, such as in MG-12.345.678, and punctuation are ignored.
+//
+// The returned error is always an *RGError.
+func (rg RG) Validate(uf address.UF) (RGValidation, error) {
+	if uf == address.ZZ || uf.String() == "" {
+		return 0, &RGError{UF: uf, Err: address.ErrInvalidUF}
+	}
+
+	d := rg.normalize(uf)
+
+	for i, c := range d {
+		if !isDigit(c) && (c != 'X' || i != len(d)-1) {
+			return 0, &RGError{UF: uf, Err: ErrRGCharacter}
+		}
+	}
+
+	if uf == address.SP {
+		if len(d) != 9 {
+			return 0, &RGError{UF: uf, Err: ErrRGLength}
+		}
+
+		if rgSPCheckDigit(d[:8]) != d[8] {
+			return 0, &RGError{UF: uf, Err: ErrRGCheckDigit}
+		}
+
+		return RGCheckDigit, nil
+	}
+
+	if len(d) < 5 || len(d) > 14 {
+		return 0, &RGError{UF: uf, Err: ErrRGLength}
+	}
+
+	return RGStructural, nil
+}
+
+// rgSPCheckDigit calculates the SP check digit over 8 digits with the weights 2 to 9.
+func rgSPCheckDigit(d []byte) byte {
+	var sum int
+	for i := range 8 {
+		sum += (i + 2) * int(d[i]-'0')
+	}
+
+	switch dv := 11 - sum%11; dv {
+	case 10:
+		return 'X'
+	case 11:
+		return '0'
+	default:
+		return byte(dv) + '0'
+	}
+}
+
+// normalize strips the punctuation and the leading UF abbreviation of the RG and converts it to uppercase.
+func (rg RG) normalize(uf address.UF) []byte {
+	out := make([]byte, 0, len(rg))
+	for i := range len(rg) {
+		switch c := rg[i]; c {
+		case '.', '-', '/', ' ':
+		default:
+			out = append(out, asciiLowerToUpper(c))
+		}
+	}
+
+	if abbr := uf.String(); len(out) > 2 && string(out[:2]) == abbr {
+		out = out[2:]
+	}
+
+	return out
+}
+
+// Format returns the RG validated against the given UF and normalized.
+//
+// RGs with 8 digits and a check digit are formatted as XX.XXX.XXX-X,
+// the others are returned without punctuation.
+//
+// If the RG is not valid for the UF, an empty string is returned.
+func (rg RG) Format(uf address.UF) string {
+	if !rg.IsValid(uf) {
+		return ""
+	}
+
+	d := rg.normalize(uf)
+	if len(d) != 9 {
+		return string(d)
+	}
+
+	out := make([]byte, 12)
+	out[2] = '.'
+	out[6] = '.'
+	out[10] = '-'
+
+	copy(out[0:2], d[0:2])
+	copy(out[3:6], d[2:5])
+	copy(out[7:10], d[5:8])
+	out[11] = d[8]
+
+	return string(out)
+}
@@ -0,0 +1,88 @@
+package br
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/phenpessoa/br/x/address"
+)
+
+func TestGenerateRG(t *testing.T) {
+	for range 1_000_000 {
+		if rg := GenerateRG(); !rg.IsValid(address.SP) {
+			t.Errorf("invalid RG generated: %s", string(rg))
+		}
+	}
+}
+
+func TestRG_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		rg         RG
+		uf         address.UF
+		validation RGValidation
+		err        error
+	}{
+		{name: "SP formatted", rg: "24.678.131-2", uf: address.SP, validation: RGCheckDigit},
+		{name: "SP raw", rg: "246781312", uf: address.SP, validation: RGCheckDigit},
+		{name: "SP X", rg: "00.000.005-X", uf: address.SP, validation: RGCheckDigit},
+		{name: "SP x lower", rg: "00.000.005-x", uf: address.SP, validation: RGCheckDigit},
+		{name: "SP with uf", rg: "SP-24.678.131-2", uf: address.SP, validation: RGCheckDigit},
+		{name: "SP invalid check digit", rg: "24.678.131-3", uf: address.SP, err: ErrRGCheckDigit},
+		{name: "SP invalid X", rg: "24.678.131-X", uf: address.SP, err: ErrRGCheckDigit},
+		{name: "SP short", rg: "4.678.131-2", uf: address.SP, err: ErrRGLength},
+		{name: "RJ", rg: "12.345.678-9", uf: address.RJ, validation: RGStructural},
+		{name: "RJ check digit not verified", rg: "12.345.678-0", uf: address.RJ, validation: RGStructural},
+		{name: "MG with uf", rg: "MG-12.345.678", uf: address.MG, validation: RGStructural},
+		{name: "RS long", rg: "1234567890", uf: address.RS, validation: RGStructural},
+		{name: "too short", rg: "1234", uf: address.RJ, err: ErrRGLength},
+		{name: "too long", rg: "123456789012345", uf: address.RJ, err: ErrRGLength},
+		{name: "X in the middle", rg: "12.345.X78-9", uf: address.RJ, err: ErrRGCharacter},
+		{name: "letters", rg: "AB.CDE.FGH-I", uf: address.BA, err: ErrRGCharacter},
+		{name: "abroad", rg: "24.678.131-2", uf: address.ZZ, err: address.ErrInvalidUF},
+		{name: "invalid uf", rg: "24.678.131-2", uf: 0, err: address.ErrInvalidUF},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			validation, err := tc.rg.Validate(tc.uf)
+			if tc.err == nil {
+				if err != nil {
+					t.Fatalf("\nrg: %s\nuf: %s\nshould be valid, err: %v", tc.rg, tc.uf, err)
+				}
+
+				if validation != tc.validation {
+					t.Errorf("\nrg: %s\nwant validation: %s\ngot validation: %s", tc.rg, tc.validation, validation)
+				}
+				return
+			}
+
+			if !errors.Is(err, tc.err) || !errors.Is(err, ErrInvalidRG) {
+				t.Errorf("\nrg: %s\nuf: %s\nwant err: %v\ngot err: %v", tc.rg, tc.uf, tc.err, err)
+			}
+		})
+	}
+}
+
+func TestRG_Format(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		rg   RG
+		uf   address.UF
+		want string
+	}{
+		{name: "SP raw", rg: "246781312", uf: address.SP, want: "24.678.131-2"},
+		{name: "SP with uf", rg: "sp 24678131-2", uf: address.SP, want: "24.678.131-2"},
+		{name: "SP X", rg: "00000005x", uf: address.SP, want: "00.000.005-X"},
+		{name: "RJ 9 digits", rg: "123456789", uf: address.RJ, want: "12.345.678-9"},
+		{name: "MG 8 digits", rg: "MG-12.345.678", uf: address.MG, want: "12345678"},
+		{name: "SP invalid", rg: "246781313", uf: address.SP, want: ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.rg.Format(tc.uf); got != tc.want {
+				t.Errorf(
+					"\nrg: %s\nshould be formatted like: %s\nis formatted like: %s",
+					tc.rg, tc.want, got,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package br
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"github.com/phenpessoa/br/x/address"
+)
+
+// TituloEleitor represents a Brazilian voter ID (Título de Eleitor).
+type TituloEleitor string
+
+// NewTituloEleitor creates a new TituloEleitor instance from a string representation.
+//
+// It verifies the TituloEleitor's validity using checksum digits.
+func NewTituloEleitor(s string) (TituloEleitor, error) {
+	te := TituloEleitor(s)
+	if !te.IsValid() {
+		return "", ErrInvalidTituloEleitor
+	}
+	return te, nil
+}
+
+// GenerateTituloEleitor generates a pseudo-random valid TituloEleitor from a random UF.
+func GenerateTituloEleitor() TituloEleitor {
+	code := byte(randomUint64n(uint64(len(tituloEleitorUFs)-1))) + 1
+	return generateTituloEleitor(code)
+}
+
+// GenerateTituloEleitorForUF generates a pseudo-random valid TituloEleitor issued by the given UF.
+//
+// Pass address.ZZ to generate a TituloEleitor of a voter abroad.
+func GenerateTituloEleitorForUF(uf address.UF) (TituloEleitor, error) {
+	code, ok := tituloEleitorUFCodes[uf]
+	if !ok {
+		return "", address.ErrInvalidUF
+	}
+	return generateTituloEleitor(code), nil
+}
+
+func generateTituloEleitor(code byte) TituloEleitor {
+	data := make([]byte, 14)
+	data[4] = ' '
+	data[9] = ' '
+
+	for i := range 4 {
+		data[i] = randomDigit()
+	}
+
+	for i := 5; i < 9; i++ {
+		data[i] = randomDigit()
+	}
+
+	data[10] = code/10 + '0'
+	data[11] = code%10 + '0'
+
+	digits, _ := TituloEleitor(data).digits()
+	data[12], data[13] = tituloEleitorCheckDigits(digits)
+
+	return TituloEleitor(string(data))
+}
+
+// ErrInvalidTituloEleitor is an error returned when an invalid TituloEleitor is encountered.
+var ErrInvalidTituloEleitor = errors.New("br: invalid titulo de eleitor")
+
+var tituloEleitorFirstTable = []int{2, 3, 4, 5, 6, 7, 8, 9}
+
+// tituloEleitorUFs maps the UF codes used by the TSE, which differ from the IBGE ones, to address.UF.
+var tituloEleitorUFs = [...]address.UF{
+	1: address.SP, 2: address.MG, 3: address.RJ, 4: address.RS, 5: address.BA, 6: address.PR, 7: address.CE,
+	8: address.PE, 9: address.SC, 10: address.GO, 11: address.MA, 12: address.PB, 13: address.PA, 14: address.ES,
+	15: address.PI, 16: address.RN, 17: address.AL, 18: address.MT, 19: address.MS, 20: address.DF, 21: address.SE,
+	22: address.AM, 23: address.RO, 24: address.AC, 25: address.AP, 26: address.RR, 27: address.TO, 28: address.ZZ,
+}
+
+var tituloEleitorUFCodes = func() map[address.UF]byte {
+	m := make(map[address.UF]byte, len(tituloEleitorUFs))
+	for code, uf := range tituloEleitorUFs {
+		if uf != 0 {
+			m[uf] = byte(code)
+		}
+	}
+	return m
+}()
+
+// IsValid checks whether the provided TituloEleitor is valid based on its UF code and checksum digits.
+//
+// The formats accepted are: XXXXXXXXXXXX and XXXX XXXX XXXX.
+func (te TituloEleitor) IsValid() bool {
+	digits, ok := te.digits()
+	if !ok {
+		return false
+	}
+
+	if tituloEleitorUF(digits) == 0 {
+		return false
+	}
+
+	d1, d2 := tituloEleitorCheckDigits(digits)
+	return digits[10] == d1 && digits[11] == d2
+}
+
+// digits strips the punctuation of the TituloEleitor and makes sure all remaining bytes are digits.
+func (te TituloEleitor) digits() (out [12]byte, ok bool) {
+	switch len(te) {
+	case 12:
+		copy(out[:], te)
+	case 14:
+		if !isSpace(te[4]) || !isSpace(te[9]) {
+			return out, false
+		}
+
+		copy(out[0:4], te[0:4])
+		copy(out[4:8], te[5:9])
+		copy(out[8:12], te[10:14])
+	default:
+		return out, false
+	}
+
+	for _, d := range out {
+		if !isDigit(d) {
+			return out, false
+		}
+	}
+
+	return out, true
+}
+
+func tituloEleitorUF(digits [12]byte) address.UF {
+	code := int(digits[8]-'0')*10 + int(digits[9]-'0')
+	if code >= len(tituloEleitorUFs) {
+		return 0
+	}
+	return tituloEleitorUFs[code]
+}
+
+// tituloEleitorCheckDigits calculates both check digits of the TituloEleitor.
+//
+// When the rest of the division is 0, SP and MG use 1 as the check digit instead of 0.
+func tituloEleitorCheckDigits(digits [12]byte) (byte, byte) {
+	uf := tituloEleitorUF(digits)
+	spOrMG := uf == address.SP || uf == address.MG
+
+	var sum int
+	for i, d := range tituloEleitorFirstTable {
+		sum += d * int(digits[i]-'0')
+	}
+
+	d1 := tituloEleitorRestToDigit(sum%11, spOrMG)
+
+	sum = 7*int(digits[8]-'0') + 8*int(digits[9]-'0') + 9*int(d1)
+	d2 := tituloEleitorRestToDigit(sum%11, spOrMG)
+
+	return d1 + '0', d2 + '0'
+}
+
+func tituloEleitorRestToDigit(rest int, spOrMG bool) byte {
+	switch {
+	case rest == 10:
+		return 0
+	case rest == 0 && spOrMG:
+		return 1
+	default:
+		return byte(rest)
+	}
+}
+
+// UF returns the UF that issued the TituloEleitor.
+//
+// Voters abroad are represented by address.ZZ.
+// If the TituloEleitor is invalid, 0 is returned.
+func (te TituloEleitor) UF() address.UF {
+	if !te.IsValid() {
+		return 0
+	}
+
+	digits, _ := te.digits()
+	return tituloEleitorUF(digits)
+}
+
+// String returns the formatted TituloEleitor string as XXXX XXXX XXXX.
+func (te TituloEleitor) String() string {
+	if !te.IsValid() {
+		return ""
+	}
+
+	if len(te) == 14 {
+		return string(te)
+	}
+
+	out := make([]byte, 14)
+	out[4] = ' '
+	out[9] = ' '
+
+	copy(out[0:4], te[0:4])
+	copy(out[5:9], te[4:8])
+	copy(out[10:14], te[8:12])
+
+	return string(out)
+}
+
+// Value implements the driver.Valuer interface for TituloEleitor.
+func (te TituloEleitor) Value() (driver.Value, error) {
+	return te.String(), nil
+}
+
+// Scan implements the sql.Scanner interface for TituloEleitor.
+func (te *TituloEleitor) Scan(value any) error {
+	str, err := scanString("TituloEleitor", value)
+	if err != nil {
+		return err
+	}
+
+	_te, err := NewTituloEleitor(str)
+	if err != nil {
+		return fmt.Errorf("br: can not scan %q into TituloEleitor: %w", str, err)
+	}
+
+	*te = _te
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for TituloEleitor.
+func (te TituloEleitor) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + te.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for TituloEleitor.
+func (te *TituloEleitor) UnmarshalJSON(b []byte) error {
+	str, ok, err := unmarshalJSONString(b)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %s into TituloEleitor: %w", b, err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	_te, err := NewTituloEleitor(str)
+	if err != nil {
+		return fmt.Errorf("br: can not unmarshal %q into TituloEleitor: %w", str, err)
+	}
+
+	*te = _te
+	return nil
+}
@@ -0,0 +1,250 @@
+package br
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/phenpessoa/br/x/address"
+)
+
+var tituloEleitorSink TituloEleitor
+
+func BenchmarkGenerateTituloEleitor(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		tituloEleitorSink = GenerateTituloEleitor()
+	}
+}
+
+func TestGenerateTituloEleitor(t *testing.T) {
+	for range 1_000_000 {
+		if te := GenerateTituloEleitor(); !te.IsValid() {
+			t.Errorf("invalid TituloEleitor generated: %s", string(te))
+		}
+	}
+}
+
+func TestGenerateTituloEleitorForUF(t *testing.T) {
+	for _, uf := range []address.UF{address.SP, address.MG, address.RJ, address.TO, address.ZZ} {
+		for range 10_000 {
+			te, err := GenerateTituloEleitorForUF(uf)
+			if err != nil {
+				t.Fatalf("failed to generate TituloEleitor for %s: %v", uf, err)
+			}
+
+			if !te.IsValid() {
+				t.Errorf("invalid TituloEleitor generated: %s", string(te))
+			}
+
+			if te.UF() != uf {
+				t.Errorf("TituloEleitor generated for %s has uf %s", uf, te.UF())
+			}
+		}
+	}
+
+	if _, err := GenerateTituloEleitorForUF(0); err == nil {
+		t.Error("generated TituloEleitor for invalid uf")
+	}
+}
+
+func BenchmarkTituloEleitor_IsValid(b *testing.B) {
+	const te = TituloEleitor("102385010671")
+	if !te.IsValid() {
+		b.Error("invalid titulo eleitor on benchmark")
+		b.FailNow()
+	}
+	b.ReportAllocs()
+	for range b.N {
+		boolSink = te.IsValid()
+	}
+}
+
+func TestTituloEleitor_IsValid(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		te    TituloEleitor
+		valid bool
+	}{
+		{
+			name:  "raw TituloEleitor",
+			te:    TituloEleitor("102385010671"),
+			valid: true,
+		},
+		{
+			name:  "formatted TituloEleitor",
+			te:    TituloEleitor("1023 8501 0671"),
+			valid: true,
+		},
+		{
+			name:  "SP rest zero",
+			te:    TituloEleitor("000000000116"),
+			valid: true,
+		},
+		{
+			name:  "SP rest zero without special rule",
+			te:    TituloEleitor("000000000108"),
+			valid: false,
+		},
+		{
+			name:  "RJ rest zero",
+			te:    TituloEleitor("000000000302"),
+			valid: true,
+		},
+		{
+			name:  "abroad",
+			te:    TituloEleitor("435687092836"),
+			valid: true,
+		},
+		{
+			name:  "invalid first digit",
+			te:    TituloEleitor("102385010681"),
+			valid: false,
+		},
+		{
+			name:  "invalid second digit",
+			te:    TituloEleitor("102385010672"),
+			valid: false,
+		},
+		{
+			name:  "invalid uf code",
+			te:    TituloEleitor("102385012971"),
+			valid: false,
+		},
+		{
+			name:  "zero uf code",
+			te:    TituloEleitor("102385010071"),
+			valid: false,
+		},
+		{
+			name:  "invalid separators",
+			te:    TituloEleitor("1023.8501.0671"),
+			valid: false,
+		},
+		{
+			name:  "invalid characters",
+			te:    TituloEleitor("aaaaaaaaaaaa"),
+			valid: false,
+		},
+		{
+			name:  "empty",
+			te:    TituloEleitor(""),
+			valid: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.te.IsValid() != tc.valid {
+				t.Errorf(
+					"\ntitulo eleitor: %s\nshould be valid: %v\nis valid: %v",
+					tc.te, tc.valid, tc.te.IsValid(),
+				)
+			}
+		})
+	}
+}
+
+func TestTituloEleitor_UF(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		te   TituloEleitor
+		want address.UF
+	}{
+		{
+			name: "PR",
+			te:   TituloEleitor("102385010671"),
+			want: address.PR,
+		},
+		{
+			name: "SP",
+			te:   TituloEleitor("000000000116"),
+			want: address.SP,
+		},
+		{
+			name: "abroad",
+			te:   TituloEleitor("4356 8709 2836"),
+			want: address.ZZ,
+		},
+		{
+			name: "invalid",
+			te:   TituloEleitor("102385010672"),
+			want: 0,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.te.UF(); got != tc.want {
+				t.Errorf("\ntitulo eleitor: %s\nwant uf: %s\ngot uf: %s", tc.te, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestTituloEleitor_String(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		te   TituloEleitor
+		want string
+	}{
+		{
+			name: "raw TituloEleitor",
+			te:   TituloEleitor("102385010671"),
+			want: "1023 8501 0671",
+		},
+		{
+			name: "formatted TituloEleitor",
+			te:   TituloEleitor("1023 8501 0671"),
+			want: "1023 8501 0671",
+		},
+		{
+			name: "invalid",
+			te:   TituloEleitor("102385010672"),
+			want: "",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.te.String() != tc.want {
+				t.Errorf(
+					"\ntitulo eleitor: %s\nshould be formatted like: %s\nis formatted like: %s",
+					tc.te, tc.want, tc.te.String(),
+				)
+			}
+		})
+	}
+}
+
+func TestTituloEleitor_JSON(t *testing.T) {
+	data, err := json.Marshal(TituloEleitor("102385010671"))
+	if err != nil {
+		t.Fatalf("failed to marshal titulo eleitor: %v", err)
+	}
+
+	if want := `"1023 8501 0671"`; string(data) != want {
+		t.Errorf("\nwant: %s\ngot: %s", want, data)
+	}
+
+	var te TituloEleitor
+	if err := json.Unmarshal(data, &te); err != nil {
+		t.Fatalf("failed to unmarshal titulo eleitor: %v", err)
+	}
+
+	if te != TituloEleitor("1023 8501 0671") {
+		t.Errorf("unmarshaled wrong titulo eleitor: %s", te)
+	}
+
+	if err := json.Unmarshal([]byte(`"102385010672"`), &te); err == nil {
+		t.Error("invalid titulo eleitor unmarshaled without error")
+	}
+}
+
+func TestTituloEleitor_Scan(t *testing.T) {
+	var te TituloEleitor
+	if err := te.Scan([]byte("102385010671")); err != nil {
+		t.Fatalf("failed to scan titulo eleitor: %v", err)
+	}
+
+	if te != TituloEleitor("102385010671") {
+		t.Errorf("scanned wrong titulo eleitor: %s", te)
+	}
+
+	if err := te.Scan("102385010672"); err == nil {
+		t.Error("invalid titulo eleitor scanned without error")
+	}
+}
@@ -1,6 +1,8 @@
 package br
 
 import (
+	"encoding/json"
+	"fmt"
 	"math/bits"
 	"math/rand/v2"
 )
@@ -28,6 +30,44 @@ func asciiLowerToUpper(b byte) byte {
 	return b
 }
 
+// scanString converts a value received by a sql.Scanner into a string.
+//
+// name is the name of the type being scanned and is only used to build the error message.
+func scanString(name string, value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		return "", fmt.Errorf("br: unknown type passed to %s Scan: %T", name, value)
+	}
+}
+
+// unmarshalJSONString decodes a JSON string.
+//
+// ok is false when b is the JSON null literal, in which case the caller should leave its value untouched.
+func unmarshalJSONString(b []byte) (s string, ok bool, err error) {
+	if string(b) == "null" {
+		return "", false, nil
+	}
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return "", false, err
+	}
+
+	return s, true, nil
+}
+
+// digitsToInt parses a slice of ASCII digits. It does not validate them.
+func digitsToInt(d []byte) int {
+	var n int
+	for _, c := range d {
+		n = n*10 + int(c-'0')
+	}
+	return n
+}
+
 var pcg = rand.NewPCG(rand.Uint64(), rand.Uint64())
 
 func randomZeroOr1() byte {
@@ -118,3 +158,17 @@ func randomAlphaNumericalUpper() byte {
 
 	return alphaNumericals[int(hi)]
 }
+
+func randomUint64n(n uint64) uint64 {
+	// This code here is taken from the stdlib.
+	// You can check it at the math/rand/v2 package under func '(r *Rand) uint64n(n uint64) uint64'.
+	hi, lo := bits.Mul64(pcg.Uint64(), n)
+	if lo < n {
+		thresh := -n % n
+		for lo < thresh {
+			hi, lo = bits.Mul64(pcg.Uint64(), n)
+		}
+	}
+
+	return hi
+}
@@ -31,6 +31,45 @@ var (
 // UF stands for Unidade Federativa and represents a Brazilian state.
 type UF uint8
 
+// The Brazilian states, identified by their IBGE codes.
+const (
+	RO UF = 11
+	AC UF = 12
+	AM UF = 13
+	RR UF = 14
+	PA UF = 15
+	AP UF = 16
+	TO UF = 17
+	MA UF = 21
+	PI UF = 22
+	CE UF = 23
+	RN UF = 24
+	PB UF = 25
+	PE UF = 26
+	AL UF = 27
+	SE UF = 28
+	BA UF = 29
+	MG UF = 31
+	ES UF = 32
+	RJ UF = 33
+	SP UF = 35
+	PR UF = 41
+	SC UF = 42
+	RS UF = 43
+	MS UF = 50
+	MT UF = 51
+	GO UF = 52
+	DF UF = 53
+
+	// ZZ is not a state. It is used by some documents, such as the Título de Eleitor,
+	// to represent residents abroad.
+	//
+	// ZZ is not accepted by NewUF, NewUFFromStr or Address.Deserialize, so an Address never holds
+	// it. The types that represent a party abroad handle it explicitly. UnmarshalJSON and Scan
+	// accept it, so that a UF encoded by MarshalJSON or Value can be decoded back.
+	ZZ UF = 99
+)
+
 // NewUF creates a UF instance from a given state code.
 func NewUF(codigo int) (UF, error) {
 	if codigo < 11 || codigo > 53 {
@@ -168,6 +207,8 @@ func (uf UF) String() string {
 		return "GO"
 	case 53:
 		return "DF"
+	case 99:
+		return "ZZ"
 	default:
 		return ""
 	}
@@ -230,6 +271,8 @@ func (uf UF) UnidadeFederativa() string {
 		return "Goiás"
 	case 53:
 		return "Distrito Federal"
+	case 99:
+		return "Exterior"
 	default:
 		return ""
 	}
@@ -245,6 +288,11 @@ func (uf *UF) UnmarshalJSON(b []byte) error {
 	str := unsafex.String(b)
 	if strings.Contains(str, `"`) {
 		str = strings.ReplaceAll(str, `"`, "")
+		if strings.EqualFold(str, "ZZ") {
+			*uf = ZZ
+			return nil
+		}
+
 		_uf, err := NewUFFromStr(str)
 		if err != nil {
 			return fmt.Errorf("can not unmarshal %s into uf: %w", str, err)
@@ -258,6 +306,11 @@ func (uf *UF) UnmarshalJSON(b []byte) error {
 		return fmt.Errorf("can not unmarshal %s into uf: %w", str, ErrInvalidUF)
 	}
 
+	if parsedCode == int64(ZZ) {
+		*uf = ZZ
+		return nil
+	}
+
 	_uf, err := NewUF(int(parsedCode))
 	if err != nil {
 		return fmt.Errorf("can not unmarshal %d into uf: %w", parsedCode, err)
@@ -279,6 +332,11 @@ func (uf *UF) Scan(value any) error {
 		return fmt.Errorf("br: unknown type passed to UF Scan: %T", value)
 	}
 
+	if i64 == int64(ZZ) {
+		*uf = ZZ
+		return nil
+	}
+
 	_uf, err := NewUF(int(i64))
 	if err != nil {
 		return fmt.Errorf("br: can not convert %d to UF", i64)
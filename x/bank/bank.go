@@ -0,0 +1,422 @@
+// Package bank provides functions for validating and formatting the agência and the conta of
+// Brazilian bank accounts, using the check digit algorithm of each bank.
+package bank
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/phenpessoa/br"
+)
+
+// ErrInvalidBankAccount is matched by every error returned when validating a BankAccount.
+var ErrInvalidBankAccount = errors.New("br: invalid bank account")
+
+// The COMPE codes of the banks whose check digits are validated.
+const (
+	BancoDoBrasil = "001"
+	Santander     = "033"
+	Banrisul      = "041"
+	Caixa         = "104"
+	Bradesco      = "237"
+	Itau          = "341"
+)
+
+// rules describes the agência and the conta of a bank.
+type rules struct {
+	// agenciaDV calculates the check digits of the agência. It is nil when the agência has no check digit.
+	agenciaDV func(agencia string) string
+
+	// contaLen is the amount of digits of the conta, without its check digit.
+	contaLen int
+
+	// contaDV calculates the check digit of the conta.
+	contaDV func(agencia, conta string) string
+}
+
+var banks = map[string]rules{
+	BancoDoBrasil: {
+		agenciaDV: func(agencia string) string { return mod11(agencia, 'X') },
+		contaLen:  8,
+		contaDV:   func(_, conta string) string { return mod11(conta, 'X') },
+	},
+	Santander: {
+		contaLen: 8,
+		contaDV:  santanderDV,
+	},
+	Banrisul: {
+		agenciaDV: banrisulAgenciaDV,
+		contaLen:  9,
+		contaDV:   banrisulContaDV,
+	},
+	Caixa: {
+		contaLen: 11,
+		contaDV:  caixaDV,
+	},
+	Bradesco: {
+		agenciaDV: func(agencia string) string { return mod11(agencia, 'P') },
+		contaLen:  7,
+		contaDV:   bradescoContaDV,
+	},
+	Itau: {
+		contaLen: 5,
+		contaDV:  func(agencia, conta string) string { return mod10(agencia + conta) },
+	},
+}
+
+const (
+	agenciaLen    = 4
+	maxContaLen   = 20
+	maxContaDVLen = 2
+)
+
+// BankAccount is an account of a Brazilian bank.
+//
+// The check digits are validated for the banks with the constants of this package. For the other
+// banks, only the characters and the lengths are validated.
+type BankAccount struct {
+	// Banco is the 3-digit COMPE code of the bank.
+	Banco string
+
+	// Agencia is the 4-digit agência, without its check digit.
+	Agencia string
+
+	// AgenciaDV is the check digit of the agência, empty for the banks whose agência has none.
+	AgenciaDV string
+
+	// Conta is the number of the conta, without its check digit. For the Caixa, it starts with the
+	// 3-digit operação, such as 001 for checking accounts and 013 for savings.
+	Conta string
+
+	// ContaDV is the check digit of the conta.
+	ContaDV string
+}
+
+// NewBankAccount creates a BankAccount from the COMPE code of the bank and the agência and the conta
+// with their check digits, such as NewBankAccount("001", "1584-9", "210169-6").
+//
+// The check digits can be separated by a dash or be the last character. Dots and spaces are ignored,
+// and the agência and the conta are padded with zeros to the lengths used by the bank.
+//
+// The returned error is always a *br.DocumentoError, matching ErrInvalidBankAccount.
+func NewBankAccount(banco, agencia, conta string) (BankAccount, error) {
+	a := BankAccount{Banco: banco}
+	r, known := banks[banco]
+
+	a.Agencia, a.AgenciaDV = splitDV(agencia, r.agenciaDV != nil)
+	a.Conta, a.ContaDV = splitDV(conta, true)
+
+	a.Agencia = padDigits(a.Agencia, agenciaLen)
+	if known {
+		a.Conta = padDigits(a.Conta, r.contaLen)
+	}
+
+	if err := a.Validate(); err != nil {
+		return BankAccount{}, err
+	}
+
+	return a, nil
+}
+
+// splitDV removes dots and spaces from s, splitting its check digits after the dash or,
+// if there is no dash, its last character.
+func splitDV(s string, hasDV bool) (string, string) {
+	s = strings.NewReplacer(".", "", " ", "").Replace(strings.ToUpper(s))
+	if i := strings.LastIndexByte(s, '-'); i >= 0 {
+		return s[:i], s[i+1:]
+	}
+	if !hasDV || len(s) < 2 {
+		return s, ""
+	}
+	return s[:len(s)-1], s[len(s)-1:]
+}
+
+// padDigits pads s with zeros to n digits, or removes the zeros to the left beyond n digits.
+func padDigits(s string, n int) string {
+	if len(s) < n {
+		return strings.Repeat("0", n-len(s)) + s
+	}
+	for len(s) > n && s[0] == '0' {
+		s = s[1:]
+	}
+	return s
+}
+
+// Validate checks the lengths, the characters and the check digits of the BankAccount.
+//
+// The returned error is always a *br.DocumentoError, matching ErrInvalidBankAccount.
+func (a BankAccount) Validate() error {
+	if len(a.Banco) != 3 {
+		return newError("banco", br.ErrDocumentoLength)
+	}
+	if !isNumeric(a.Banco) {
+		return newError("banco", br.ErrDocumentoCharacter)
+	}
+
+	if len(a.Agencia) != agenciaLen {
+		return newError("agencia", br.ErrDocumentoLength)
+	}
+	if !isNumeric(a.Agencia) {
+		return newError("agencia", br.ErrDocumentoCharacter)
+	}
+
+	r, known := banks[a.Banco]
+	if !known {
+		return a.validateUnknown()
+	}
+
+	if r.agenciaDV == nil {
+		if a.AgenciaDV != "" {
+			return newError("agencia dv", br.ErrDocumentoLength)
+		}
+	} else {
+		dv := r.agenciaDV(a.Agencia)
+		if len(a.AgenciaDV) != len(dv) {
+			return newError("agencia dv", br.ErrDocumentoLength)
+		}
+		if a.AgenciaDV != dv {
+			return newError("agencia dv", br.ErrDocumentoCheckDigit)
+		}
+	}
+
+	if len(a.Conta) != r.contaLen {
+		return newError("conta", br.ErrDocumentoLength)
+	}
+	if !isNumeric(a.Conta) {
+		return newError("conta", br.ErrDocumentoCharacter)
+	}
+
+	dv := r.contaDV(a.Agencia, a.Conta)
+	if len(a.ContaDV) != len(dv) {
+		return newError("conta dv", br.ErrDocumentoLength)
+	}
+	if a.ContaDV != dv {
+		return newError("conta dv", br.ErrDocumentoCheckDigit)
+	}
+
+	return nil
+}
+
+// validateUnknown checks the conta and the check digits of the banks without a known algorithm.
+func (a BankAccount) validateUnknown() error {
+	if len(a.AgenciaDV) > 1 {
+		return newError("agencia dv", br.ErrDocumentoLength)
+	}
+	if !isAlphaNumeric(a.AgenciaDV) {
+		return newError("agencia dv", br.ErrDocumentoCharacter)
+	}
+
+	if a.Conta == "" || len(a.Conta) > maxContaLen {
+		return newError("conta", br.ErrDocumentoLength)
+	}
+	if !isNumeric(a.Conta) {
+		return newError("conta", br.ErrDocumentoCharacter)
+	}
+
+	if len(a.ContaDV) > maxContaDVLen {
+		return newError("conta dv", br.ErrDocumentoLength)
+	}
+	if !isAlphaNumeric(a.ContaDV) {
+		return newError("conta dv", br.ErrDocumentoCharacter)
+	}
+
+	return nil
+}
+
+func newError(field string, err error) error {
+	return &br.DocumentoError{Doc: ErrInvalidBankAccount, Field: field, Err: err}
+}
+
+// IsValid checks whether the BankAccount is valid.
+func (a BankAccount) IsValid() bool {
+	return a.Validate() == nil
+}
+
+// FormatAgencia returns the agência with its check digit, such as 1584-9, or only the agência for
+// the banks whose agência has no check digit.
+//
+// If the BankAccount is invalid, an empty string is returned.
+func (a BankAccount) FormatAgencia() string {
+	if !a.IsValid() {
+		return ""
+	}
+	return join(a.Agencia, a.AgenciaDV)
+}
+
+// FormatConta returns the conta with its check digit, such as 00210169-6. For the Caixa, the
+// operação is separated by a dot, such as 001.00000448-6.
+//
+// If the BankAccount is invalid, an empty string is returned.
+func (a BankAccount) FormatConta() string {
+	if !a.IsValid() {
+		return ""
+	}
+	if a.Banco == Caixa {
+		return a.Conta[:3] + "." + join(a.Conta[3:], a.ContaDV)
+	}
+	return join(a.Conta, a.ContaDV)
+}
+
+// String returns the bank, the agência and the conta separated by spaces, such as 001 1584-9 00210169-6.
+//
+// If the BankAccount is invalid, an empty string is returned.
+func (a BankAccount) String() string {
+	if !a.IsValid() {
+		return ""
+	}
+	return a.Banco + " " + a.FormatAgencia() + " " + a.FormatConta()
+}
+
+func join(s, dv string) string {
+	if dv == "" {
+		return s
+	}
+	return s + "-" + dv
+}
+
+// mod11 calculates a check digit with the weights 2 to 9, from right to left. The result 10 becomes
+// the given character and 11 becomes 0.
+func mod11(s string, ten byte) string {
+	var sum int
+	weight := 2
+	for i := len(s) - 1; i >= 0; i-- {
+		sum += int(s[i]-'0') * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+
+	switch dv := 11 - sum%11; dv {
+	case 10:
+		return string(ten)
+	case 11:
+		return "0"
+	default:
+		return string(byte(dv) + '0')
+	}
+}
+
+// mod10 calculates a check digit with the weights 2 and 1, from right to left, summing the digits of each product.
+func mod10(s string) string {
+	var sum int
+	weight := 2
+	for i := len(s) - 1; i >= 0; i-- {
+		p := int(s[i]-'0') * weight
+		sum += p/10 + p%10
+		weight = 3 - weight
+	}
+	return string(byte((10-sum%10)%10) + '0')
+}
+
+// caixaDV calculates the check digit of a conta of the Caixa over the agência, the operação and the conta.
+func caixaDV(agencia, conta string) string {
+	s := agencia + conta
+	var sum int
+	weight := 2
+	for i := len(s) - 1; i >= 0; i-- {
+		sum += int(s[i]-'0') * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+	return string(byte(sum*10%11%10) + '0')
+}
+
+// bradescoContaDV calculates the check digit of a conta of the Bradesco with the weights 2, 7, 6, 5, 4, 3
+// and 2, from left to right. Unlike mod11, the weights do not cycle, so the first digit has the weight 2.
+// The result 10 becomes P and 11 becomes 0.
+func bradescoContaDV(_, conta string) string {
+	weights := [...]int{2, 7, 6, 5, 4, 3, 2}
+
+	var sum int
+	for i := range len(conta) {
+		sum += int(conta[i]-'0') * weights[i]
+	}
+
+	switch dv := 11 - sum%11; dv {
+	case 10:
+		return "P"
+	case 11:
+		return "0"
+	default:
+		return string(byte(dv) + '0')
+	}
+}
+
+// santanderDV calculates the check digit of a conta of the Santander over the agência, 00 and the conta,
+// summing the last digit of each product.
+func santanderDV(agencia, conta string) string {
+	weights := [...]int{9, 7, 3, 1, 0, 0, 9, 7, 1, 3, 1, 9, 7, 3}
+	s := agencia + "00" + conta
+
+	var sum int
+	for i := range len(s) {
+		sum += int(s[i]-'0') * weights[i] % 10
+	}
+	return string(byte((10-sum%10)%10) + '0')
+}
+
+// banrisulAgenciaDV calculates the 2 check digits of an agência of the Banrisul: the first with
+// modulo 10 and the second with modulo 11 over the agência and the first check digit.
+func banrisulAgenciaDV(agencia string) string {
+	dv1 := mod10(agencia)[0] - '0'
+
+	for {
+		weights := [...]int{6, 5, 4, 3, 2}
+		s := agencia + string(dv1+'0')
+
+		var sum int
+		for i := range len(s) {
+			sum += int(s[i]-'0') * weights[i]
+		}
+
+		switch rest := sum % 11; rest {
+		case 0:
+			return string(dv1+'0') + "0"
+		case 1:
+			// The first check digit is incremented until the second one is valid.
+			dv1 = (dv1 + 1) % 10
+		default:
+			return string(dv1+'0') + string(byte(11-rest)+'0')
+		}
+	}
+}
+
+// banrisulContaDV calculates the check digit of a conta of the Banrisul.
+func banrisulContaDV(_, conta string) string {
+	weights := [...]int{3, 2, 4, 7, 6, 5, 4, 3, 2}
+
+	var sum int
+	for i := range len(conta) {
+		sum += int(conta[i]-'0') * weights[i]
+	}
+
+	switch rest := sum % 11; rest {
+	case 0:
+		return "0"
+	case 1:
+		return "6"
+	default:
+		return string(byte(11-rest) + '0')
+	}
+}
+
+func isNumeric(s string) bool {
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphaNumeric(s string) bool {
+	for i := range len(s) {
+		if (s[i] < '0' || s[i] > '9') && (s[i] < 'A' || s[i] > 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,121 @@
+package bank
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/phenpessoa/br"
+)
+
+func BenchmarkNewBankAccount(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		_, _ = NewBankAccount(BancoDoBrasil, "1584-9", "00210169-6")
+	}
+}
+
+func TestNewBankAccount(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		banco   string
+		agencia string
+		conta   string
+		want    string
+	}{
+		{"banco do brasil", BancoDoBrasil, "1584-9", "00210169-6", "001 1584-9 00210169-6"},
+		{"banco do brasil short conta", BancoDoBrasil, "1584-9", "210169-6", "001 1584-9 00210169-6"},
+		{"banco do brasil without dash", BancoDoBrasil, "15849", "002101696", "001 1584-9 00210169-6"},
+		{"banco do brasil dv x", BancoDoBrasil, "0001-9", "00000006-X", "001 0001-9 00000006-X"},
+		{"banco do brasil lower x", BancoDoBrasil, "0001-9", "6-x", "001 0001-9 00000006-X"},
+		{"itau", Itau, "2545", "02366-1", "341 2545 02366-1"},
+		{"bradesco", Bradesco, "1234-3", "0238069-2", "237 1234-3 0238069-2"},
+		{"bradesco dv p", Bradesco, "0006-p", "0238069-2", "237 0006-P 0238069-2"},
+		{"bradesco conta without leading zero", Bradesco, "1234-3", "1234567-4", "237 1234-3 1234567-4"},
+		{"bradesco conta dv p", Bradesco, "1234-3", "1000005-P", "237 1234-3 1000005-P"},
+		{"bradesco conta cycling weights", Bradesco, "1234-3", "1234567-9", ""},
+		{"caixa", Caixa, "2004", "001.00000448-6", "104 2004 001.00000448-6"},
+		{"santander", Santander, "2006", "13000123-4", "033 2006 13000123-4"},
+		{"banrisul", Banrisul, "0155-20", "358507671-8", "041 0155-20 358507671-8"},
+		{"unknown bank", "260", "0001", "1234567-8", "260 0001 1234567-8"},
+		{"spaces and dots", BancoDoBrasil, " 1.584-9 ", "21.016-9-6", ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := NewBankAccount(tc.banco, tc.agencia, tc.conta)
+			if tc.want == "" {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to create bank account: %v", err)
+			}
+
+			if got := a.String(); got != tc.want {
+				t.Errorf("\nwant: %s\ngot: %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestBankAccount_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		a     BankAccount
+		field string
+		err   error
+	}{
+		{"banco length", BankAccount{Banco: "1", Agencia: "1584", AgenciaDV: "9", Conta: "00210169", ContaDV: "6"}, "banco", br.ErrDocumentoLength},
+		{"banco character", BankAccount{Banco: "00A", Agencia: "1584", AgenciaDV: "9", Conta: "00210169", ContaDV: "6"}, "banco", br.ErrDocumentoCharacter},
+		{"agencia length", BankAccount{Banco: BancoDoBrasil, Agencia: "158", AgenciaDV: "9", Conta: "00210169", ContaDV: "6"}, "agencia", br.ErrDocumentoLength},
+		{"agencia character", BankAccount{Banco: BancoDoBrasil, Agencia: "15A4", AgenciaDV: "9", Conta: "00210169", ContaDV: "6"}, "agencia", br.ErrDocumentoCharacter},
+		{"agencia dv", BankAccount{Banco: BancoDoBrasil, Agencia: "1584", AgenciaDV: "8", Conta: "00210169", ContaDV: "6"}, "agencia dv", br.ErrDocumentoCheckDigit},
+		{"missing agencia dv", BankAccount{Banco: Bradesco, Agencia: "1234", Conta: "0238069", ContaDV: "2"}, "agencia dv", br.ErrDocumentoLength},
+		{"unexpected agencia dv", BankAccount{Banco: Itau, Agencia: "2545", AgenciaDV: "1", Conta: "02366", ContaDV: "1"}, "agencia dv", br.ErrDocumentoLength},
+		{"conta length", BankAccount{Banco: Itau, Agencia: "2545", Conta: "2366", ContaDV: "1"}, "conta", br.ErrDocumentoLength},
+		{"conta character", BankAccount{Banco: Itau, Agencia: "2545", Conta: "0236A", ContaDV: "1"}, "conta", br.ErrDocumentoCharacter},
+		{"conta dv", BankAccount{Banco: Itau, Agencia: "2545", Conta: "02366", ContaDV: "2"}, "conta dv", br.ErrDocumentoCheckDigit},
+		{"caixa without operacao", BankAccount{Banco: Caixa, Agencia: "2004", Conta: "00000448", ContaDV: "6"}, "conta", br.ErrDocumentoLength},
+		{"banrisul agencia dv", BankAccount{Banco: Banrisul, Agencia: "0155", AgenciaDV: "21", Conta: "358507671", ContaDV: "8"}, "agencia dv", br.ErrDocumentoCheckDigit},
+		{"santander conta dv", BankAccount{Banco: Santander, Agencia: "2006", Conta: "13000123", ContaDV: "5"}, "conta dv", br.ErrDocumentoCheckDigit},
+		{"unknown conta", BankAccount{Banco: "260", Agencia: "0001", Conta: ""}, "conta", br.ErrDocumentoLength},
+		{"unknown conta dv", BankAccount{Banco: "260", Agencia: "0001", Conta: "1234", ContaDV: "-"}, "conta dv", br.ErrDocumentoCharacter},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.a.Validate()
+			if !errors.Is(err, ErrInvalidBankAccount) {
+				t.Fatalf("expected ErrInvalidBankAccount, got %v", err)
+			}
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected %v, got %v", tc.err, err)
+			}
+
+			var de *br.DocumentoError
+			if !errors.As(err, &de) {
+				t.Fatalf("expected a *br.DocumentoError, got %T", err)
+			}
+			if de.Field != tc.field {
+				t.Errorf("expected field %q, got %q", tc.field, de.Field)
+			}
+
+			if tc.a.IsValid() || tc.a.String() != "" || tc.a.FormatAgencia() != "" || tc.a.FormatConta() != "" {
+				t.Errorf("expected an invalid bank account")
+			}
+		})
+	}
+}
+
+func TestBankAccount_Format(t *testing.T) {
+	a, err := NewBankAccount(Caixa, "2004", "00100000448-6")
+	if err != nil {
+		t.Fatalf("failed to create bank account: %v", err)
+	}
+
+	if got := a.FormatAgencia(); got != "2004" {
+		t.Errorf("\nwant: 2004\ngot: %s", got)
+	}
+
+	if got := a.FormatConta(); got != "001.00000448-6" {
+		t.Errorf("\nwant: 001.00000448-6\ngot: %s", got)
+	}
+}
@@ -0,0 +1,318 @@
+// Command gen generates the embedded dataset of institutions of the bank package from the files
+// published by the Banco Central.
+//
+// Usage:
+//
+//	go run ./x/bank/internal/gen -str ParticipantesSTRport.csv -pix participantes-pix.csv -o x/bank/institutions.csv
+//
+// The -str file is the list of participants of the STR, with the COMPE codes. The optional -pix file
+// is the list of participants of Pix. Both are read from local files, in UTF-8 or ISO-8859-1, separated
+// by commas or semicolons.
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+func main() {
+	var (
+		strPath = flag.String("str", "", "path of the CSV of the participants of the STR")
+		pixPath = flag.String("pix", "", "path of the CSV of the participants of Pix")
+		outPath = flag.String("o", "institutions.csv", "path of the generated dataset")
+		version = flag.String("version", time.Now().Format(time.DateOnly), "version of the generated dataset")
+	)
+	flag.Parse()
+
+	if *strPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	str, err := os.ReadFile(*strPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var pix []byte
+	if *pixPath != "" {
+		if pix, err = os.ReadFile(*pixPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	institutions, err := merge(str, pix)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := write(&buf, *version, institutions); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*outPath, buf.Bytes(), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type institution struct {
+	ispb      string
+	compe     string
+	cnpj      string
+	shortName string
+	name      string
+	pix       string
+}
+
+// merge joins the participants of the STR and of Pix by their ISPB.
+func merge(str, pix []byte) ([]institution, error) {
+	byISPB := make(map[string]*institution)
+
+	rows, err := readCSV(str)
+	if err != nil {
+		return nil, fmt.Errorf("str: %w", err)
+	}
+
+	cols, err := columns(rows, "ispb", "nome reduzido", "numero codigo", "nome extenso")
+	if err != nil {
+		return nil, fmt.Errorf("str: %w", err)
+	}
+
+	for _, row := range rows[1:] {
+		ispb, ok := normalizeISPB(row[cols[0]])
+		if !ok {
+			continue
+		}
+
+		compe := strings.TrimSpace(row[cols[2]])
+		if compe == "" || len(compe) > 3 || !isNumeric(compe) {
+			compe = ""
+		} else {
+			compe = strings.Repeat("0", 3-len(compe)) + compe
+		}
+
+		byISPB[ispb] = &institution{
+			ispb:      ispb,
+			compe:     compe,
+			cnpj:      cnpjMatriz(ispb),
+			shortName: strings.TrimSpace(row[cols[1]]),
+			name:      strings.TrimSpace(row[cols[3]]),
+		}
+	}
+
+	if pix != nil {
+		if err := mergePix(byISPB, pix); err != nil {
+			return nil, fmt.Errorf("pix: %w", err)
+		}
+	}
+
+	out := make([]institution, 0, len(byISPB))
+	for _, inst := range byISPB {
+		out = append(out, *inst)
+	}
+
+	slices.SortFunc(out, func(a, b institution) int {
+		return strings.Compare(a.ispb, b.ispb)
+	})
+
+	return out, nil
+}
+
+// mergePix sets the Pix flags of the participants of Pix, adding the ones that are not in the STR.
+func mergePix(byISPB map[string]*institution, data []byte) error {
+	rows, err := readCSV(data)
+	if err != nil {
+		return err
+	}
+
+	cols, err := columns(rows, "ispb", "nome reduzido", "nome", "participacao no spi", "participacao no pix")
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows[1:] {
+		ispb, ok := normalizeISPB(row[cols[0]])
+		if !ok {
+			continue
+		}
+
+		inst, ok := byISPB[ispb]
+		if !ok {
+			inst = &institution{
+				ispb:      ispb,
+				cnpj:      cnpjMatriz(ispb),
+				shortName: strings.TrimSpace(row[cols[1]]),
+				name:      strings.TrimSpace(row[cols[2]]),
+			}
+			byISPB[ispb] = inst
+		}
+
+		inst.pix = "P"
+		if strings.HasPrefix(fold(row[cols[3]]), "direta") {
+			inst.pix += "D"
+		}
+		if strings.HasPrefix(fold(row[cols[4]]), "obrigatoria") {
+			inst.pix += "M"
+		}
+	}
+
+	return nil
+}
+
+// write writes the dataset read by the bank package.
+func write(w io.Writer, version string, institutions []institution) error {
+	if _, err := fmt.Fprintf(w, "# version: %s\n", version); err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"ispb", "compe", "cnpj", "short_name", "name", "pix"}); err != nil {
+		return err
+	}
+
+	for _, inst := range institutions {
+		if err := cw.Write([]string{inst.ispb, inst.compe, inst.cnpj, inst.shortName, inst.name, inst.pix}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// readCSV reads the rows of a CSV in UTF-8 or ISO-8859-1, separated by commas or semicolons.
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	if !utf8.Valid(data) {
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		data = []byte(string(runes))
+	}
+
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+
+	r := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		r.Comma = ';'
+	}
+	r.FieldsPerRecord = -1
+
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("empty file")
+	}
+
+	width := len(rows[0])
+	for i, row := range rows {
+		for len(row) < width {
+			row = append(row, "")
+		}
+		rows[i] = row
+	}
+
+	return rows, nil
+}
+
+// columns returns the index of the columns of the header whose names contain each of the given names,
+// ignoring case, accents and underscores. Each name matches the first column not matched before.
+func columns(rows [][]string, names ...string) ([]int, error) {
+	header := make([]string, len(rows[0]))
+	for i, h := range rows[0] {
+		header[i] = fold(h)
+	}
+
+	used := make(map[int]bool)
+	out := make([]int, len(names))
+	for i, name := range names {
+		out[i] = -1
+		for j, h := range header {
+			if !used[j] && strings.Contains(h, name) {
+				out[i] = j
+				used[j] = true
+				break
+			}
+		}
+		if out[i] < 0 {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	return out, nil
+}
+
+var accents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a",
+	"é", "e", "ê", "e",
+	"í", "i",
+	"ó", "o", "ô", "o", "õ", "o",
+	"ú", "u", "ü", "u",
+	"ç", "c",
+	"_", " ",
+)
+
+// fold lowercases s, removing its accents and replacing underscores with spaces.
+func fold(s string) string {
+	return strings.Join(strings.Fields(accents.Replace(strings.ToLower(s))), " ")
+}
+
+// normalizeISPB pads the ISPB with zeros, as spreadsheets often remove them.
+func normalizeISPB(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" || len(s) > 8 || !isNumeric(s) {
+		return "", false
+	}
+	return strings.Repeat("0", 8-len(s)) + s, true
+}
+
+// cnpjMatriz returns the CNPJ of the headquarters of the institution, whose base is the ISPB.
+func cnpjMatriz(ispb string) string {
+	d := ispb + "0001"
+	d += mod11(d)
+	d += mod11(d)
+	return d
+}
+
+func mod11(s string) string {
+	var sum int
+	weight := 2
+	for i := len(s) - 1; i >= 0; i-- {
+		sum += int(s[i]-'0') * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+
+	dv := 11 - sum%11
+	if dv >= 10 {
+		dv = 0
+	}
+	return string(byte(dv) + '0')
+}
+
+func isNumeric(s string) bool {
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	str := []byte("\xef\xbb\xbfISPB,Nome_Reduzido,Número_Código,Participa_da_Compe,Acesso_Principal,Nome_Extenso\n" +
+		"0,BCO DO BRASIL S.A.,1,Sim,RSFN,Banco do Brasil S.A.\n" +
+		"60701190,ITAÚ UNIBANCO S.A.,341,Sim,RSFN,ITAÚ UNIBANCO S.A.\n" +
+		"30306294,BCO BTG PACTUAL S.A.,208,Sim,RSFN,Banco BTG Pactual S.A.\n" +
+		"12345678,SEM COMPE,n/a,Não,RSFN,Sem Compe S.A.\n" +
+		",,,,,\n")
+
+	// ISO-8859-1, separated by semicolons.
+	pix := []byte("ISPB;Nome;Nome Reduzido;Modalidade de Participa\xe7\xe3o;Tipo de Participa\xe7\xe3o no SPI;Tipo de Participa\xe7\xe3o no Pix\r\n" +
+		"00000000;Banco do Brasil S.A.;BCO DO BRASIL S.A.;Provedor de Conta Transacional;Direta;Obrigat\xf3ria\r\n" +
+		"30306294;Banco BTG Pactual S.A.;BCO BTG PACTUAL S.A.;Provedor de Conta Transacional;Direta;Volunt\xe1ria\r\n" +
+		"99999999;Pix Indireto IP S.A.;PIX INDIRETO IP;Provedor de Conta Transacional;Indireta;Volunt\xe1ria\r\n")
+
+	got, err := merge(str, pix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := write(&buf, "2026-01-02", got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "# version: 2026-01-02\n" +
+		"ispb,compe,cnpj,short_name,name,pix\n" +
+		"00000000,001,00000000000191,BCO DO BRASIL S.A.,Banco do Brasil S.A.,PDM\n" +
+		"12345678,,12345678000195,SEM COMPE,Sem Compe S.A.,\n" +
+		"30306294,208,30306294000145,BCO BTG PACTUAL S.A.,Banco BTG Pactual S.A.,PD\n" +
+		"60701190,341,60701190000104,ITAÚ UNIBANCO S.A.,ITAÚ UNIBANCO S.A.,\n" +
+		"99999999,,99999999000191,PIX INDIRETO IP,Pix Indireto IP S.A.,P\n"
+
+	if buf.String() != want {
+		t.Errorf("\nwant:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
+
+func TestMerge_MissingColumn(t *testing.T) {
+	if _, err := merge([]byte("ISPB,Nome_Reduzido\n00000000,BCO DO BRASIL S.A.\n"), nil); err == nil {
+		t.Error("expected error")
+	}
+}
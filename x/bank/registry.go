@@ -0,0 +1,202 @@
+package bank
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"strings"
+	"sync"
+
+	"github.com/phenpessoa/br"
+)
+
+// institutionsCSV is generated by internal/gen from the files published by the Banco Central.
+//
+// Until it is generated, it is a hand-written sample with some of the largest institutions, with the
+// version "sample". Run internal/gen with the current files of the Banco Central to replace it.
+//
+//go:embed institutions.csv
+var institutionsCSV string
+
+// PixFlags tells how an Institution participates in Pix.
+type PixFlags uint8
+
+const (
+	// PixParticipant is set for the institutions that participate in Pix.
+	PixParticipant PixFlags = 1 << iota
+
+	// PixDirect is set for the direct participants of the SPI, which settle their own transactions.
+	PixDirect
+
+	// PixMandatory is set for the institutions required to participate in Pix.
+	PixMandatory
+)
+
+// Has reports whether all the given flags are set.
+func (f PixFlags) Has(flags PixFlags) bool {
+	return f&flags == flags
+}
+
+// Institution is a financial institution authorized by the Banco Central.
+type Institution struct {
+	// ISPB is the 8-digit identifier of the institution in the payment system,
+	// the base of its CNPJ.
+	ISPB string
+
+	// COMPE is the 3-digit code of the institution, empty if it has none.
+	COMPE string
+
+	// CNPJ is the CNPJ of the headquarters of the institution.
+	CNPJ br.CNPJ
+
+	// ShortName is the short name used by the Banco Central, such as BCO DO BRASIL S.A.
+	ShortName string
+
+	// Name is the full name of the institution.
+	Name string
+
+	// Pix tells how the institution participates in Pix.
+	Pix PixFlags
+}
+
+type registry struct {
+	version      string
+	institutions []Institution
+	byCOMPE      map[string]int
+	byISPB       map[string]int
+}
+
+var loadRegistry = sync.OnceValue(func() registry {
+	r, err := parseRegistry(institutionsCSV)
+	if err != nil {
+		panic("bank: invalid embedded registry: " + err.Error())
+	}
+	return r
+})
+
+// parseRegistry parses the CSV written by internal/gen. Its first line is a comment with the version
+// of the dataset, followed by a header and the rows.
+func parseRegistry(s string) (registry, error) {
+	var r registry
+
+	if rest, ok := strings.CutPrefix(s, "# version: "); ok {
+		r.version, s, _ = strings.Cut(rest, "\n")
+		r.version = strings.TrimSpace(r.version)
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(s)).ReadAll()
+	if err != nil {
+		return registry{}, err
+	}
+
+	if len(rows) > 0 {
+		rows = rows[1:]
+	}
+
+	r.institutions = make([]Institution, 0, len(rows))
+	r.byCOMPE = make(map[string]int, len(rows))
+	r.byISPB = make(map[string]int, len(rows))
+
+	for _, row := range rows {
+		if len(row) != 6 {
+			return registry{}, csv.ErrFieldCount
+		}
+
+		inst := Institution{
+			ISPB:      row[0],
+			COMPE:     row[1],
+			CNPJ:      br.CNPJ(row[2]),
+			ShortName: row[3],
+			Name:      row[4],
+		}
+
+		for _, c := range row[5] {
+			switch c {
+			case 'P':
+				inst.Pix |= PixParticipant
+			case 'D':
+				inst.Pix |= PixDirect
+			case 'M':
+				inst.Pix |= PixMandatory
+			}
+		}
+
+		r.byISPB[inst.ISPB] = len(r.institutions)
+		if inst.COMPE != "" {
+			r.byCOMPE[inst.COMPE] = len(r.institutions)
+		}
+		r.institutions = append(r.institutions, inst)
+	}
+
+	return r, nil
+}
+
+// RegistryVersion returns the version of the embedded dataset of institutions, the date it was generated.
+//
+// It is "sample" when the dataset is the hand-written sample, which only has some of the institutions,
+// so LookupCOMPE and LookupISPB do not find most of the participants of the STR and of Pix.
+func RegistryVersion() string {
+	return loadRegistry().version
+}
+
+// Institutions returns all the institutions of the embedded dataset, sorted by ISPB.
+func Institutions() []Institution {
+	r := loadRegistry()
+	out := make([]Institution, len(r.institutions))
+	copy(out, r.institutions)
+	return out
+}
+
+// LookupCOMPE returns the institution with the given 3-digit COMPE code.
+func LookupCOMPE(code string) (Institution, bool) {
+	r := loadRegistry()
+	i, ok := r.byCOMPE[code]
+	if !ok {
+		return Institution{}, false
+	}
+	return r.institutions[i], true
+}
+
+// LookupISPB returns the institution with the given 8-digit ISPB.
+func LookupISPB(ispb string) (Institution, bool) {
+	r := loadRegistry()
+	i, ok := r.byISPB[ispb]
+	if !ok {
+		return Institution{}, false
+	}
+	return r.institutions[i], true
+}
+
+// SearchName returns the institutions whose short name or full name contain name, ignoring case and accents.
+func SearchName(name string) []Institution {
+	name = foldName(name)
+	if name == "" {
+		return nil
+	}
+
+	var out []Institution
+	for _, inst := range loadRegistry().institutions {
+		if strings.Contains(foldName(inst.ShortName), name) || strings.Contains(foldName(inst.Name), name) {
+			out = append(out, inst)
+		}
+	}
+	return out
+}
+
+// Institution returns the institution of the bank of the BankAccount.
+func (a BankAccount) Institution() (Institution, bool) {
+	return LookupCOMPE(a.Banco)
+}
+
+var accents = strings.NewReplacer(
+	"Á", "A", "À", "A", "Â", "A", "Ã", "A", "Ä", "A",
+	"É", "E", "È", "E", "Ê", "E", "Ë", "E",
+	"Í", "I", "Ì", "I", "Î", "I", "Ï", "I",
+	"Ó", "O", "Ò", "O", "Ô", "O", "Õ", "O", "Ö", "O",
+	"Ú", "U", "Ù", "U", "Û", "U", "Ü", "U",
+	"Ç", "C", "Ñ", "N",
+)
+
+// foldName uppercases s, removing its accents and extra spaces.
+func foldName(s string) string {
+	return strings.Join(strings.Fields(accents.Replace(strings.ToUpper(s))), " ")
+}
@@ -0,0 +1,182 @@
+package bank
+
+import (
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	if RegistryVersion() == "" {
+		t.Error("expected registry version")
+	}
+
+	institutions := Institutions()
+	if len(institutions) == 0 {
+		t.Fatal("expected institutions")
+	}
+
+	for i, inst := range institutions {
+		if len(inst.ISPB) != 8 || !isNumeric(inst.ISPB) {
+			t.Errorf("invalid ispb: %q", inst.ISPB)
+		}
+		if inst.COMPE != "" && (len(inst.COMPE) != 3 || !isNumeric(inst.COMPE)) {
+			t.Errorf("invalid compe: %q", inst.COMPE)
+		}
+		if !inst.CNPJ.IsValid() {
+			t.Errorf("invalid cnpj of %s: %q", inst.ISPB, inst.CNPJ)
+		}
+		if string(inst.CNPJ[:8]) != inst.ISPB {
+			t.Errorf("cnpj %s does not match ispb %s", inst.CNPJ, inst.ISPB)
+		}
+		if inst.ShortName == "" || inst.Name == "" {
+			t.Errorf("missing name of %s", inst.ISPB)
+		}
+		if i > 0 && institutions[i-1].ISPB >= inst.ISPB {
+			t.Errorf("institutions not sorted by ispb: %s >= %s", institutions[i-1].ISPB, inst.ISPB)
+		}
+	}
+
+	institutions[0].Name = "changed"
+	if Institutions()[0].Name == "changed" {
+		t.Error("Institutions must return a copy")
+	}
+}
+
+func TestLookupCOMPE(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		code string
+		ispb string
+		cnpj string
+		ok   bool
+	}{
+		{"banco do brasil", "001", "00000000", "00000000000191", true},
+		{"santander", "033", "90400888", "90400888000142", true},
+		{"caixa", "104", "00360305", "00360305000104", true},
+		{"bradesco", "237", "60746948", "60746948000112", true},
+		{"itau", "341", "60701190", "60701190000104", true},
+		{"unknown", "999", "", "", false},
+		{"ispb", "00000000", "", "", false},
+		{"empty", "", "", "", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			inst, ok := LookupCOMPE(tc.code)
+			if ok != tc.ok {
+				t.Fatalf("\nwant: %v\ngot: %v", tc.ok, ok)
+			}
+			if inst.ISPB != tc.ispb {
+				t.Errorf("\nwant: %s\ngot: %s", tc.ispb, inst.ISPB)
+			}
+			if string(inst.CNPJ) != tc.cnpj {
+				t.Errorf("\nwant: %s\ngot: %s", tc.cnpj, inst.CNPJ)
+			}
+		})
+	}
+}
+
+func TestLookupISPB(t *testing.T) {
+	inst, ok := LookupISPB("18236120")
+	if !ok {
+		t.Fatal("expected institution")
+	}
+
+	if inst.COMPE != "260" {
+		t.Errorf("\nwant: %s\ngot: %s", "260", inst.COMPE)
+	}
+
+	if inst.ShortName != "NU PAGAMENTOS - IP" {
+		t.Errorf("\nwant: %s\ngot: %s", "NU PAGAMENTOS - IP", inst.ShortName)
+	}
+
+	if _, ok := LookupISPB("260"); ok {
+		t.Error("expected no institution")
+	}
+}
+
+func TestSearchName(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"accents", "itaú", []string{"341"}},
+		{"no accents", "ITAU UNIBANCO", []string{"341"}},
+		{"lower", "bradesco", []string{"237"}},
+		{"full name", "rio grande do sul", []string{"041"}},
+		{"spaces", "  banco   do brasil ", []string{"001"}},
+		{"empty", " ", nil},
+		{"unknown", "banco inexistente", nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SearchName(tc.query)
+			if len(got) != len(tc.want) {
+				t.Fatalf("\nwant: %v\ngot: %v", tc.want, got)
+			}
+			for i, inst := range got {
+				if inst.COMPE != tc.want[i] {
+					t.Errorf("\nwant: %s\ngot: %s", tc.want[i], inst.COMPE)
+				}
+			}
+		})
+	}
+}
+
+func TestPixFlags(t *testing.T) {
+	bb, _ := LookupCOMPE(BancoDoBrasil)
+	if !bb.Pix.Has(PixParticipant | PixDirect | PixMandatory) {
+		t.Errorf("unexpected pix flags: %b", bb.Pix)
+	}
+
+	btg, _ := LookupCOMPE("208")
+	if !btg.Pix.Has(PixParticipant | PixDirect) {
+		t.Errorf("unexpected pix flags: %b", btg.Pix)
+	}
+	if btg.Pix.Has(PixMandatory) {
+		t.Errorf("unexpected pix flags: %b", btg.Pix)
+	}
+}
+
+func TestParseRegistry(t *testing.T) {
+	r, err := parseRegistry("# version: 2020-01-02\nispb,compe,cnpj,short_name,name,pix\n00000001,,00000001000100,\"A, B\",A B,P\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.version != "2020-01-02" {
+		t.Errorf("\nwant: %s\ngot: %s", "2020-01-02", r.version)
+	}
+
+	if len(r.institutions) != 1 || r.institutions[0].ShortName != "A, B" || r.institutions[0].Pix != PixParticipant {
+		t.Errorf("unexpected institutions: %+v", r.institutions)
+	}
+
+	if len(r.byCOMPE) != 0 {
+		t.Errorf("unexpected compe codes: %v", r.byCOMPE)
+	}
+
+	if _, err := parseRegistry("ispb,compe\n00000001,001\n"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestBankAccount_Institution(t *testing.T) {
+	a, err := NewBankAccount(Itau, "2545", "02366-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inst, ok := a.Institution()
+	if !ok {
+		t.Fatal("expected institution")
+	}
+
+	if inst.ISPB != "60701190" {
+		t.Errorf("\nwant: %s\ngot: %s", "60701190", inst.ISPB)
+	}
+}
+
+func BenchmarkLookupCOMPE(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		_, _ = LookupCOMPE("341")
+	}
+}
@@ -0,0 +1,300 @@
+package boleto
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/phenpessoa/br"
+)
+
+// ErrIdentificador is returned when the product, segment or value identifier of an arrecadação is unknown.
+var ErrIdentificador = errors.New("br: invalid arrecadacao identifier")
+
+// Segmento identifies the kind of issuer of an arrecadação.
+type Segmento uint8
+
+const (
+	// SegmentoPrefeituras is used by city halls.
+	SegmentoPrefeituras Segmento = 1
+
+	// SegmentoSaneamento is used by water and sewage companies.
+	SegmentoSaneamento Segmento = 2
+
+	// SegmentoEnergiaGas is used by electricity and gas companies.
+	SegmentoEnergiaGas Segmento = 3
+
+	// SegmentoTelecomunicacoes is used by telecommunication companies.
+	SegmentoTelecomunicacoes Segmento = 4
+
+	// SegmentoOrgaosGovernamentais is used by government bodies.
+	SegmentoOrgaosGovernamentais Segmento = 5
+
+	// SegmentoCNPJ is used by carnês and other issuers identified by their CNPJ.
+	SegmentoCNPJ Segmento = 6
+
+	// SegmentoMultasTransito is used by traffic fines.
+	SegmentoMultasTransito Segmento = 7
+
+	// SegmentoBanco is reserved for the exclusive use of each bank.
+	SegmentoBanco Segmento = 9
+)
+
+// String returns the name of the Segmento.
+func (s Segmento) String() string {
+	switch s {
+	case SegmentoPrefeituras:
+		return "prefeituras"
+	case SegmentoSaneamento:
+		return "saneamento"
+	case SegmentoEnergiaGas:
+		return "energia elétrica e gás"
+	case SegmentoTelecomunicacoes:
+		return "telecomunicações"
+	case SegmentoOrgaosGovernamentais:
+		return "órgãos governamentais"
+	case SegmentoCNPJ:
+		return "carnês e assemelhados"
+	case SegmentoMultasTransito:
+		return "multas de trânsito"
+	case SegmentoBanco:
+		return "uso exclusivo do banco"
+	default:
+		return ""
+	}
+}
+
+const (
+	produtoArrecadacao        = '8'
+	linhaArrecadacaoLen       = 48
+	maxValorArrecadacao       = 99_999_999_999
+	blocoArrecadacaoLen       = 11
+	campoLivreArrecadacaoLen  = 25
+	campoLivreArrecadacaoCNPJ = 21
+)
+
+// Arrecadacao holds the data of a boleto of arrecadação, used by utility bills and taxes.
+type Arrecadacao struct {
+	// Segmento is the kind of issuer.
+	Segmento Segmento
+
+	// IdentificadorValor tells what Valor means and which module the check digits use:
+	//
+	//	6: Valor is in reais, with mod 10 check digits
+	//	7: Valor is a reference amount, with mod 10 check digits
+	//	8: Valor is in reais, with mod 11 check digits
+	//	9: Valor is a reference amount, with mod 11 check digits
+	IdentificadorValor byte
+
+	// Valor is the amount in centavos, or the reference amount if ValorEfetivo is false.
+	Valor int64
+
+	// Empresa is the 4-digit identification of the company or government body.
+	// It is empty for SegmentoCNPJ.
+	Empresa string
+
+	// CNPJRaiz is the 8-digit base of the CNPJ of the issuer, only present for SegmentoCNPJ.
+	CNPJRaiz string
+
+	// CampoLivre is the free field, with 21 digits for SegmentoCNPJ and 25 digits otherwise.
+	CampoLivre string
+}
+
+// ParseArrecadacaoCodigoBarras parses the 44 digits of the barcode of an arrecadação, verifying its general check digit.
+//
+// The returned error is a *CheckDigitError or wraps ErrInvalidBoleto.
+func ParseArrecadacaoCodigoBarras(s string) (Arrecadacao, error) {
+	d, err := onlyDigits(s, barcodeLen)
+	if err != nil {
+		return Arrecadacao{}, err
+	}
+
+	dvFunc, err := arrecadacaoModule(d)
+	if err != nil {
+		return Arrecadacao{}, err
+	}
+
+	if dv := dvFunc(d[:3] + d[4:]); d[3] != dv {
+		return Arrecadacao{}, &CheckDigitError{DV: DVGeral, Expected: dv, Actual: d[3]}
+	}
+
+	valor, _ := strconv.ParseInt(d[4:15], 10, 64)
+
+	a := Arrecadacao{
+		Segmento:           Segmento(d[1] - '0'),
+		IdentificadorValor: d[2],
+		Valor:              valor,
+	}
+
+	if a.Segmento == SegmentoCNPJ {
+		a.CNPJRaiz = d[15:23]
+		a.CampoLivre = d[23:]
+	} else {
+		a.Empresa = d[15:19]
+		a.CampoLivre = d[19:]
+	}
+
+	return a, nil
+}
+
+// ParseArrecadacaoLinhaDigitavel parses the 48 digits of the linha digitável of an arrecadação,
+// verifying all its check digits.
+//
+// Spaces, dots and dashes are ignored. The returned error is a *CheckDigitError or wraps ErrInvalidBoleto.
+func ParseArrecadacaoLinhaDigitavel(s string) (Arrecadacao, error) {
+	d, err := onlyDigits(s, linhaArrecadacaoLen)
+	if err != nil {
+		return Arrecadacao{}, err
+	}
+
+	dvFunc, err := arrecadacaoModule(d)
+	if err != nil {
+		return Arrecadacao{}, err
+	}
+
+	barcode := make([]byte, 0, barcodeLen)
+	for i, campo := range []DigitoVerificador{DVCampo1, DVCampo2, DVCampo3, DVCampo4} {
+		bloco := d[i*(blocoArrecadacaoLen+1) : (i+1)*(blocoArrecadacaoLen+1)]
+		if dv := dvFunc(bloco[:blocoArrecadacaoLen]); bloco[blocoArrecadacaoLen] != dv {
+			return Arrecadacao{}, &CheckDigitError{DV: campo, Expected: dv, Actual: bloco[blocoArrecadacaoLen]}
+		}
+		barcode = append(barcode, bloco[:blocoArrecadacaoLen]...)
+	}
+
+	return ParseArrecadacaoCodigoBarras(string(barcode))
+}
+
+// ParseArrecadacao parses either the barcode or the linha digitável of an arrecadação, telling them apart by their length.
+func ParseArrecadacao(s string) (Arrecadacao, error) {
+	d, err := onlyDigits(s, -1)
+	if err != nil {
+		return Arrecadacao{}, err
+	}
+
+	switch len(d) {
+	case barcodeLen:
+		return ParseArrecadacaoCodigoBarras(d)
+	case linhaArrecadacaoLen:
+		return ParseArrecadacaoLinhaDigitavel(d)
+	default:
+		return Arrecadacao{}, fmt.Errorf("%w: %w", ErrInvalidBoleto, ErrLength)
+	}
+}
+
+// arrecadacaoModule checks the identifiers at the start of an arrecadação and returns the
+// function that calculates its check digits.
+func arrecadacaoModule(d string) (func(string) byte, error) {
+	if d[0] != produtoArrecadacao {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBoleto, ErrIdentificador)
+	}
+
+	if Segmento(d[1]-'0').String() == "" {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBoleto, ErrIdentificador)
+	}
+
+	switch d[2] {
+	case '6', '7':
+		return mod10, nil
+	case '8', '9':
+		return mod11Zero, nil
+	default:
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBoleto, ErrIdentificador)
+	}
+}
+
+// ValorEfetivo reports whether Valor is an amount in centavos, instead of a reference amount.
+func (a Arrecadacao) ValorEfetivo() bool {
+	return a.IdentificadorValor == '6' || a.IdentificadorValor == '8'
+}
+
+// CNPJFilial returns the CNPJ of the given 4-digit branch of the issuer of a SegmentoCNPJ
+// arrecadação, such as 0001 for the headquarters.
+//
+// The barcode only has the 8-digit base of the CNPJ, so the branch must be known by the caller,
+// such as from the registry of the issuer. No branch is assumed: if the Arrecadacao has no
+// CNPJRaiz or the branch does not have 4 digits, an empty CNPJ is returned.
+func (a Arrecadacao) CNPJFilial(filial string) br.CNPJ {
+	if len(a.CNPJRaiz) != 8 || !isNumeric(a.CNPJRaiz) || len(filial) != 4 || !isNumeric(filial) {
+		return ""
+	}
+
+	d := a.CNPJRaiz + filial
+	d += string(mod11Zero(d))
+	d += string(mod11Zero(d))
+
+	return br.CNPJ(d)
+}
+
+// IsValid checks whether the fields of the Arrecadacao can be encoded.
+func (a Arrecadacao) IsValid() bool {
+	if a.Segmento.String() == "" || a.IdentificadorValor < '6' || a.IdentificadorValor > '9' ||
+		a.Valor < 0 || a.Valor > maxValorArrecadacao || !isNumeric(a.CampoLivre) {
+		return false
+	}
+
+	if a.Segmento == SegmentoCNPJ {
+		return len(a.CNPJRaiz) == 8 && isNumeric(a.CNPJRaiz) && len(a.CampoLivre) == campoLivreArrecadacaoCNPJ
+	}
+
+	return len(a.Empresa) == 4 && isNumeric(a.Empresa) && len(a.CampoLivre) == campoLivreArrecadacaoLen
+}
+
+// CodigoBarras returns the 44 digits of the barcode of the Arrecadacao, with its general check digit.
+//
+// If the Arrecadacao is invalid, an empty string is returned.
+func (a Arrecadacao) CodigoBarras() string {
+	if !a.IsValid() {
+		return ""
+	}
+
+	d := string([]byte{produtoArrecadacao, byte(a.Segmento) + '0', a.IdentificadorValor}) +
+		fmt.Sprintf("%011d", a.Valor) + a.Empresa + a.CNPJRaiz + a.CampoLivre
+
+	dvFunc, _ := arrecadacaoModule(d)
+	return d[:3] + string(dvFunc(d)) + d[3:]
+}
+
+// LinhaDigitavel returns the linha digitável of the Arrecadacao, formatted as 4 blocks of 11 digits
+// and their check digits, such as 8XXXXXXXXXX-X XXXXXXXXXXX-X XXXXXXXXXXX-X XXXXXXXXXXX-X.
+//
+// If the Arrecadacao is invalid, an empty string is returned.
+func (a Arrecadacao) LinhaDigitavel() string {
+	d := a.CodigoBarras()
+	if d == "" {
+		return ""
+	}
+
+	dvFunc, _ := arrecadacaoModule(d)
+
+	out := make([]byte, 0, linhaArrecadacaoLen+7)
+	for i := range 4 {
+		if i > 0 {
+			out = append(out, ' ')
+		}
+		bloco := d[i*blocoArrecadacaoLen : (i+1)*blocoArrecadacaoLen]
+		out = append(out, bloco...)
+		out = append(out, '-', dvFunc(bloco))
+	}
+
+	return string(out)
+}
+
+// mod11Zero calculates a check digit with the weights 2 to 9, from right to left, as used by arrecadações
+// and some banks. The results 10 and 11 become 0.
+func mod11Zero(s string) byte {
+	var sum int
+	weight := 2
+	for i := len(s) - 1; i >= 0; i-- {
+		sum += int(s[i]-'0') * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+
+	dv := 11 - sum%11
+	if dv >= 10 {
+		dv = 0
+	}
+	return byte(dv) + '0'
+}
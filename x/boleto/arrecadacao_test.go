@@ -0,0 +1,147 @@
+package boleto
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/phenpessoa/br"
+)
+
+func TestParseArrecadacao(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		s     string
+		want  Arrecadacao
+		linha string
+	}{
+		{
+			name: "energia mod 10",
+			s:    "836200000005 667800481000 180975657313 001589636081",
+			want: Arrecadacao{
+				Segmento:           SegmentoEnergiaGas,
+				IdentificadorValor: '6',
+				Valor:              6678,
+				Empresa:            "0048",
+				CampoLivre:         "1001809756573100158963608",
+			},
+			linha: "83620000000-5 66780048100-0 18097565731-3 00158963608-1",
+		},
+		{
+			name: "telecomunicacoes mod 10",
+			s:    "84670000001-7 43590024020-9 02405000243-5 84221010811-9",
+			want: Arrecadacao{
+				Segmento:           SegmentoTelecomunicacoes,
+				IdentificadorValor: '6',
+				Valor:              14359,
+				Empresa:            "0024",
+				CampoLivre:         "0200240500024384221010811",
+			},
+			linha: "84670000001-7 43590024020-9 02405000243-5 84221010811-9",
+		},
+		{
+			name: "cnpj mod 11",
+			s:    "86960000001234511222333202610180000000000042",
+			want: Arrecadacao{
+				Segmento:           SegmentoCNPJ,
+				IdentificadorValor: '9',
+				Valor:              12345,
+				CNPJRaiz:           "11222333",
+				CampoLivre:         "202610180000000000042",
+			},
+			linha: "86960000001-8 23451122233-2 32026101800-4 00000000042-6",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := ParseArrecadacao(tc.s)
+			if err != nil {
+				t.Fatalf("failed to parse arrecadacao: %v", err)
+			}
+
+			if a != tc.want {
+				t.Errorf("\nwant: %+v\ngot: %+v", tc.want, a)
+			}
+
+			if got := a.LinhaDigitavel(); got != tc.linha {
+				t.Errorf("\nwant linha digitavel: %s\ngot linha digitavel: %s", tc.linha, got)
+			}
+
+			b, err := ParseArrecadacaoLinhaDigitavel(a.LinhaDigitavel())
+			if err != nil {
+				t.Fatalf("failed to parse linha digitavel: %v", err)
+			}
+
+			if b.CodigoBarras() != a.CodigoBarras() {
+				t.Errorf("\nwant barcode: %s\ngot barcode: %s", a.CodigoBarras(), b.CodigoBarras())
+			}
+		})
+	}
+}
+
+func TestArrecadacao_CNPJFilial(t *testing.T) {
+	a := Arrecadacao{CNPJRaiz: "11222333"}
+	for _, tc := range []struct {
+		filial string
+		want   br.CNPJ
+	}{
+		{"0001", "11222333000181"},
+		{"0002", "11222333000262"},
+		{"", ""},
+		{"1", ""},
+		{"000A", ""},
+	} {
+		got := a.CNPJFilial(tc.filial)
+		if got != tc.want {
+			t.Errorf("filial %q\nwant: %s\ngot: %s", tc.filial, tc.want, got)
+		}
+		if got != "" && !got.IsValid() {
+			t.Errorf("filial %q: invalid CNPJ %s", tc.filial, got)
+		}
+	}
+
+	if got := (Arrecadacao{Empresa: "0481"}).CNPJFilial("0001"); got != "" {
+		t.Errorf("want empty CNPJ, got %s", got)
+	}
+}
+
+func TestParseArrecadacao_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		s    string
+		dv   DigitoVerificador
+		err  error
+	}{
+		{name: "campo 1", s: "836200000006 667800481000 180975657313 001589636081", dv: DVCampo1},
+		{name: "campo 2", s: "836200000005 667800481001 180975657313 001589636081", dv: DVCampo2},
+		{name: "campo 3", s: "836200000005 667800481000 180975657314 001589636081", dv: DVCampo3},
+		{name: "campo 4", s: "836200000005 667800481000 180975657313 001589636082", dv: DVCampo4},
+		{name: "geral", s: "86950000001234511222333202610180000000000042", dv: DVGeral},
+		{name: "mod 11 block", s: "86960000001-7 23451122233-2 32026101800-4 00000000042-6", dv: DVCampo1},
+		{name: "not arrecadacao", s: bbCodigoBarras, err: ErrIdentificador},
+		{name: "unknown segment", s: "88620000000667800481001809756573100158963608", err: ErrIdentificador},
+		{name: "unknown value identifier", s: "83520000000667800481001809756573100158963608", err: ErrIdentificador},
+		{name: "length", s: "8362000000066780048100018097565731300158963608", err: ErrLength},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseArrecadacao(tc.s)
+			if !errors.Is(err, ErrInvalidBoleto) {
+				t.Fatalf("expected ErrInvalidBoleto, got %v", err)
+			}
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("expected %v, got %v", tc.err, err)
+				}
+				return
+			}
+
+			var cde *CheckDigitError
+			if !errors.As(err, &cde) {
+				t.Fatalf("expected a *CheckDigitError, got %v", err)
+			}
+
+			if cde.DV != tc.dv {
+				t.Errorf("want check digit %s, got %s", tc.dv, cde.DV)
+			}
+		})
+	}
+}
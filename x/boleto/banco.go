@@ -0,0 +1,292 @@
+package boleto
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrCampoLivre is returned when a field of a Layout is invalid.
+	ErrCampoLivre = errors.New("br: invalid boleto campo livre")
+
+	// ErrValor is returned when the amount of a boleto is negative or too large.
+	ErrValor = errors.New("br: invalid boleto valor")
+
+	// ErrVencimento is returned when the due date of a boleto is before the first fator de vencimento.
+	ErrVencimento = errors.New("br: invalid boleto vencimento")
+)
+
+// Layout builds the campo livre of a bank.
+type Layout interface {
+	// Banco returns the 3-digit COMPE code of the bank.
+	Banco() string
+
+	// CampoLivre returns the 25 digits of the free field.
+	CampoLivre() (string, error)
+}
+
+// New assembles a Boleto in reais of the bank of the Layout.
+//
+// A zero vencimento means the boleto has no due date and a zero valor lets the payer choose the amount.
+func New(layout Layout, vencimento time.Time, valor int64) (Boleto, error) {
+	if valor < 0 || valor > maxValor {
+		return Boleto{}, fmt.Errorf("%w: %w", ErrInvalidBoleto, ErrValor)
+	}
+
+	if !vencimento.IsZero() && FatorVencimento(vencimento) == 0 {
+		return Boleto{}, fmt.Errorf("%w: %w", ErrInvalidBoleto, ErrVencimento)
+	}
+
+	campoLivre, err := layout.CampoLivre()
+	if err != nil {
+		return Boleto{}, fmt.Errorf("%w: %w", ErrInvalidBoleto, err)
+	}
+
+	if len(campoLivre) != campoLivreLen || !isNumeric(campoLivre) {
+		return Boleto{}, fmt.Errorf("%w: %w", ErrInvalidBoleto, ErrCampoLivre)
+	}
+
+	b := Boleto{
+		Banco:      layout.Banco(),
+		Moeda:      MoedaReal,
+		Vencimento: vencimento,
+		Valor:      valor,
+		CampoLivre: campoLivre,
+	}
+
+	if !b.IsValid() {
+		return Boleto{}, fmt.Errorf("%w: %w", ErrInvalidBoleto, ErrCampoLivre)
+	}
+
+	return b, nil
+}
+
+// field is a numeric field of a campo livre, left padded with zeros to n digits.
+type field struct {
+	name  string
+	value string
+	n     int
+}
+
+// padFields pads and joins the fields, returning an error naming the first one that does not fit.
+func padFields(fields ...field) (string, error) {
+	var sb strings.Builder
+	for _, f := range fields {
+		if f.value == "" || len(f.value) > f.n || !isNumeric(f.value) {
+			return "", fmt.Errorf("%w: %s", ErrCampoLivre, f.name)
+		}
+		sb.WriteString(strings.Repeat("0", f.n-len(f.value)))
+		sb.WriteString(f.value)
+	}
+	return sb.String(), nil
+}
+
+// BancoDoBrasil is the Layout of the Banco do Brasil (001).
+//
+// The length of the Convenio selects the layout:
+//
+//	7 digits: convênio, nosso número (10) and carteira
+//	6 digits: convênio, nosso número (5), agência, conta and carteira; or
+//	          convênio and nosso número (17), for the service 21
+//	4 digits: convênio, nosso número (7), agência, conta and carteira
+type BancoDoBrasil struct {
+	Convenio    string
+	NossoNumero string
+	Agencia     string
+	Conta       string
+	Carteira    string
+}
+
+// Banco returns 001.
+func (BancoDoBrasil) Banco() string {
+	return "001"
+}
+
+// CampoLivre returns the 25 digits of the free field.
+func (bb BancoDoBrasil) CampoLivre() (string, error) {
+	convenio := field{"convenio", bb.Convenio, len(bb.Convenio)}
+
+	switch len(bb.Convenio) {
+	case 7:
+		d, err := padFields(convenio, field{"nosso numero", bb.NossoNumero, 10}, field{"carteira", bb.Carteira, 2})
+		if err != nil {
+			return "", err
+		}
+		return "000000" + d, nil
+	case 6:
+		if len(bb.NossoNumero) == 17 {
+			d, err := padFields(convenio, field{"nosso numero", bb.NossoNumero, 17})
+			if err != nil {
+				return "", err
+			}
+			return d + "21", nil
+		}
+
+		return padFields(
+			convenio,
+			field{"nosso numero", bb.NossoNumero, 5},
+			field{"agencia", bb.Agencia, 4},
+			field{"conta", bb.Conta, 8},
+			field{"carteira", bb.Carteira, 2},
+		)
+	case 4:
+		return padFields(
+			convenio,
+			field{"nosso numero", bb.NossoNumero, 7},
+			field{"agencia", bb.Agencia, 4},
+			field{"conta", bb.Conta, 8},
+			field{"carteira", bb.Carteira, 2},
+		)
+	default:
+		return "", fmt.Errorf("%w: convenio", ErrCampoLivre)
+	}
+}
+
+// itauCarteirasSemConta are the carteiras of Itaú whose nosso número DAC does not include the agência and the conta.
+var itauCarteirasSemConta = []string{"126", "131", "146", "150", "168"}
+
+// Itau is the Layout of the Itaú Unibanco (341).
+//
+// The campo livre has the carteira (3), the nosso número (8) and its DAC, the agência (4),
+// the conta (5) and its DAC, followed by 000.
+type Itau struct {
+	Carteira    string
+	NossoNumero string
+	Agencia     string
+	Conta       string
+}
+
+// Banco returns 341.
+func (Itau) Banco() string {
+	return "341"
+}
+
+// CampoLivre returns the 25 digits of the free field.
+func (it Itau) CampoLivre() (string, error) {
+	d, err := padFields(
+		field{"carteira", it.Carteira, 3},
+		field{"nosso numero", it.NossoNumero, 8},
+		field{"agencia", it.Agencia, 4},
+		field{"conta", it.Conta, 5},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	carteira, nossoNumero, agencia, conta := d[0:3], d[3:11], d[11:15], d[15:20]
+
+	dacNossoNumero := mod10(agencia + conta + carteira + nossoNumero)
+	if slices.Contains(itauCarteirasSemConta, carteira) {
+		dacNossoNumero = mod10(carteira + nossoNumero)
+	}
+
+	return carteira + nossoNumero + string(dacNossoNumero) + agencia + conta + string(mod10(agencia+conta)) + "000", nil
+}
+
+// Bradesco is the Layout of the Banco Bradesco (237).
+//
+// The campo livre has the agência (4), the carteira (2), the nosso número (11) and the conta (7), followed by 0.
+type Bradesco struct {
+	Agencia     string
+	Carteira    string
+	NossoNumero string
+	Conta       string
+}
+
+// Banco returns 237.
+func (Bradesco) Banco() string {
+	return "237"
+}
+
+// CampoLivre returns the 25 digits of the free field.
+func (bd Bradesco) CampoLivre() (string, error) {
+	d, err := padFields(
+		field{"agencia", bd.Agencia, 4},
+		field{"carteira", bd.Carteira, 2},
+		field{"nosso numero", bd.NossoNumero, 11},
+		field{"conta", bd.Conta, 7},
+	)
+	if err != nil {
+		return "", err
+	}
+	return d + "0", nil
+}
+
+// Caixa is the Layout of the Caixa Econômica Federal (104), in the SIGCB format for registered
+// boletos issued by the beneficiário.
+//
+// NossoNumero is the 15-digit sequence of the nosso número, without the 2-digit prefix 14.
+type Caixa struct {
+	Beneficiario string
+	NossoNumero  string
+}
+
+const (
+	caixaRegistrada           = "1"
+	caixaEmissaoBeneficiario  = "4"
+	caixaNossoNumeroSequencia = 15
+)
+
+// Banco returns 104.
+func (Caixa) Banco() string {
+	return "104"
+}
+
+// CampoLivre returns the 25 digits of the free field.
+func (cx Caixa) CampoLivre() (string, error) {
+	d, err := padFields(
+		field{"beneficiario", cx.Beneficiario, 6},
+		field{"nosso numero", cx.NossoNumero, caixaNossoNumeroSequencia},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	beneficiario, nossoNumero := d[:6], d[6:]
+
+	out := beneficiario + string(mod11Zero(beneficiario)) +
+		nossoNumero[0:3] + caixaRegistrada +
+		nossoNumero[3:6] + caixaEmissaoBeneficiario +
+		nossoNumero[6:15]
+
+	return out + string(mod11Zero(out)), nil
+}
+
+// Santander is the Layout of the Banco Santander (033).
+//
+// NossoNumero has up to 12 digits, without its check digit, which is calculated.
+// IOF is only used by insurance companies and is 0 otherwise.
+type Santander struct {
+	Beneficiario string
+	NossoNumero  string
+	Carteira     string
+	IOF          uint8
+}
+
+// Banco returns 033.
+func (Santander) Banco() string {
+	return "033"
+}
+
+// CampoLivre returns the 25 digits of the free field.
+func (st Santander) CampoLivre() (string, error) {
+	if st.IOF > 9 {
+		return "", fmt.Errorf("%w: iof", ErrCampoLivre)
+	}
+
+	d, err := padFields(
+		field{"beneficiario", st.Beneficiario, 7},
+		field{"nosso numero", st.NossoNumero, 12},
+		field{"carteira", st.Carteira, 3},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	beneficiario, nossoNumero, carteira := d[:7], d[7:19], d[19:22]
+
+	return "9" + beneficiario + nossoNumero + string(mod11Zero(nossoNumero)) + string('0'+st.IOF) + carteira, nil
+}
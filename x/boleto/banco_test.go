@@ -0,0 +1,145 @@
+package boleto
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		layout     Layout
+		campoLivre string
+	}{
+		{
+			name:       "banco do brasil convenio 6",
+			layout:     BancoDoBrasil{Convenio: "050094", NossoNumero: "01448", Agencia: "1606", Conta: "06809350", Carteira: "31"},
+			campoLivre: "0500940144816060680935031",
+		},
+		{
+			name:       "banco do brasil convenio 6 nosso numero 17",
+			layout:     BancoDoBrasil{Convenio: "123456", NossoNumero: "12345678901234567"},
+			campoLivre: "1234561234567890123456721",
+		},
+		{
+			name:       "banco do brasil convenio 7",
+			layout:     BancoDoBrasil{Convenio: "1234567", NossoNumero: "89", Carteira: "18"},
+			campoLivre: "0000001234567000000008918",
+		},
+		{
+			name:       "banco do brasil convenio 4",
+			layout:     BancoDoBrasil{Convenio: "1234", NossoNumero: "5", Agencia: "1606", Conta: "6809350", Carteira: "17"},
+			campoLivre: "1234000000516060680935017",
+		},
+		{
+			name:       "itau",
+			layout:     Itau{Carteira: "109", NossoNumero: "12345678", Agencia: "57", Conta: "12345"},
+			campoLivre: "1091234567800057123457000",
+		},
+		{
+			name:       "itau carteira sem conta",
+			layout:     Itau{Carteira: "126", NossoNumero: "12345678", Agencia: "0057", Conta: "12345"},
+			campoLivre: "1261234567850057123457000",
+		},
+		{
+			name:       "bradesco",
+			layout:     Bradesco{Agencia: "1234", Carteira: "9", NossoNumero: "123", Conta: "7654321"},
+			campoLivre: "1234090000000012376543210",
+		},
+		{
+			name:       "caixa",
+			layout:     Caixa{Beneficiario: "5507", NossoNumero: "19"},
+			campoLivre: "0055077000100040000000190",
+		},
+		{
+			name:       "santander",
+			layout:     Santander{Beneficiario: "1234567", NossoNumero: "123456", Carteira: "101"},
+			campoLivre: "9123456700000012345600101",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			vencimento := date(2026, time.November, 30)
+
+			b, err := New(tt.layout, vencimento, 12345)
+			if err != nil {
+				t.Fatalf("failed to create boleto: %v", err)
+			}
+
+			if b.Banco != tt.layout.Banco() || b.Moeda != MoedaReal || b.CampoLivre != tt.campoLivre {
+				t.Errorf("\nwant: %s %c %s\ngot: %s %c %s", tt.layout.Banco(), MoedaReal, tt.campoLivre, b.Banco, b.Moeda, b.CampoLivre)
+			}
+
+			got, err := ParseLinhaDigitavel(b.LinhaDigitavel())
+			if err != nil {
+				t.Fatalf("failed to parse linha digitavel: %v", err)
+			}
+
+			if got != b {
+				t.Errorf("\nwant: %+v\ngot: %+v", b, got)
+			}
+		})
+	}
+}
+
+func TestNew_BancoDoBrasil(t *testing.T) {
+	b, err := New(
+		BancoDoBrasil{Convenio: "050094", NossoNumero: "1448", Agencia: "1606", Conta: "6809350", Carteira: "31"},
+		date(2007, time.December, 31),
+		100,
+	)
+	if err != nil {
+		t.Fatalf("failed to create boleto: %v", err)
+	}
+
+	if got := b.CodigoBarras(); got != bbCodigoBarras {
+		t.Errorf("\nwant: %s\ngot: %s", bbCodigoBarras, got)
+	}
+
+	if got := b.LinhaDigitavel(); got != bbLinhaDigitavel {
+		t.Errorf("\nwant: %s\ngot: %s", bbLinhaDigitavel, got)
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	vencimento := date(2026, time.November, 30)
+	itau := Itau{Carteira: "109", NossoNumero: "12345678", Agencia: "0057", Conta: "12345"}
+
+	for _, tt := range []struct {
+		name       string
+		layout     Layout
+		vencimento time.Time
+		valor      int64
+		err        error
+	}{
+		{"negative valor", itau, vencimento, -1, ErrValor},
+		{"valor too large", itau, vencimento, maxValor + 1, ErrValor},
+		{"vencimento before fator", itau, date(2000, time.July, 2), 100, ErrVencimento},
+		{"nosso numero too long", Itau{Carteira: "109", NossoNumero: "123456789", Agencia: "0057", Conta: "12345"}, vencimento, 100, ErrCampoLivre},
+		{"non numeric conta", Bradesco{Agencia: "1234", Carteira: "09", NossoNumero: "1", Conta: "12A"}, vencimento, 100, ErrCampoLivre},
+		{"empty agencia", Bradesco{Carteira: "09", NossoNumero: "1", Conta: "1"}, vencimento, 100, ErrCampoLivre},
+		{"convenio length", BancoDoBrasil{Convenio: "12345", NossoNumero: "1", Carteira: "17"}, vencimento, 100, ErrCampoLivre},
+		{"iof", Santander{Beneficiario: "1", NossoNumero: "1", Carteira: "101", IOF: 10}, vencimento, 100, ErrCampoLivre},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.layout, tt.vencimento, tt.valor)
+			if !errors.Is(err, ErrInvalidBoleto) {
+				t.Errorf("expected ErrInvalidBoleto, got %v", err)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestNew_NoVencimento(t *testing.T) {
+	b, err := New(Bradesco{Agencia: "1234", Carteira: "09", NossoNumero: "123", Conta: "7654321"}, time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("failed to create boleto: %v", err)
+	}
+
+	if got := b.CodigoBarras()[5:19]; got != "00000000000000" {
+		t.Errorf("expected no fator and no valor, got %s", got)
+	}
+}
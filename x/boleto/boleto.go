@@ -0,0 +1,355 @@
+// Package boleto provides functions for parsing and building the linha digitável and the
+// barcode of Brazilian boletos.
+package boleto
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrInvalidBoleto is matched by every error returned when parsing a boleto.
+	ErrInvalidBoleto = errors.New("br: invalid boleto")
+
+	// ErrLength is returned when a boleto does not have the expected amount of digits.
+	ErrLength = errors.New("br: invalid boleto length")
+
+	// ErrCharacter is returned when a boleto has a character other than digits, spaces, dots and dashes.
+	ErrCharacter = errors.New("br: invalid boleto character")
+)
+
+// DigitoVerificador identifies one of the check digits of a boleto.
+type DigitoVerificador uint8
+
+const (
+	// DVCampo1 is the check digit of the first field of the linha digitável.
+	DVCampo1 DigitoVerificador = iota + 1
+
+	// DVCampo2 is the check digit of the second field of the linha digitável.
+	DVCampo2
+
+	// DVCampo3 is the check digit of the third field of the linha digitável.
+	DVCampo3
+
+	// DVGeral is the check digit of the whole barcode.
+	DVGeral
+
+	// DVCampo4 is the check digit of the fourth block of the linha digitável of an arrecadação.
+	DVCampo4
+)
+
+// String returns the name of the DigitoVerificador.
+func (dv DigitoVerificador) String() string {
+	switch dv {
+	case DVCampo1:
+		return "campo 1"
+	case DVCampo2:
+		return "campo 2"
+	case DVCampo3:
+		return "campo 3"
+	case DVGeral:
+		return "geral"
+	case DVCampo4:
+		return "campo 4"
+	default:
+		return ""
+	}
+}
+
+// CheckDigitError is the error returned when a check digit of a boleto does not match.
+//
+// It matches ErrInvalidBoleto with errors.Is.
+type CheckDigitError struct {
+	// DV is the check digit that failed.
+	DV DigitoVerificador
+
+	// Expected is the check digit calculated from the boleto.
+	Expected byte
+
+	// Actual is the check digit present in the boleto.
+	Actual byte
+}
+
+func (e *CheckDigitError) Error() string {
+	return fmt.Sprintf("br: invalid boleto check digit (%s): expected %c, got %c", e.DV, e.Expected, e.Actual)
+}
+
+func (e *CheckDigitError) Unwrap() error {
+	return ErrInvalidBoleto
+}
+
+const (
+	// MoedaReal is the currency code of the Brazilian real.
+	MoedaReal = '9'
+
+	barcodeLen        = 44
+	linhaDigitavelLen = 47
+	campoLivreLen     = 25
+	maxValor          = 9_999_999_999
+)
+
+// Boleto holds the data of a bank boleto (título), encoded in its barcode and linha digitável.
+type Boleto struct {
+	// Banco is the 3-digit COMPE code of the issuing bank.
+	Banco string
+
+	// Moeda is the currency code, MoedaReal for the Brazilian real.
+	Moeda byte
+
+	// Vencimento is the due date. It is the zero time when the boleto has no due date.
+	Vencimento time.Time
+
+	// Valor is the amount, in centavos. It is 0 when the payer chooses the amount.
+	Valor int64
+
+	// CampoLivre is the 25-digit free field, whose layout is defined by each bank.
+	CampoLivre string
+}
+
+// ParseCodigoBarras parses the 44 digits of the barcode of a boleto, verifying its general check digit.
+//
+// The returned error is a *CheckDigitError or wraps ErrInvalidBoleto.
+func ParseCodigoBarras(s string) (Boleto, error) {
+	d, err := onlyDigits(s, barcodeLen)
+	if err != nil {
+		return Boleto{}, err
+	}
+
+	if dv := mod11(d[:4] + d[5:]); d[4] != dv {
+		return Boleto{}, &CheckDigitError{DV: DVGeral, Expected: dv, Actual: d[4]}
+	}
+
+	fator, _ := strconv.Atoi(d[5:9])
+	valor, _ := strconv.ParseInt(d[9:19], 10, 64)
+
+	return Boleto{
+		Banco:      d[:3],
+		Moeda:      d[3],
+		Vencimento: DataVencimento(fator, now()),
+		Valor:      valor,
+		CampoLivre: d[19:],
+	}, nil
+}
+
+// ParseLinhaDigitavel parses the 47 digits of the linha digitável of a boleto, verifying all its check digits.
+//
+// Spaces, dots and dashes are ignored. The returned error is a *CheckDigitError or wraps ErrInvalidBoleto.
+func ParseLinhaDigitavel(s string) (Boleto, error) {
+	d, err := onlyDigits(s, linhaDigitavelLen)
+	if err != nil {
+		return Boleto{}, err
+	}
+
+	for _, campo := range []struct {
+		dv    DigitoVerificador
+		start int
+		end   int
+	}{
+		{DVCampo1, 0, 9},
+		{DVCampo2, 10, 20},
+		{DVCampo3, 21, 31},
+	} {
+		if dv := mod10(d[campo.start:campo.end]); d[campo.end] != dv {
+			return Boleto{}, &CheckDigitError{DV: campo.dv, Expected: dv, Actual: d[campo.end]}
+		}
+	}
+
+	return ParseCodigoBarras(linhaDigitavelToCodigoBarras(d))
+}
+
+// Parse parses either the barcode or the linha digitável of a boleto, telling them apart by their length.
+func Parse(s string) (Boleto, error) {
+	d, err := onlyDigits(s, -1)
+	if err != nil {
+		return Boleto{}, err
+	}
+
+	switch len(d) {
+	case barcodeLen:
+		return ParseCodigoBarras(d)
+	case linhaDigitavelLen:
+		return ParseLinhaDigitavel(d)
+	default:
+		return Boleto{}, fmt.Errorf("%w: %w", ErrInvalidBoleto, ErrLength)
+	}
+}
+
+// LinhaDigitavelToCodigoBarras converts the linha digitável of a boleto into its barcode, verifying all check digits.
+func LinhaDigitavelToCodigoBarras(s string) (string, error) {
+	b, err := ParseLinhaDigitavel(s)
+	if err != nil {
+		return "", err
+	}
+	return b.CodigoBarras(), nil
+}
+
+// CodigoBarrasToLinhaDigitavel converts the barcode of a boleto into its formatted linha digitável,
+// verifying its general check digit.
+func CodigoBarrasToLinhaDigitavel(s string) (string, error) {
+	b, err := ParseCodigoBarras(s)
+	if err != nil {
+		return "", err
+	}
+	return b.LinhaDigitavel(), nil
+}
+
+// IsValid checks whether the fields of the Boleto can be encoded.
+func (b Boleto) IsValid() bool {
+	return len(b.Banco) == 3 && isNumeric(b.Banco) &&
+		b.Moeda >= '0' && b.Moeda <= '9' &&
+		b.Valor >= 0 && b.Valor <= maxValor &&
+		len(b.CampoLivre) == campoLivreLen && isNumeric(b.CampoLivre)
+}
+
+// CodigoBarras returns the 44 digits of the barcode of the Boleto, with its general check digit.
+//
+// If the Boleto is invalid, an empty string is returned.
+func (b Boleto) CodigoBarras() string {
+	if !b.IsValid() {
+		return ""
+	}
+
+	d := b.Banco + string(b.Moeda) + fmt.Sprintf("%04d%010d", FatorVencimento(b.Vencimento), b.Valor) + b.CampoLivre
+	return d[:4] + string(mod11(d)) + d[4:]
+}
+
+// LinhaDigitavel returns the linha digitável of the Boleto, formatted as
+// AAABC.CCCCX DDDDD.DDDDDY EEEEE.EEEEEZ K UUUUVVVVVVVVVV.
+//
+// If the Boleto is invalid, an empty string is returned.
+func (b Boleto) LinhaDigitavel() string {
+	d := b.CodigoBarras()
+	if d == "" {
+		return ""
+	}
+
+	campo1 := d[:4] + d[19:24]
+	campo1 += string(mod10(campo1))
+	campo2 := d[24:34]
+	campo2 += string(mod10(campo2))
+	campo3 := d[34:44]
+	campo3 += string(mod10(campo3))
+
+	return campo1[:5] + "." + campo1[5:] + " " +
+		campo2[:5] + "." + campo2[5:] + " " +
+		campo3[:5] + "." + campo3[5:] + " " +
+		d[4:5] + " " + d[5:19]
+}
+
+// linhaDigitavelToCodigoBarras rearranges the 47 digits of a linha digitável into the 44 digits of the barcode.
+func linhaDigitavelToCodigoBarras(d string) string {
+	return d[0:4] + d[32:33] + d[33:47] + d[4:9] + d[10:20] + d[21:31]
+}
+
+// now is replaced in tests, so the due dates do not depend on when they run.
+var now = time.Now
+
+// fatorBase is the date of the fator de vencimento 0.
+var fatorBase = time.Date(1997, time.October, 7, 0, 0, 0, 0, time.UTC)
+
+const (
+	fatorMin   = 1000
+	fatorMax   = 9999
+	fatorCycle = fatorMax - fatorMin + 1
+)
+
+// FatorVencimento returns the fator de vencimento of a due date: the days since 1997-10-07.
+//
+// After reaching 9999 on 2025-02-21, the fator restarted at 1000 on 2025-02-22, so it
+// repeats every 9000 days. The zero time, meaning no due date, has the fator 0.
+func FatorVencimento(t time.Time) int {
+	if t.IsZero() {
+		return 0
+	}
+
+	days := int(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Sub(fatorBase).Hours() / 24)
+	if days < fatorMin {
+		return 0
+	}
+
+	return (days-fatorMin)%fatorCycle + fatorMin
+}
+
+// DataVencimento returns the due date of a fator de vencimento.
+//
+// As the fator repeats every 9000 days, the date closest to ref is chosen. For instance, near 2025
+// the fator 1000 is 2025-02-22 instead of 2000-07-03. The fator 0 means no due date and returns
+// the zero time.
+func DataVencimento(fator int, ref time.Time) time.Time {
+	if fator < fatorMin || fator > fatorMax {
+		return time.Time{}
+	}
+
+	refDays := int(ref.Sub(fatorBase).Hours() / 24)
+
+	// The cycle whose dates are centered around ref.
+	cycle := (refDays - fator + fatorCycle/2) / fatorCycle
+	if cycle < 0 {
+		cycle = 0
+	}
+
+	return fatorBase.AddDate(0, 0, fator+cycle*fatorCycle)
+}
+
+// onlyDigits strips spaces, dots and dashes from s, making sure it has n digits. A negative n accepts any length.
+func onlyDigits(s string, n int) (string, error) {
+	out := make([]byte, 0, len(s))
+	for i := range len(s) {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			out = append(out, c)
+		case c == ' ' || c == '.' || c == '-':
+		default:
+			return "", fmt.Errorf("%w: %w", ErrInvalidBoleto, ErrCharacter)
+		}
+	}
+
+	if n >= 0 && len(out) != n {
+		return "", fmt.Errorf("%w: %w", ErrInvalidBoleto, ErrLength)
+	}
+
+	return string(out), nil
+}
+
+// mod10 calculates a check digit with the weights 2 and 1, from right to left, summing the digits of each product.
+func mod10(s string) byte {
+	var sum int
+	weight := 2
+	for i := len(s) - 1; i >= 0; i-- {
+		p := int(s[i]-'0') * weight
+		sum += p/10 + p%10
+		weight = 3 - weight
+	}
+	return byte((10-sum%10)%10) + '0'
+}
+
+// mod11 calculates the general check digit of a barcode with the weights 2 to 9, from right to left.
+// The results 0, 10 and 11 become 1.
+func mod11(s string) byte {
+	var sum int
+	weight := 2
+	for i := len(s) - 1; i >= 0; i-- {
+		sum += int(s[i]-'0') * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+
+	dv := 11 - sum%11
+	if dv == 0 || dv >= 10 {
+		dv = 1
+	}
+	return byte(dv) + '0'
+}
+
+func isNumeric(s string) bool {
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
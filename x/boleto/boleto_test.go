@@ -0,0 +1,181 @@
+package boleto
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// The example of the Banco do Brasil: R$ 1,00 due on 2007-12-31.
+const (
+	bbCodigoBarras   = "00193373700000001000500940144816060680935031"
+	bbLinhaDigitavel = "00190.50095 40144.816069 06809.350314 3 37370000000100"
+)
+
+func init() {
+	now = func() time.Time {
+		return time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func BenchmarkParseLinhaDigitavel(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		_, _ = ParseLinhaDigitavel(bbLinhaDigitavel)
+	}
+}
+
+func TestParse(t *testing.T) {
+	want := Boleto{
+		Banco:      "001",
+		Moeda:      MoedaReal,
+		Vencimento: date(2007, time.December, 31),
+		Valor:      100,
+		CampoLivre: "0500940144816060680935031",
+	}
+
+	for _, s := range []string{
+		bbCodigoBarras,
+		bbLinhaDigitavel,
+		"00190500954014481606906809350314337370000000100",
+	} {
+		t.Run(s, func(t *testing.T) {
+			// The due date is decoded close to 2007, so it falls in the first cycle of the fator.
+			defer func(old func() time.Time) { now = old }(now)
+			now = func() time.Time { return date(2008, time.January, 1) }
+
+			b, err := Parse(s)
+			if err != nil {
+				t.Fatalf("failed to parse boleto: %v", err)
+			}
+
+			if b != want {
+				t.Errorf("\nwant: %+v\ngot: %+v", want, b)
+			}
+
+			if got := b.CodigoBarras(); got != bbCodigoBarras {
+				t.Errorf("\nwant barcode: %s\ngot barcode: %s", bbCodigoBarras, got)
+			}
+
+			if got := b.LinhaDigitavel(); got != bbLinhaDigitavel {
+				t.Errorf("\nwant linha digitavel: %s\ngot linha digitavel: %s", bbLinhaDigitavel, got)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	b := Boleto{
+		Banco:      "341",
+		Moeda:      MoedaReal,
+		Vencimento: date(2026, time.November, 10),
+		Valor:      123456,
+		CampoLivre: "1091234567881234567890000",
+	}
+
+	barcode := b.CodigoBarras()
+	linha := b.LinhaDigitavel()
+
+	if got, err := LinhaDigitavelToCodigoBarras(linha); err != nil || got != barcode {
+		t.Errorf("\nwant: %s\ngot: %s (%v)", barcode, got, err)
+	}
+
+	if got, err := CodigoBarrasToLinhaDigitavel(barcode); err != nil || got != linha {
+		t.Errorf("\nwant: %s\ngot: %s (%v)", linha, got, err)
+	}
+
+	parsed, err := ParseLinhaDigitavel(linha)
+	if err != nil {
+		t.Fatalf("failed to parse boleto: %v", err)
+	}
+
+	if !parsed.Vencimento.Equal(b.Vencimento) || parsed.Valor != b.Valor {
+		t.Errorf("\nwant: %+v\ngot: %+v", b, parsed)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		s    string
+		dv   DigitoVerificador
+		err  error
+	}{
+		{name: "campo 1", s: "00190.50096 40144.816069 06809.350314 3 37370000000100", dv: DVCampo1},
+		{name: "campo 2", s: "00190.50095 40144.816068 06809.350314 3 37370000000100", dv: DVCampo2},
+		{name: "campo 3", s: "00190.50095 40144.816069 06809.350315 3 37370000000100", dv: DVCampo3},
+		{name: "geral linha digitavel", s: "00190.50095 40144.816069 06809.350314 4 37370000000100", dv: DVGeral},
+		{name: "geral barcode", s: "00194373700000001000500940144816060680935031", dv: DVGeral},
+		{name: "amount changed", s: "00193373700000002000500940144816060680935031", dv: DVGeral},
+		{name: "length", s: "0019337370000000100050094014481606068093503", err: ErrLength},
+		{name: "character", s: "00190/50095 40144.816069 06809.350314 3 37370000000100", err: ErrCharacter},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(tc.s)
+			if !errors.Is(err, ErrInvalidBoleto) {
+				t.Fatalf("expected ErrInvalidBoleto, got %v", err)
+			}
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("expected %v, got %v", tc.err, err)
+				}
+				return
+			}
+
+			var cde *CheckDigitError
+			if !errors.As(err, &cde) {
+				t.Fatalf("expected a *CheckDigitError, got %v", err)
+			}
+
+			if cde.DV != tc.dv {
+				t.Errorf("want check digit %s, got %s", tc.dv, cde.DV)
+			}
+		})
+	}
+}
+
+func TestFatorVencimento(t *testing.T) {
+	for _, tc := range []struct {
+		date  time.Time
+		fator int
+	}{
+		{date: time.Time{}, fator: 0},
+		{date: date(2000, time.July, 3), fator: 1000},
+		{date: date(2007, time.December, 31), fator: 3737},
+		{date: date(2025, time.February, 21), fator: 9999},
+		{date: date(2025, time.February, 22), fator: 1000},
+		{date: date(2025, time.February, 23), fator: 1001},
+		{date: date(2026, time.October, 18), fator: 1603},
+		{date: date(2049, time.October, 13), fator: 9999},
+		{date: date(2049, time.October, 14), fator: 1000},
+	} {
+		if got := FatorVencimento(tc.date); got != tc.fator {
+			t.Errorf("%s: want fator %d, got %d", tc.date.Format(time.DateOnly), tc.fator, got)
+		}
+	}
+}
+
+func TestDataVencimento(t *testing.T) {
+	for _, tc := range []struct {
+		fator int
+		ref   time.Time
+		want  time.Time
+	}{
+		{fator: 0, ref: date(2026, time.October, 18), want: time.Time{}},
+		{fator: 3737, ref: date(2008, time.January, 1), want: date(2007, time.December, 31)},
+		{fator: 1000, ref: date(2001, time.January, 1), want: date(2000, time.July, 3)},
+		{fator: 1000, ref: date(2025, time.January, 1), want: date(2025, time.February, 22)},
+		{fator: 9999, ref: date(2025, time.March, 1), want: date(2025, time.February, 21)},
+		{fator: 1603, ref: date(2026, time.October, 18), want: date(2026, time.October, 18)},
+		{fator: 9000, ref: date(2026, time.October, 18), want: date(2022, time.May, 29)},
+	} {
+		if got := DataVencimento(tc.fator, tc.ref); !got.Equal(tc.want) {
+			t.Errorf("fator %d near %s: want %s, got %s", tc.fator, tc.ref.Format(time.DateOnly), tc.want.Format(time.DateOnly), got.Format(time.DateOnly))
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package boleto
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidITF is returned when an ITF barcode is created from an odd amount of digits or from non-digits.
+var ErrInvalidITF = errors.New("br: invalid itf digits")
+
+// The dimensions of the barcode of boletos defined by FEBRABAN.
+const (
+	// ITFNarrowMM is the width, in millimeters, of the narrow bars and spaces.
+	ITFNarrowMM = 0.254
+
+	// ITFWideRatio is how many times the wide bars and spaces are wider than the narrow ones.
+	ITFWideRatio = 3
+
+	// ITFHeightMM is the height, in millimeters, of the bars.
+	ITFHeightMM = 13
+
+	// itfQuietZone is the width, in narrow units, of the light margin on each side of the barcode.
+	itfQuietZone = 10
+)
+
+// itfPatterns holds the 5 elements of each digit, true for the wide ones.
+var itfPatterns = [10][5]bool{
+	{false, false, true, true, false},
+	{true, false, false, false, true},
+	{false, true, false, false, true},
+	{true, true, false, false, false},
+	{false, false, true, false, true},
+	{true, false, true, false, false},
+	{false, true, true, false, false},
+	{false, false, false, true, true},
+	{true, false, false, true, false},
+	{false, true, false, true, false},
+}
+
+// ITF is an Interleaved 2 of 5 barcode, the symbology of the barcode of boletos.
+type ITF struct {
+	// widths holds the widths, in narrow units, of the alternating bars and spaces, starting with a bar.
+	widths []int
+}
+
+// NewITF creates the Interleaved 2 of 5 barcode of an even amount of digits.
+func NewITF(digits string) (ITF, error) {
+	if digits == "" || len(digits)%2 != 0 || !isNumeric(digits) {
+		return ITF{}, ErrInvalidITF
+	}
+
+	width := func(wide bool) int {
+		if wide {
+			return ITFWideRatio
+		}
+		return 1
+	}
+
+	// The start pattern, narrow bar, space, bar and space.
+	widths := make([]int, 0, 4+len(digits)*5+3)
+	widths = append(widths, 1, 1, 1, 1)
+
+	// Each pair of digits interleaves the bars of the first with the spaces of the second.
+	for i := 0; i < len(digits); i += 2 {
+		bars, spaces := itfPatterns[digits[i]-'0'], itfPatterns[digits[i+1]-'0']
+		for j := range 5 {
+			widths = append(widths, width(bars[j]), width(spaces[j]))
+		}
+	}
+
+	// The stop pattern, wide bar, narrow space and narrow bar.
+	widths = append(widths, ITFWideRatio, 1, 1)
+
+	return ITF{widths: widths}, nil
+}
+
+// width returns the width of the barcode in narrow units, without the quiet zones.
+func (itf ITF) width() int {
+	var w int
+	for _, n := range itf.widths {
+		w += n
+	}
+	return w
+}
+
+// Image renders the barcode, with its quiet zones, at the FEBRABAN dimensions for the given resolution
+// in dots per inch. The narrow bars have at least 1 pixel.
+func (itf ITF) Image(dpi int) image.Image {
+	narrow := max(int(float64(dpi)*ITFNarrowMM/25.4+0.5), 1)
+	height := max(int(float64(dpi)*ITFHeightMM/25.4+0.5), 1)
+	width := (itf.width() + itfQuietZone*2) * narrow
+
+	img := image.NewPaletted(image.Rect(0, 0, width, height), color.Palette{color.White, color.Black})
+
+	x := itfQuietZone * narrow
+	for i, n := range itf.widths {
+		w := n * narrow
+		if i%2 == 0 {
+			for px := x; px < x+w; px++ {
+				for y := range height {
+					img.Pix[y*img.Stride+px] = 1
+				}
+			}
+		}
+		x += w
+	}
+
+	return img
+}
+
+// SVG renders the barcode, with its quiet zones, as an SVG document measured in millimeters
+// at the FEBRABAN dimensions.
+func (itf ITF) SVG() string {
+	total := itf.width() + itfQuietZone*2
+	height := strconv.FormatFloat(ITFHeightMM/ITFNarrowMM, 'f', -1, 64)
+	mm := strconv.FormatFloat(float64(total)*ITFNarrowMM, 'f', -1, 64)
+
+	var sb strings.Builder
+	sb.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" version="1.1" viewBox="0 0 ` + strconv.Itoa(total) + ` ` + height + `"`)
+	sb.WriteString(` width="` + mm + `mm" height="` + strconv.Itoa(ITFHeightMM) + `mm" shape-rendering="crispEdges">`)
+	sb.WriteString(`<rect width="100%" height="100%" fill="#FFFFFF"/><path fill="#000000" d="`)
+
+	x := itfQuietZone
+	for i, n := range itf.widths {
+		if i%2 == 0 {
+			sb.WriteString("M" + strconv.Itoa(x) + ",0h" + strconv.Itoa(n) + "v" + height + "h-" + strconv.Itoa(n) + "z")
+		}
+		x += n
+	}
+
+	sb.WriteString(`"/></svg>`)
+	return sb.String()
+}
+
+// ITF returns the Interleaved 2 of 5 barcode of the Boleto, ready to be rendered.
+func (b Boleto) ITF() (ITF, error) {
+	return NewITF(b.CodigoBarras())
+}
+
+// ITF returns the Interleaved 2 of 5 barcode of the Arrecadacao, ready to be rendered.
+func (a Arrecadacao) ITF() (ITF, error) {
+	return NewITF(a.CodigoBarras())
+}
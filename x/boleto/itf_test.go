@@ -0,0 +1,148 @@
+package boleto
+
+import (
+	"errors"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func BenchmarkITF_Image(b *testing.B) {
+	itf, err := NewITF(bbCodigoBarras)
+	if err != nil {
+		b.Fatalf("failed to create itf: %v", err)
+	}
+
+	b.ReportAllocs()
+	for range b.N {
+		_ = itf.Image(300)
+	}
+}
+
+// decodeITF reads the digits back from the widths of an ITF barcode.
+func decodeITF(t *testing.T, widths []int) string {
+	t.Helper()
+
+	if len(widths) < 7 || (len(widths)-7)%10 != 0 {
+		t.Fatalf("unexpected amount of elements: %d", len(widths))
+	}
+
+	for i, w := range []int{1, 1, 1, 1} {
+		if widths[i] != w {
+			t.Fatalf("invalid start pattern: %v", widths[:4])
+		}
+	}
+	for i, w := range []int{ITFWideRatio, 1, 1} {
+		if widths[len(widths)-3+i] != w {
+			t.Fatalf("invalid stop pattern: %v", widths[len(widths)-3:])
+		}
+	}
+
+	digit := func(pattern [5]bool) byte {
+		for d, p := range itfPatterns {
+			if p == pattern {
+				return byte(d) + '0'
+			}
+		}
+		t.Fatalf("unknown pattern: %v", pattern)
+		return 0
+	}
+
+	var out []byte
+	for i := 4; i < len(widths)-3; i += 10 {
+		var bars, spaces [5]bool
+		for j := range 5 {
+			bars[j] = widths[i+j*2] == ITFWideRatio
+			spaces[j] = widths[i+j*2+1] == ITFWideRatio
+		}
+		out = append(out, digit(bars), digit(spaces))
+	}
+
+	return string(out)
+}
+
+func TestNewITF(t *testing.T) {
+	for _, s := range []string{"00", "1234567890", bbCodigoBarras} {
+		t.Run(s, func(t *testing.T) {
+			itf, err := NewITF(s)
+			if err != nil {
+				t.Fatalf("failed to create itf: %v", err)
+			}
+
+			if got := decodeITF(t, itf.widths); got != s {
+				t.Errorf("\nwant: %s\ngot: %s", s, got)
+			}
+		})
+	}
+}
+
+func TestNewITF_Errors(t *testing.T) {
+	for _, s := range []string{"", "1", "123", "12a4"} {
+		t.Run(s, func(t *testing.T) {
+			if _, err := NewITF(s); !errors.Is(err, ErrInvalidITF) {
+				t.Errorf("expected ErrInvalidITF, got %v", err)
+			}
+		})
+	}
+}
+
+func TestITF_Dimensions(t *testing.T) {
+	b, err := ParseCodigoBarras(bbCodigoBarras)
+	if err != nil {
+		t.Fatalf("failed to parse boleto: %v", err)
+	}
+
+	itf, err := b.ITF()
+	if err != nil {
+		t.Fatalf("failed to create itf: %v", err)
+	}
+
+	// FEBRABAN: 44 digits take 405 narrow units, about 103 mm.
+	if got := itf.width(); got != 405 {
+		t.Errorf("expected 405 units, got %d", got)
+	}
+
+	img := itf.Image(300)
+	if got, want := img.Bounds().Dx(), (405+itfQuietZone*2)*3; got != want {
+		t.Errorf("expected width %d, got %d", want, got)
+	}
+	if got := img.Bounds().Dy(); got != 154 {
+		t.Errorf("expected height 154, got %d", got)
+	}
+
+	black := color.GrayModel.Convert(color.Black)
+	for _, tt := range []struct {
+		x     int
+		black bool
+	}{
+		{0, false},
+		{itfQuietZone*3 - 1, false},
+		{itfQuietZone * 3, true},
+		{itfQuietZone*3 + 2, true},
+		{itfQuietZone*3 + 3, false},
+		{itfQuietZone*3 + 6, true},
+		{img.Bounds().Dx() - 1, false},
+	} {
+		got := color.GrayModel.Convert(img.At(tt.x, 100)) == black
+		if got != tt.black {
+			t.Errorf("pixel %d: expected black %t, got %t", tt.x, tt.black, got)
+		}
+	}
+
+	svg := itf.SVG()
+	if !strings.HasPrefix(svg, "<svg") || !strings.HasSuffix(svg, "</svg>") {
+		t.Errorf("invalid svg: %s", svg)
+	}
+	if !strings.Contains(svg, `viewBox="0 0 425 `) || !strings.Contains(svg, `height="13mm"`) {
+		t.Errorf("invalid svg dimensions: %s", svg[:200])
+	}
+	if got := strings.Count(svg, "M"); got != 44/2*5+2+2 {
+		t.Errorf("expected %d bars, got %d", 44/2*5+2+2, got)
+	}
+}
+
+func TestArrecadacao_ITF(t *testing.T) {
+	if _, err := (Arrecadacao{}).ITF(); !errors.Is(err, ErrInvalidITF) {
+		t.Errorf("expected ErrInvalidITF, got %v", err)
+	}
+}
@@ -0,0 +1,591 @@
+// Package cnab provides functions for reading and writing the CNAB 240 and CNAB 400 files defined by
+// FEBRABAN, used to exchange remittance (remessa) and return (retorno) files of boletos with banks.
+//
+// The columns of each record are described by a Layout240 or a Layout400, made of Field descriptors
+// that map the columns to the fields of the Go structs of this package. The layouts of this package
+// can be adapted to the variations of each bank, or new ones can be declared.
+//
+// This package provides FEBRABAN240, the CNAB 240 layout of FEBRABAN, and the CNAB 240 layouts of
+// the Banco do Brasil, the Caixa, the Santander and the Itaú, declared from it. For CNAB 400, it
+// provides the layouts of the remessas and of the retornos of the Bradesco and of the Itaú.
+package cnab
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/phenpessoa/br"
+	"github.com/phenpessoa/br/x/address"
+)
+
+var (
+	// ErrInvalidFile is matched by every error returned by this package.
+	ErrInvalidFile = errors.New("br: invalid cnab file")
+
+	// ErrLength is returned when a line does not have the width of the layout.
+	ErrLength = errors.New("br: invalid cnab line length")
+
+	// ErrRecord is returned when a record is unknown or is not allowed where it is.
+	ErrRecord = errors.New("br: unexpected cnab record")
+
+	// ErrValue is returned when the value of a field can not be read or written.
+	ErrValue = errors.New("br: invalid cnab field value")
+
+	// ErrCount is returned when a sequence number or an amount of records does not match the file.
+	ErrCount = errors.New("br: invalid cnab record count")
+
+	// ErrLayout is returned when a Field does not match the fields of the record.
+	ErrLayout = errors.New("br: invalid cnab layout")
+)
+
+// FieldError is the error returned when a line of a CNAB file is invalid.
+//
+// It matches ErrInvalidFile and Err with errors.Is.
+type FieldError struct {
+	// Line is the 1-based line of the file.
+	Line int
+
+	// Column is the 1-based column where the field starts, or 0 when the whole line is invalid.
+	Column int
+
+	// Field is the name of the field, if the error is about a named field.
+	Field string
+
+	// Err is one of ErrLength, ErrRecord, ErrValue, ErrCount or ErrLayout.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.Err.Error())
+	sb.WriteString(": line ")
+	sb.WriteString(strconv.Itoa(e.Line))
+	if e.Column > 0 {
+		sb.WriteString(", column ")
+		sb.WriteString(strconv.Itoa(e.Column))
+	}
+	if e.Field != "" {
+		sb.WriteString(" (" + e.Field + ")")
+	}
+	return sb.String()
+}
+
+func (e *FieldError) Unwrap() []error {
+	return []error{ErrInvalidFile, e.Err}
+}
+
+// Kind tells how the value of a Field is formatted.
+type Kind uint8
+
+const (
+	// KindAlpha is left aligned and padded with spaces. It holds a string or an address.UF.
+	// Without a Name, the columns are left blank.
+	KindAlpha Kind = iota + 1
+
+	// KindNumeric is right aligned and padded with zeros. It holds a string of digits, an int, an int64,
+	// such as the amounts in centavos, or an address.CEP. Without a Name, the columns are filled with zeros.
+	KindNumeric
+
+	// KindDate is a date formatted as DDMMAAAA with 8 columns or DDMMAA with 6, filled with zeros
+	// when there is no date. It holds a time.Time.
+	KindDate
+
+	// KindTime is the time of day formatted as HHMMSS. It holds the time.Time of the KindDate Field
+	// with the same Name, which must come before it.
+	KindTime
+
+	// KindTipoInscricao is the kind of the document of the Inscricao with the same Name: 1 for CPF
+	// and 2 for CNPJ, padded with zeros.
+	KindTipoInscricao
+
+	// KindInscricao is the number of the CPF or the CNPJ of an Inscricao, padded with zeros.
+	// It needs a KindTipoInscricao Field with the same Name to be read.
+	KindInscricao
+
+	// KindConstant holds the fixed Value, padded with spaces. It is checked when reading.
+	KindConstant
+
+	// KindLote is the number of the lote of a CNAB 240 record, filled and checked by this package.
+	KindLote
+
+	// KindSequencial is the sequence number of the record, filled and checked by this package.
+	// It is the number of the record in its lote for CNAB 240 and the line of the record for CNAB 400.
+	KindSequencial
+)
+
+// Field describes the columns of a field of a record.
+type Field struct {
+	// Name is the name of the field of the Go struct of the record.
+	Name string
+
+	// Pos is the 1-based column where the field starts, as in the layouts of the banks.
+	Pos int
+
+	// Len is the amount of columns of the field.
+	Len int
+
+	// Kind tells how the field is formatted.
+	Kind Kind
+
+	// Value is the value of a KindConstant field.
+	Value string
+}
+
+// Inscricao is the document of a beneficiário, a pagador or a sacador avalista: either a CPF or a CNPJ.
+type Inscricao struct {
+	CPF  br.CPF
+	CNPJ br.CNPJ
+}
+
+// IsZero reports whether the Inscricao has no document.
+func (i Inscricao) IsZero() bool {
+	return i.CPF == "" && i.CNPJ == ""
+}
+
+const (
+	tipoInscricaoCPF  = 1
+	tipoInscricaoCNPJ = 2
+)
+
+// record holds the position of a record in the file, used by the fields that are filled by this package.
+type record struct {
+	line       int
+	lote       int
+	sequencial int
+}
+
+func (r record) errorf(f Field, err error) error {
+	return &FieldError{Line: r.line, Column: f.Pos, Field: f.Name, Err: err}
+}
+
+// encode writes the fields of v into line.
+func encode(line []byte, fields []Field, v reflect.Value, r record) error {
+	for i := range line {
+		line[i] = ' '
+	}
+
+	for _, f := range fields {
+		if f.Pos < 1 || f.Len < 1 || f.Pos+f.Len-1 > len(line) {
+			return r.errorf(f, ErrLayout)
+		}
+
+		s, err := encodeField(f, v, r)
+		if err != nil {
+			return err
+		}
+
+		copy(line[f.Pos-1:f.Pos-1+f.Len], s)
+	}
+
+	return nil
+}
+
+func encodeField(f Field, v reflect.Value, r record) (string, error) {
+	switch f.Kind {
+	case KindConstant:
+		return alpha(f, f.Value, r)
+	case KindLote:
+		return numeric(f, strconv.Itoa(r.lote), r)
+	case KindSequencial:
+		return numeric(f, strconv.Itoa(r.sequencial), r)
+	}
+
+	if f.Name == "" {
+		switch f.Kind {
+		case KindAlpha:
+			return strings.Repeat(" ", f.Len), nil
+		case KindNumeric:
+			return strings.Repeat("0", f.Len), nil
+		default:
+			return "", r.errorf(f, ErrLayout)
+		}
+	}
+
+	fv := v.FieldByName(f.Name)
+	if !fv.IsValid() {
+		return "", r.errorf(f, ErrLayout)
+	}
+
+	switch f.Kind {
+	case KindAlpha:
+		switch x := fv.Interface().(type) {
+		case string:
+			return alpha(f, x, r)
+		case address.UF:
+			if x == 0 {
+				return alpha(f, "", r)
+			}
+			return alpha(f, x.String(), r)
+		}
+	case KindNumeric:
+		switch x := fv.Interface().(type) {
+		case string:
+			return numeric(f, x, r)
+		case int:
+			return numeric(f, strconv.Itoa(x), r)
+		case int64:
+			return numeric(f, strconv.FormatInt(x, 10), r)
+		case address.CEP:
+			if x == "" {
+				return numeric(f, "", r)
+			}
+			if !x.IsValid() {
+				return "", r.errorf(f, ErrValue)
+			}
+			return numeric(f, strings.ReplaceAll(string(x), "-", ""), r)
+		}
+	case KindDate:
+		if t, ok := fv.Interface().(time.Time); ok {
+			layout, ok := dateLayout(f.Len)
+			if !ok {
+				return "", r.errorf(f, ErrLayout)
+			}
+			if t.IsZero() {
+				return numeric(f, "", r)
+			}
+			return t.Format(layout), nil
+		}
+	case KindTime:
+		if t, ok := fv.Interface().(time.Time); ok {
+			if f.Len != 6 {
+				return "", r.errorf(f, ErrLayout)
+			}
+			if t.IsZero() {
+				return numeric(f, "", r)
+			}
+			return t.Format("150405"), nil
+		}
+	case KindTipoInscricao:
+		if x, ok := fv.Interface().(Inscricao); ok {
+			switch {
+			case x.CPF != "" && x.CNPJ != "":
+				return "", r.errorf(f, ErrValue)
+			case x.CPF != "":
+				return numeric(f, strconv.Itoa(tipoInscricaoCPF), r)
+			case x.CNPJ != "":
+				return numeric(f, strconv.Itoa(tipoInscricaoCNPJ), r)
+			default:
+				return numeric(f, "", r)
+			}
+		}
+	case KindInscricao:
+		if x, ok := fv.Interface().(Inscricao); ok {
+			switch {
+			case x.CPF != "" && x.CNPJ != "":
+				return "", r.errorf(f, ErrValue)
+			case x.CPF != "":
+				if !x.CPF.IsValid() {
+					return "", r.errorf(f, ErrValue)
+				}
+				return numeric(f, strings.NewReplacer(".", "", "-", "").Replace(string(x.CPF)), r)
+			case x.CNPJ != "":
+				if !x.CNPJ.IsValid() {
+					return "", r.errorf(f, ErrValue)
+				}
+				return padLeft(f, strings.ToUpper(x.CNPJ.AlphaNumerical()), r)
+			default:
+				return numeric(f, "", r)
+			}
+		}
+	}
+
+	return "", r.errorf(f, ErrLayout)
+}
+
+// alpha pads s with spaces to the right, making sure it only has printable ASCII characters.
+func alpha(f Field, s string, r record) (string, error) {
+	if len(s) > f.Len {
+		return "", r.errorf(f, ErrValue)
+	}
+	for i := range len(s) {
+		if s[i] < ' ' || s[i] > '~' {
+			return "", r.errorf(f, ErrValue)
+		}
+	}
+	return s + strings.Repeat(" ", f.Len-len(s)), nil
+}
+
+// numeric pads the digits of s with zeros to the left.
+func numeric(f Field, s string, r record) (string, error) {
+	if !isNumeric(s) {
+		return "", r.errorf(f, ErrValue)
+	}
+	return padLeft(f, s, r)
+}
+
+func padLeft(f Field, s string, r record) (string, error) {
+	if len(s) > f.Len {
+		return "", r.errorf(f, ErrValue)
+	}
+	return strings.Repeat("0", f.Len-len(s)) + s, nil
+}
+
+func dateLayout(n int) (string, bool) {
+	switch n {
+	case 8:
+		return "02012006", true
+	case 6:
+		return "020106", true
+	default:
+		return "", false
+	}
+}
+
+// decode reads the fields of line into v, which must be addressable.
+func decode(line string, fields []Field, v reflect.Value, r record) error {
+	// The kind of each Inscricao is read first, so the number can be read in any order.
+	tipos := make(map[string]int)
+	for _, f := range fields {
+		if f.Kind != KindTipoInscricao {
+			continue
+		}
+		if f.Pos < 1 || f.Len < 1 || f.Pos+f.Len-1 > len(line) {
+			return r.errorf(f, ErrLayout)
+		}
+		s := line[f.Pos-1 : f.Pos-1+f.Len]
+		if blank(s) {
+			continue
+		}
+		tipo, err := strconv.Atoi(s)
+		if err != nil || tipo < 0 || tipo > tipoInscricaoCNPJ {
+			return r.errorf(f, ErrValue)
+		}
+		tipos[f.Name] = tipo
+	}
+
+	for _, f := range fields {
+		if f.Pos < 1 || f.Len < 1 || f.Pos+f.Len-1 > len(line) {
+			return r.errorf(f, ErrLayout)
+		}
+
+		if err := decodeField(f, line[f.Pos-1:f.Pos-1+f.Len], v, r, tipos); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func decodeField(f Field, s string, v reflect.Value, r record, tipos map[string]int) error {
+	switch f.Kind {
+	case KindConstant:
+		if strings.TrimRight(s, " ") != strings.TrimRight(f.Value, " ") {
+			return r.errorf(f, ErrValue)
+		}
+		return nil
+	case KindLote, KindSequencial:
+		want := r.lote
+		if f.Kind == KindSequencial {
+			want = r.sequencial
+		}
+		if n, err := strconv.Atoi(s); err != nil || !isNumeric(s) {
+			return r.errorf(f, ErrValue)
+		} else if n != want {
+			return r.errorf(f, ErrCount)
+		}
+		return nil
+	case KindTipoInscricao:
+		return nil
+	}
+
+	if f.Name == "" {
+		return nil
+	}
+
+	fv := v.FieldByName(f.Name)
+	if !fv.IsValid() || !fv.CanAddr() {
+		return r.errorf(f, ErrLayout)
+	}
+
+	switch f.Kind {
+	case KindAlpha:
+		switch x := fv.Addr().Interface().(type) {
+		case *string:
+			*x = strings.TrimRight(s, " ")
+			return nil
+		case *address.UF:
+			if blank(s) {
+				*x = 0
+				return nil
+			}
+			uf, err := address.NewUFFromStr(s)
+			if err != nil {
+				return r.errorf(f, ErrValue)
+			}
+			*x = uf
+			return nil
+		}
+	case KindNumeric:
+		if blank(s) {
+			s = strings.Repeat("0", len(s))
+		}
+		if !isNumeric(s) {
+			return r.errorf(f, ErrValue)
+		}
+		switch x := fv.Addr().Interface().(type) {
+		case *string:
+			*x = s
+			return nil
+		case *int:
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return r.errorf(f, ErrValue)
+			}
+			*x = n
+			return nil
+		case *int64:
+			n, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return r.errorf(f, ErrValue)
+			}
+			*x = n
+			return nil
+		case *address.CEP:
+			if strings.Trim(s, "0") == "" {
+				*x = ""
+				return nil
+			}
+			if len(s) > 8 {
+				if strings.Trim(s[:len(s)-8], "0") != "" {
+					return r.errorf(f, ErrValue)
+				}
+				s = s[len(s)-8:]
+			}
+			cep := address.CEP(s)
+			if !cep.IsValid() {
+				return r.errorf(f, ErrValue)
+			}
+			*x = cep
+			return nil
+		}
+	case KindDate:
+		if x, ok := fv.Addr().Interface().(*time.Time); ok {
+			layout, ok := dateLayout(f.Len)
+			if !ok {
+				return r.errorf(f, ErrLayout)
+			}
+			if blank(s) || strings.Trim(s, "0") == "" {
+				*x = time.Time{}
+				return nil
+			}
+			t, err := time.Parse(layout, s)
+			if err != nil {
+				return r.errorf(f, ErrValue)
+			}
+			*x = t
+			return nil
+		}
+	case KindTime:
+		if x, ok := fv.Addr().Interface().(*time.Time); ok {
+			if f.Len != 6 {
+				return r.errorf(f, ErrLayout)
+			}
+			if blank(s) || x.IsZero() {
+				return nil
+			}
+			t, err := time.Parse("150405", s)
+			if err != nil {
+				return r.errorf(f, ErrValue)
+			}
+			*x = time.Date(x.Year(), x.Month(), x.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+			return nil
+		}
+	case KindInscricao:
+		if x, ok := fv.Addr().Interface().(*Inscricao); ok {
+			if blank(s) {
+				s = strings.Repeat("0", len(s))
+			}
+			switch tipos[f.Name] {
+			case tipoInscricaoCPF:
+				if len(s) < 11 || strings.Trim(s[:len(s)-11], "0") != "" {
+					return r.errorf(f, ErrValue)
+				}
+				cpf := br.CPF(s[len(s)-11:])
+				if !cpf.IsValid() {
+					return r.errorf(f, ErrValue)
+				}
+				*x = Inscricao{CPF: cpf}
+			case tipoInscricaoCNPJ:
+				if len(s) < 14 || strings.Trim(s[:len(s)-14], "0") != "" {
+					return r.errorf(f, ErrValue)
+				}
+				cnpj := br.CNPJ(s[len(s)-14:])
+				if !cnpj.IsValid() {
+					return r.errorf(f, ErrValue)
+				}
+				*x = Inscricao{CNPJ: cnpj}
+			default:
+				*x = Inscricao{}
+			}
+			return nil
+		}
+	}
+
+	return r.errorf(f, ErrLayout)
+}
+
+// column returns the column of the field with the given name, or 0 if the layout does not have it.
+func column(fields []Field, name string) int {
+	for _, f := range fields {
+		if f.Name == name {
+			return f.Pos
+		}
+	}
+	return 0
+}
+
+// countError returns the ErrCount error of a field whose value does not match the file.
+func countError(line int, fields []Field, name string) error {
+	return &FieldError{Line: line, Column: column(fields, name), Field: name, Err: ErrCount}
+}
+
+// hasField reports whether the layout has a field with the given name.
+func hasField(fields []Field, name string) bool {
+	return column(fields, name) > 0
+}
+
+// lines splits the contents of a CNAB file into its lines, accepting both CRLF and LF line endings
+// and the end of file character some systems append.
+func lines(data string) []string {
+	data = strings.TrimRight(data, "\x1a")
+	data = strings.TrimSuffix(data, "\n")
+	data = strings.TrimSuffix(data, "\r")
+	if data == "" {
+		return nil
+	}
+
+	out := strings.Split(data, "\n")
+	for i, l := range out {
+		out[i] = strings.TrimSuffix(l, "\r")
+	}
+	return out
+}
+
+func blank(s string) bool {
+	return strings.Trim(s, " ") == ""
+}
+
+func isNumeric(s string) bool {
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// checkLength makes sure a line has the width of the layout.
+func checkLength(line string, n, width int) error {
+	if len(line) != width {
+		return &FieldError{Line: n, Err: ErrLength}
+	}
+	return nil
+}
+
+// recordError returns the ErrRecord error of a line.
+func recordError(line, col int) error {
+	return &FieldError{Line: line, Column: col, Err: ErrRecord}
+}
@@ -0,0 +1,856 @@
+package cnab
+
+import (
+	"bufio"
+	"cmp"
+	"io"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/phenpessoa/br/x/address"
+)
+
+const width240 = 240
+
+// The values of the RemessaRetorno of a HeaderArquivo.
+const (
+	CodigoRemessa = 1
+	CodigoRetorno = 2
+)
+
+// HeaderArquivo is the header of a CNAB 240 file (registro 0).
+type HeaderArquivo struct {
+	Empresa Inscricao
+
+	// Convenio is the code of the agreement with the bank, such as the número do convênio of the
+	// Banco do Brasil, the código do beneficiário of the Caixa or the código de transmissão of the
+	// Santander.
+	Convenio string
+
+	// Carteira and VariacaoCarteira are the wallet of the convênio of the Banco do Brasil.
+	Carteira         int
+	VariacaoCarteira int
+
+	Agencia        string
+	AgenciaDV      string
+	Conta          string
+	ContaDV        string
+	AgenciaContaDV string
+	NomeEmpresa    string
+	NomeBanco      string
+
+	// RemessaRetorno is CodigoRemessa or CodigoRetorno.
+	RemessaRetorno int
+
+	// DataGeracao is the date and the time the file was generated.
+	DataGeracao time.Time
+
+	// NumeroSequencial is the sequence number of the file (NSA).
+	NumeroSequencial int
+
+	VersaoLayout     string
+	Densidade        int
+	ReservadoBanco   string
+	ReservadoEmpresa string
+}
+
+// HeaderLote is the header of a lote of a CNAB 240 file (registro 1).
+type HeaderLote struct {
+	// Operacao is R for remessa and T for retorno.
+	Operacao string
+
+	// Servico is the kind of service of the lote, 1 for cobrança.
+	Servico int
+
+	VersaoLayout string
+	Empresa      Inscricao
+
+	// Convenio, Carteira and VariacaoCarteira are as in the HeaderArquivo.
+	Convenio         string
+	Carteira         int
+	VariacaoCarteira int
+
+	Agencia              string
+	AgenciaDV            string
+	Conta                string
+	ContaDV              string
+	AgenciaContaDV       string
+	NomeEmpresa          string
+	Mensagem1            string
+	Mensagem2            string
+	NumeroRemessaRetorno int
+	DataGravacao         time.Time
+	DataCredito          time.Time
+}
+
+// Segmento is a detail record of a lote of a CNAB 240 file (registro 3): a SegmentoP, SegmentoQ or
+// SegmentoR of a remessa, or a SegmentoT or SegmentoU of a retorno.
+type Segmento interface {
+	// Codigo returns the letter of the segment, at the column 14.
+	Codigo() byte
+}
+
+// SegmentoP holds the data of a título of a remessa.
+type SegmentoP struct {
+	// Movimento is the code of the instruction of the remessa, such as 1 for the registration of a título.
+	Movimento int
+
+	Agencia        string
+	AgenciaDV      string
+	Conta          string
+	ContaDV        string
+	AgenciaContaDV string
+
+	// ContaCobranca and ContaCobrancaDV are the account of cobrança of the Santander, which is not
+	// the conta corrente.
+	ContaCobranca   string
+	ContaCobrancaDV string
+
+	NossoNumero        string
+	Carteira           int
+	FormaCadastramento int
+	TipoDocumento      string
+	EmissaoBoleto      int
+	DistribuicaoBoleto string
+	NumeroDocumento    string
+	Vencimento         time.Time
+
+	// Valor is the amount of the título, in centavos.
+	Valor int64
+
+	AgenciaCobradora   string
+	AgenciaCobradoraDV string
+	Especie            int
+	Aceite             string
+	Emissao            time.Time
+	CodigoJuros        int
+	DataJuros          time.Time
+	Juros              int64
+	CodigoDesconto     int
+	DataDesconto       time.Time
+	Desconto           int64
+	IOF                int64
+	Abatimento         int64
+	UsoEmpresa         string
+	CodigoProtesto     int
+	PrazoProtesto      int
+	CodigoBaixa        int
+	PrazoBaixa         int
+	Moeda              int
+	Contrato           string
+}
+
+// SegmentoQ holds the pagador and the sacador avalista of a título of a remessa.
+type SegmentoQ struct {
+	Movimento                 int
+	Pagador                   Inscricao
+	PagadorNome               string
+	PagadorEndereco           string
+	PagadorBairro             string
+	PagadorCEP                address.CEP
+	PagadorCidade             string
+	PagadorUF                 address.UF
+	Avalista                  Inscricao
+	AvalistaNome              string
+	BancoCorrespondente       string
+	NossoNumeroCorrespondente string
+}
+
+// SegmentoR holds the additional discounts, the fine and the messages of a título of a remessa.
+type SegmentoR struct {
+	Movimento         int
+	CodigoDesconto2   int
+	DataDesconto2     time.Time
+	Desconto2         int64
+	CodigoDesconto3   int
+	DataDesconto3     time.Time
+	Desconto3         int64
+	CodigoMulta       string
+	DataMulta         time.Time
+	Multa             int64
+	InformacaoPagador string
+	Mensagem3         string
+	Mensagem4         string
+}
+
+// SegmentoT holds the data of a título of a retorno.
+type SegmentoT struct {
+	// Movimento is the code of the occurrence of the retorno, such as 6 for a liquidação.
+	Movimento int
+
+	Agencia        string
+	AgenciaDV      string
+	Conta          string
+	ContaDV        string
+	AgenciaContaDV string
+
+	// ContaCobranca is the account of cobrança of the Santander, with its check digit.
+	ContaCobranca string
+
+	NossoNumero        string
+	Carteira           int
+	NumeroDocumento    string
+	Vencimento         time.Time
+	Valor              int64
+	BancoCobrador      string
+	AgenciaCobradora   string
+	AgenciaCobradoraDV string
+	UsoEmpresa         string
+	Moeda              int
+	Pagador            Inscricao
+	PagadorNome        string
+	Contrato           string
+	Tarifa             int64
+
+	// Motivos holds up to 5 codes of 2 characters explaining the occurrence.
+	Motivos string
+}
+
+// SegmentoU holds the amounts and the dates of the payment of a título of a retorno.
+type SegmentoU struct {
+	Movimento                 int
+	Encargos                  int64
+	Desconto                  int64
+	Abatimento                int64
+	IOF                       int64
+	ValorPago                 int64
+	ValorLiquido              int64
+	OutrasDespesas            int64
+	OutrosCreditos            int64
+	DataOcorrencia            time.Time
+	DataCredito               time.Time
+	OcorrenciaPagador         string
+	DataOcorrenciaPagador     time.Time
+	ValorOcorrenciaPagador    int64
+	ComplementoOcorrencia     string
+	BancoCorrespondente       string
+	NossoNumeroCorrespondente string
+}
+
+// Codigo returns P.
+func (SegmentoP) Codigo() byte { return 'P' }
+
+// Codigo returns Q.
+func (SegmentoQ) Codigo() byte { return 'Q' }
+
+// Codigo returns R.
+func (SegmentoR) Codigo() byte { return 'R' }
+
+// Codigo returns T.
+func (SegmentoT) Codigo() byte { return 'T' }
+
+// Codigo returns U.
+func (SegmentoU) Codigo() byte { return 'U' }
+
+// TrailerLote is the trailer of a lote of a CNAB 240 file (registro 5).
+type TrailerLote struct {
+	// QuantidadeRegistros is the amount of records of the lote, including its header and trailer.
+	// It is filled when writing.
+	QuantidadeRegistros int
+
+	QuantidadeCobrancaSimples int
+	ValorCobrancaSimples      int64
+	NumeroAviso               string
+}
+
+// TrailerArquivo is the trailer of a CNAB 240 file (registro 9).
+type TrailerArquivo struct {
+	// QuantidadeLotes is the amount of lotes of the file. It is filled when writing.
+	QuantidadeLotes int
+
+	// QuantidadeRegistros is the amount of records of the file. It is filled when writing.
+	QuantidadeRegistros int
+
+	QuantidadeContas int
+}
+
+// Lote is a batch of a CNAB 240 file.
+type Lote struct {
+	Header    HeaderLote
+	Segmentos []Segmento
+	Trailer   TrailerLote
+}
+
+// File240 is a CNAB 240 file.
+type File240 struct {
+	Header  HeaderArquivo
+	Lotes   []Lote
+	Trailer TrailerArquivo
+}
+
+// Layout240 describes the records of the CNAB 240 files of a bank.
+//
+// A nil segment is not supported by the layout.
+type Layout240 struct {
+	HeaderArquivo  []Field
+	HeaderLote     []Field
+	SegmentoP      []Field
+	SegmentoQ      []Field
+	SegmentoR      []Field
+	SegmentoT      []Field
+	SegmentoU      []Field
+	TrailerLote    []Field
+	TrailerArquivo []Field
+}
+
+// segmento returns the fields and a new value of the segment with the given letter.
+func (l Layout240) segmento(c byte) ([]Field, reflect.Value) {
+	var (
+		fields []Field
+		s      Segmento
+	)
+
+	switch c {
+	case 'P':
+		fields, s = l.SegmentoP, &SegmentoP{}
+	case 'Q':
+		fields, s = l.SegmentoQ, &SegmentoQ{}
+	case 'R':
+		fields, s = l.SegmentoR, &SegmentoR{}
+	case 'T':
+		fields, s = l.SegmentoT, &SegmentoT{}
+	case 'U':
+		fields, s = l.SegmentoU, &SegmentoU{}
+	default:
+		return nil, reflect.Value{}
+	}
+
+	return fields, reflect.ValueOf(s).Elem()
+}
+
+// Read240 reads a CNAB 240 file with the given layout.
+//
+// The returned error is a *FieldError reporting the line and the column of the invalid field,
+// or an error of r.
+func Read240(r io.Reader, l Layout240) (File240, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return File240{}, err
+	}
+
+	var (
+		f       File240
+		lote    *Lote
+		trailer bool
+		lns     = lines(string(data))
+	)
+
+	if len(lns) == 0 {
+		return File240{}, &FieldError{Line: 1, Err: ErrLength}
+	}
+
+	for i, line := range lns {
+		n := i + 1
+		if err := checkLength(line, n, width240); err != nil {
+			return File240{}, err
+		}
+
+		if trailer {
+			return File240{}, recordError(n, 8)
+		}
+
+		switch registro := line[7]; {
+		case n == 1:
+			if registro != '0' {
+				return File240{}, recordError(n, 8)
+			}
+			if err := decode(line, l.HeaderArquivo, reflect.ValueOf(&f.Header).Elem(), record{line: n}); err != nil {
+				return File240{}, err
+			}
+		case registro == '1' && lote == nil:
+			f.Lotes = append(f.Lotes, Lote{})
+			lote = &f.Lotes[len(f.Lotes)-1]
+			if err := decode(line, l.HeaderLote, reflect.ValueOf(&lote.Header).Elem(), record{line: n, lote: len(f.Lotes)}); err != nil {
+				return File240{}, err
+			}
+		case registro == '3' && lote != nil:
+			fields, v := l.segmento(line[13])
+			if fields == nil {
+				return File240{}, recordError(n, 14)
+			}
+			rec := record{line: n, lote: len(f.Lotes), sequencial: len(lote.Segmentos) + 1}
+			if err := decode(line, fields, v, rec); err != nil {
+				return File240{}, err
+			}
+			lote.Segmentos = append(lote.Segmentos, v.Interface().(Segmento))
+		case registro == '5' && lote != nil:
+			if err := decode(line, l.TrailerLote, reflect.ValueOf(&lote.Trailer).Elem(), record{line: n, lote: len(f.Lotes)}); err != nil {
+				return File240{}, err
+			}
+			if hasField(l.TrailerLote, "QuantidadeRegistros") && lote.Trailer.QuantidadeRegistros != len(lote.Segmentos)+2 {
+				return File240{}, countError(n, l.TrailerLote, "QuantidadeRegistros")
+			}
+			lote = nil
+		case registro == '9' && lote == nil:
+			if err := decode(line, l.TrailerArquivo, reflect.ValueOf(&f.Trailer).Elem(), record{line: n}); err != nil {
+				return File240{}, err
+			}
+			if hasField(l.TrailerArquivo, "QuantidadeLotes") && f.Trailer.QuantidadeLotes != len(f.Lotes) {
+				return File240{}, countError(n, l.TrailerArquivo, "QuantidadeLotes")
+			}
+			if hasField(l.TrailerArquivo, "QuantidadeRegistros") && f.Trailer.QuantidadeRegistros != n {
+				return File240{}, countError(n, l.TrailerArquivo, "QuantidadeRegistros")
+			}
+			trailer = true
+		default:
+			return File240{}, recordError(n, 8)
+		}
+	}
+
+	if !trailer {
+		return File240{}, recordError(len(lns), 8)
+	}
+
+	return f, nil
+}
+
+// Write240 writes a CNAB 240 file with the given layout, with CRLF line endings.
+//
+// The amounts of records of the trailers are calculated from the file. The returned error is a
+// *FieldError reporting the line and the column of the field that can not be written, or an error of w.
+func Write240(w io.Writer, l Layout240, f File240) error {
+	bw := bufio.NewWriter(w)
+	line := make([]byte, width240, width240+2)
+	n := 0
+
+	write := func(fields []Field, v any, r record) error {
+		n++
+		r.line = n
+		if err := encode(line, fields, reflect.ValueOf(v), r); err != nil {
+			return err
+		}
+		_, err := bw.Write(append(line, '\r', '\n'))
+		return err
+	}
+
+	if err := write(l.HeaderArquivo, f.Header, record{}); err != nil {
+		return err
+	}
+
+	for i, lote := range f.Lotes {
+		r := record{lote: i + 1}
+
+		if err := write(l.HeaderLote, lote.Header, r); err != nil {
+			return err
+		}
+
+		for j, s := range lote.Segmentos {
+			if s == nil {
+				return recordError(n+1, 14)
+			}
+
+			fields, _ := l.segmento(s.Codigo())
+			if fields == nil {
+				return recordError(n+1, 14)
+			}
+
+			r.sequencial = j + 1
+			if err := write(fields, reflect.Indirect(reflect.ValueOf(s)).Interface(), r); err != nil {
+				return err
+			}
+		}
+
+		lote.Trailer.QuantidadeRegistros = len(lote.Segmentos) + 2
+		r.sequencial = 0
+		if err := write(l.TrailerLote, lote.Trailer, r); err != nil {
+			return err
+		}
+	}
+
+	f.Trailer.QuantidadeLotes = len(f.Lotes)
+	f.Trailer.QuantidadeRegistros = n + 1
+	if err := write(l.TrailerArquivo, f.Trailer, record{}); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// FEBRABAN240 returns the layout of the CNAB 240 files of cobrança defined by FEBRABAN, for the bank
+// with the given 3-digit COMPE code.
+//
+// Most banks follow it, with bank specific contents in fields such as Convenio, NossoNumero and
+// the reserved fields. Banks that deviate from it can have their layout adapted from this one.
+func FEBRABAN240(banco string) Layout240 {
+	controle := func(registro string) []Field {
+		return []Field{
+			{Pos: 1, Len: 3, Kind: KindConstant, Value: banco},
+			{Pos: 4, Len: 4, Kind: KindLote},
+			{Pos: 8, Len: 1, Kind: KindConstant, Value: registro},
+		}
+	}
+
+	segmento := func(codigo string, fields ...Field) []Field {
+		return append(
+			append(controle("3"),
+				Field{Pos: 9, Len: 5, Kind: KindSequencial},
+				Field{Pos: 14, Len: 1, Kind: KindConstant, Value: codigo},
+				Field{Name: "Movimento", Pos: 16, Len: 2, Kind: KindNumeric},
+			),
+			fields...,
+		)
+	}
+
+	contaCorrente := func(pos int) []Field {
+		return []Field{
+			{Name: "Agencia", Pos: pos, Len: 5, Kind: KindNumeric},
+			{Name: "AgenciaDV", Pos: pos + 5, Len: 1, Kind: KindAlpha},
+			{Name: "Conta", Pos: pos + 6, Len: 12, Kind: KindNumeric},
+			{Name: "ContaDV", Pos: pos + 18, Len: 1, Kind: KindAlpha},
+			{Name: "AgenciaContaDV", Pos: pos + 19, Len: 1, Kind: KindAlpha},
+		}
+	}
+
+	return Layout240{
+		HeaderArquivo: append(append([]Field{
+			{Pos: 1, Len: 3, Kind: KindConstant, Value: banco},
+			{Pos: 4, Len: 4, Kind: KindConstant, Value: "0000"},
+			{Pos: 8, Len: 1, Kind: KindConstant, Value: "0"},
+			{Name: "Empresa", Pos: 18, Len: 1, Kind: KindTipoInscricao},
+			{Name: "Empresa", Pos: 19, Len: 14, Kind: KindInscricao},
+			{Name: "Convenio", Pos: 33, Len: 20, Kind: KindAlpha},
+		}, contaCorrente(53)...),
+			Field{Name: "NomeEmpresa", Pos: 73, Len: 30, Kind: KindAlpha},
+			Field{Name: "NomeBanco", Pos: 103, Len: 30, Kind: KindAlpha},
+			Field{Name: "RemessaRetorno", Pos: 143, Len: 1, Kind: KindNumeric},
+			Field{Name: "DataGeracao", Pos: 144, Len: 8, Kind: KindDate},
+			Field{Name: "DataGeracao", Pos: 152, Len: 6, Kind: KindTime},
+			Field{Name: "NumeroSequencial", Pos: 158, Len: 6, Kind: KindNumeric},
+			Field{Name: "VersaoLayout", Pos: 164, Len: 3, Kind: KindNumeric},
+			Field{Name: "Densidade", Pos: 167, Len: 5, Kind: KindNumeric},
+			Field{Name: "ReservadoBanco", Pos: 172, Len: 20, Kind: KindAlpha},
+			Field{Name: "ReservadoEmpresa", Pos: 192, Len: 20, Kind: KindAlpha},
+		),
+		HeaderLote: append(append(append(controle("1"),
+			Field{Name: "Operacao", Pos: 9, Len: 1, Kind: KindAlpha},
+			Field{Name: "Servico", Pos: 10, Len: 2, Kind: KindNumeric},
+			Field{Name: "VersaoLayout", Pos: 14, Len: 3, Kind: KindNumeric},
+			Field{Name: "Empresa", Pos: 18, Len: 1, Kind: KindTipoInscricao},
+			Field{Name: "Empresa", Pos: 19, Len: 15, Kind: KindInscricao},
+			Field{Name: "Convenio", Pos: 34, Len: 20, Kind: KindAlpha},
+		), contaCorrente(54)...),
+			Field{Name: "NomeEmpresa", Pos: 74, Len: 30, Kind: KindAlpha},
+			Field{Name: "Mensagem1", Pos: 104, Len: 40, Kind: KindAlpha},
+			Field{Name: "Mensagem2", Pos: 144, Len: 40, Kind: KindAlpha},
+			Field{Name: "NumeroRemessaRetorno", Pos: 184, Len: 8, Kind: KindNumeric},
+			Field{Name: "DataGravacao", Pos: 192, Len: 8, Kind: KindDate},
+			Field{Name: "DataCredito", Pos: 200, Len: 8, Kind: KindDate},
+		),
+		SegmentoP: append(append(segmento("P"), contaCorrente(18)...),
+			Field{Name: "NossoNumero", Pos: 38, Len: 20, Kind: KindAlpha},
+			Field{Name: "Carteira", Pos: 58, Len: 1, Kind: KindNumeric},
+			Field{Name: "FormaCadastramento", Pos: 59, Len: 1, Kind: KindNumeric},
+			Field{Name: "TipoDocumento", Pos: 60, Len: 1, Kind: KindAlpha},
+			Field{Name: "EmissaoBoleto", Pos: 61, Len: 1, Kind: KindNumeric},
+			Field{Name: "DistribuicaoBoleto", Pos: 62, Len: 1, Kind: KindAlpha},
+			Field{Name: "NumeroDocumento", Pos: 63, Len: 15, Kind: KindAlpha},
+			Field{Name: "Vencimento", Pos: 78, Len: 8, Kind: KindDate},
+			Field{Name: "Valor", Pos: 86, Len: 15, Kind: KindNumeric},
+			Field{Name: "AgenciaCobradora", Pos: 101, Len: 5, Kind: KindNumeric},
+			Field{Name: "AgenciaCobradoraDV", Pos: 106, Len: 1, Kind: KindAlpha},
+			Field{Name: "Especie", Pos: 107, Len: 2, Kind: KindNumeric},
+			Field{Name: "Aceite", Pos: 109, Len: 1, Kind: KindAlpha},
+			Field{Name: "Emissao", Pos: 110, Len: 8, Kind: KindDate},
+			Field{Name: "CodigoJuros", Pos: 118, Len: 1, Kind: KindNumeric},
+			Field{Name: "DataJuros", Pos: 119, Len: 8, Kind: KindDate},
+			Field{Name: "Juros", Pos: 127, Len: 15, Kind: KindNumeric},
+			Field{Name: "CodigoDesconto", Pos: 142, Len: 1, Kind: KindNumeric},
+			Field{Name: "DataDesconto", Pos: 143, Len: 8, Kind: KindDate},
+			Field{Name: "Desconto", Pos: 151, Len: 15, Kind: KindNumeric},
+			Field{Name: "IOF", Pos: 166, Len: 15, Kind: KindNumeric},
+			Field{Name: "Abatimento", Pos: 181, Len: 15, Kind: KindNumeric},
+			Field{Name: "UsoEmpresa", Pos: 196, Len: 25, Kind: KindAlpha},
+			Field{Name: "CodigoProtesto", Pos: 221, Len: 1, Kind: KindNumeric},
+			Field{Name: "PrazoProtesto", Pos: 222, Len: 2, Kind: KindNumeric},
+			Field{Name: "CodigoBaixa", Pos: 224, Len: 1, Kind: KindNumeric},
+			Field{Name: "PrazoBaixa", Pos: 225, Len: 3, Kind: KindNumeric},
+			Field{Name: "Moeda", Pos: 228, Len: 2, Kind: KindNumeric},
+			Field{Name: "Contrato", Pos: 230, Len: 10, Kind: KindNumeric},
+		),
+		SegmentoQ: segmento("Q",
+			Field{Name: "Pagador", Pos: 18, Len: 1, Kind: KindTipoInscricao},
+			Field{Name: "Pagador", Pos: 19, Len: 15, Kind: KindInscricao},
+			Field{Name: "PagadorNome", Pos: 34, Len: 40, Kind: KindAlpha},
+			Field{Name: "PagadorEndereco", Pos: 74, Len: 40, Kind: KindAlpha},
+			Field{Name: "PagadorBairro", Pos: 114, Len: 15, Kind: KindAlpha},
+			Field{Name: "PagadorCEP", Pos: 129, Len: 8, Kind: KindNumeric},
+			Field{Name: "PagadorCidade", Pos: 137, Len: 15, Kind: KindAlpha},
+			Field{Name: "PagadorUF", Pos: 152, Len: 2, Kind: KindAlpha},
+			Field{Name: "Avalista", Pos: 154, Len: 1, Kind: KindTipoInscricao},
+			Field{Name: "Avalista", Pos: 155, Len: 15, Kind: KindInscricao},
+			Field{Name: "AvalistaNome", Pos: 170, Len: 40, Kind: KindAlpha},
+			Field{Name: "BancoCorrespondente", Pos: 210, Len: 3, Kind: KindNumeric},
+			Field{Name: "NossoNumeroCorrespondente", Pos: 213, Len: 20, Kind: KindAlpha},
+		),
+		SegmentoR: segmento("R",
+			Field{Name: "CodigoDesconto2", Pos: 18, Len: 1, Kind: KindNumeric},
+			Field{Name: "DataDesconto2", Pos: 19, Len: 8, Kind: KindDate},
+			Field{Name: "Desconto2", Pos: 27, Len: 15, Kind: KindNumeric},
+			Field{Name: "CodigoDesconto3", Pos: 42, Len: 1, Kind: KindNumeric},
+			Field{Name: "DataDesconto3", Pos: 43, Len: 8, Kind: KindDate},
+			Field{Name: "Desconto3", Pos: 51, Len: 15, Kind: KindNumeric},
+			Field{Name: "CodigoMulta", Pos: 66, Len: 1, Kind: KindAlpha},
+			Field{Name: "DataMulta", Pos: 67, Len: 8, Kind: KindDate},
+			Field{Name: "Multa", Pos: 75, Len: 15, Kind: KindNumeric},
+			Field{Name: "InformacaoPagador", Pos: 90, Len: 10, Kind: KindAlpha},
+			Field{Name: "Mensagem3", Pos: 100, Len: 40, Kind: KindAlpha},
+			Field{Name: "Mensagem4", Pos: 140, Len: 40, Kind: KindAlpha},
+		),
+		SegmentoT: append(append(segmento("T"), contaCorrente(18)...),
+			Field{Name: "NossoNumero", Pos: 38, Len: 20, Kind: KindAlpha},
+			Field{Name: "Carteira", Pos: 58, Len: 1, Kind: KindNumeric},
+			Field{Name: "NumeroDocumento", Pos: 59, Len: 15, Kind: KindAlpha},
+			Field{Name: "Vencimento", Pos: 74, Len: 8, Kind: KindDate},
+			Field{Name: "Valor", Pos: 82, Len: 15, Kind: KindNumeric},
+			Field{Name: "BancoCobrador", Pos: 97, Len: 3, Kind: KindNumeric},
+			Field{Name: "AgenciaCobradora", Pos: 100, Len: 5, Kind: KindNumeric},
+			Field{Name: "AgenciaCobradoraDV", Pos: 105, Len: 1, Kind: KindAlpha},
+			Field{Name: "UsoEmpresa", Pos: 106, Len: 25, Kind: KindAlpha},
+			Field{Name: "Moeda", Pos: 131, Len: 2, Kind: KindNumeric},
+			Field{Name: "Pagador", Pos: 133, Len: 1, Kind: KindTipoInscricao},
+			Field{Name: "Pagador", Pos: 134, Len: 15, Kind: KindInscricao},
+			Field{Name: "PagadorNome", Pos: 149, Len: 40, Kind: KindAlpha},
+			Field{Name: "Contrato", Pos: 189, Len: 10, Kind: KindNumeric},
+			Field{Name: "Tarifa", Pos: 199, Len: 15, Kind: KindNumeric},
+			Field{Name: "Motivos", Pos: 214, Len: 10, Kind: KindAlpha},
+		),
+		SegmentoU: segmento("U",
+			Field{Name: "Encargos", Pos: 18, Len: 15, Kind: KindNumeric},
+			Field{Name: "Desconto", Pos: 33, Len: 15, Kind: KindNumeric},
+			Field{Name: "Abatimento", Pos: 48, Len: 15, Kind: KindNumeric},
+			Field{Name: "IOF", Pos: 63, Len: 15, Kind: KindNumeric},
+			Field{Name: "ValorPago", Pos: 78, Len: 15, Kind: KindNumeric},
+			Field{Name: "ValorLiquido", Pos: 93, Len: 15, Kind: KindNumeric},
+			Field{Name: "OutrasDespesas", Pos: 108, Len: 15, Kind: KindNumeric},
+			Field{Name: "OutrosCreditos", Pos: 123, Len: 15, Kind: KindNumeric},
+			Field{Name: "DataOcorrencia", Pos: 138, Len: 8, Kind: KindDate},
+			Field{Name: "DataCredito", Pos: 146, Len: 8, Kind: KindDate},
+			Field{Name: "OcorrenciaPagador", Pos: 154, Len: 4, Kind: KindAlpha},
+			Field{Name: "DataOcorrenciaPagador", Pos: 158, Len: 8, Kind: KindDate},
+			Field{Name: "ValorOcorrenciaPagador", Pos: 166, Len: 15, Kind: KindNumeric},
+			Field{Name: "ComplementoOcorrencia", Pos: 181, Len: 30, Kind: KindAlpha},
+			Field{Name: "BancoCorrespondente", Pos: 211, Len: 3, Kind: KindNumeric},
+			Field{Name: "NossoNumeroCorrespondente", Pos: 214, Len: 20, Kind: KindAlpha},
+		),
+		TrailerLote: append(controle("5"),
+			Field{Name: "QuantidadeRegistros", Pos: 18, Len: 6, Kind: KindNumeric},
+			Field{Name: "QuantidadeCobrancaSimples", Pos: 24, Len: 6, Kind: KindNumeric},
+			Field{Name: "ValorCobrancaSimples", Pos: 30, Len: 17, Kind: KindNumeric},
+			Field{Name: "NumeroAviso", Pos: 116, Len: 8, Kind: KindAlpha},
+		),
+		TrailerArquivo: []Field{
+			{Pos: 1, Len: 3, Kind: KindConstant, Value: banco},
+			{Pos: 4, Len: 4, Kind: KindConstant, Value: "9999"},
+			{Pos: 8, Len: 1, Kind: KindConstant, Value: "9"},
+			{Name: "QuantidadeLotes", Pos: 18, Len: 6, Kind: KindNumeric},
+			{Name: "QuantidadeRegistros", Pos: 24, Len: 6, Kind: KindNumeric},
+			{Name: "QuantidadeContas", Pos: 30, Len: 6, Kind: KindNumeric},
+		},
+	}
+}
+
+// override returns fields with the given fields in place of the ones that share any of their
+// columns, sorted by column. It is used to declare the layouts of the banks from FEBRABAN240.
+func override(fields []Field, with ...Field) []Field {
+	out := make([]Field, 0, len(fields)+len(with))
+	for _, f := range fields {
+		overlaps := false
+		for _, w := range with {
+			if f.Pos < w.Pos+w.Len && w.Pos < f.Pos+f.Len {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			out = append(out, f)
+		}
+	}
+
+	out = append(out, with...)
+	slices.SortStableFunc(out, func(a, b Field) int { return cmp.Compare(a.Pos, b.Pos) })
+	return out
+}
+
+// BancoDoBrasil240 returns the layout of the CNAB 240 files of cobrança of the Banco do Brasil (001).
+//
+// It is FEBRABAN240 with the Convenio of the headers split into the número do convênio, the
+// product 0014 (cobrança cedente), the Carteira and the VariacaoCarteira.
+func BancoDoBrasil240() Layout240 {
+	l := FEBRABAN240("001")
+	l.HeaderArquivo = override(l.HeaderArquivo,
+		Field{Name: "Convenio", Pos: 33, Len: 9, Kind: KindNumeric},
+		Field{Pos: 42, Len: 4, Kind: KindConstant, Value: "0014"},
+		Field{Name: "Carteira", Pos: 46, Len: 2, Kind: KindNumeric},
+		Field{Name: "VariacaoCarteira", Pos: 48, Len: 3, Kind: KindNumeric},
+		Field{Pos: 51, Len: 2, Kind: KindAlpha},
+	)
+	l.HeaderLote = override(l.HeaderLote,
+		Field{Name: "Convenio", Pos: 34, Len: 9, Kind: KindNumeric},
+		Field{Pos: 43, Len: 4, Kind: KindConstant, Value: "0014"},
+		Field{Name: "Carteira", Pos: 47, Len: 2, Kind: KindNumeric},
+		Field{Name: "VariacaoCarteira", Pos: 49, Len: 3, Kind: KindNumeric},
+		Field{Pos: 52, Len: 2, Kind: KindAlpha},
+	)
+	return l
+}
+
+// Caixa240 returns the layout of the CNAB 240 files of cobrança of the SIGCB of the Caixa Econômica
+// Federal (104).
+//
+// The Convenio of the headers is the código do beneficiário, which is also the Conta of the
+// segments P and T. The NossoNumero has 17 digits: the modalidade of the carteira followed by the
+// 15 digits of the título.
+func Caixa240() Layout240 {
+	l := FEBRABAN240("104")
+	l.HeaderArquivo = override(l.HeaderArquivo,
+		Field{Pos: 33, Len: 20, Kind: KindNumeric},
+		Field{Name: "Agencia", Pos: 53, Len: 5, Kind: KindNumeric},
+		Field{Name: "AgenciaDV", Pos: 58, Len: 1, Kind: KindAlpha},
+		Field{Name: "Convenio", Pos: 59, Len: 6, Kind: KindNumeric},
+		Field{Pos: 65, Len: 8, Kind: KindNumeric},
+	)
+	l.HeaderLote = override(l.HeaderLote,
+		Field{Name: "Convenio", Pos: 34, Len: 6, Kind: KindNumeric},
+		Field{Pos: 40, Len: 14, Kind: KindNumeric},
+		Field{Name: "Agencia", Pos: 54, Len: 5, Kind: KindNumeric},
+		Field{Name: "AgenciaDV", Pos: 59, Len: 1, Kind: KindAlpha},
+		Field{Name: "Convenio", Pos: 60, Len: 6, Kind: KindNumeric},
+		Field{Pos: 66, Len: 8, Kind: KindNumeric},
+	)
+	l.SegmentoP = override(l.SegmentoP,
+		Field{Name: "Conta", Pos: 24, Len: 6, Kind: KindNumeric},
+		Field{Pos: 30, Len: 11, Kind: KindNumeric},
+		Field{Name: "NossoNumero", Pos: 41, Len: 17, Kind: KindNumeric},
+		Field{Name: "NumeroDocumento", Pos: 63, Len: 11, Kind: KindAlpha},
+		Field{Pos: 74, Len: 4, Kind: KindAlpha},
+		Field{Pos: 230, Len: 10, Kind: KindNumeric},
+	)
+	l.SegmentoT = override(l.SegmentoT,
+		Field{Name: "Conta", Pos: 24, Len: 6, Kind: KindNumeric},
+		Field{Pos: 30, Len: 10, Kind: KindNumeric},
+		Field{Name: "NossoNumero", Pos: 40, Len: 17, Kind: KindNumeric},
+		Field{Pos: 57, Len: 1, Kind: KindAlpha},
+		Field{Name: "NumeroDocumento", Pos: 59, Len: 11, Kind: KindAlpha},
+		Field{Pos: 70, Len: 4, Kind: KindAlpha},
+		Field{Pos: 189, Len: 10, Kind: KindAlpha},
+	)
+	return l
+}
+
+// Santander240 returns the layout of the CNAB 240 files of cobrança of the Banco Santander (033).
+//
+// The Convenio of the headers is the código de transmissão. The segments P and T have the conta
+// corrente and the ContaCobranca, and a NossoNumero of 13 digits.
+func Santander240() Layout240 {
+	l := FEBRABAN240("033")
+	l.HeaderArquivo = override(l.HeaderArquivo,
+		Field{Pos: 9, Len: 8, Kind: KindAlpha},
+		Field{Name: "Empresa", Pos: 17, Len: 1, Kind: KindTipoInscricao},
+		Field{Name: "Empresa", Pos: 18, Len: 15, Kind: KindInscricao},
+		Field{Name: "Convenio", Pos: 33, Len: 15, Kind: KindAlpha},
+		Field{Pos: 48, Len: 25, Kind: KindAlpha},
+		Field{Name: "DataGeracao", Pos: 144, Len: 8, Kind: KindDate},
+		Field{Pos: 152, Len: 6, Kind: KindAlpha},
+		Field{Pos: 167, Len: 74, Kind: KindAlpha},
+	)
+	l.HeaderLote = override(l.HeaderLote,
+		Field{Pos: 34, Len: 20, Kind: KindAlpha},
+		Field{Name: "Convenio", Pos: 54, Len: 15, Kind: KindAlpha},
+		Field{Pos: 69, Len: 5, Kind: KindAlpha},
+		Field{Pos: 200, Len: 41, Kind: KindAlpha},
+	)
+	l.SegmentoP = override(l.SegmentoP,
+		Field{Name: "Agencia", Pos: 18, Len: 4, Kind: KindNumeric},
+		Field{Name: "AgenciaDV", Pos: 22, Len: 1, Kind: KindAlpha},
+		Field{Name: "Conta", Pos: 23, Len: 9, Kind: KindNumeric},
+		Field{Name: "ContaDV", Pos: 32, Len: 1, Kind: KindAlpha},
+		Field{Name: "ContaCobranca", Pos: 33, Len: 9, Kind: KindNumeric},
+		Field{Name: "ContaCobrancaDV", Pos: 42, Len: 1, Kind: KindAlpha},
+		Field{Pos: 43, Len: 2, Kind: KindAlpha},
+		Field{Name: "NossoNumero", Pos: 45, Len: 13, Kind: KindNumeric},
+		Field{Pos: 61, Len: 2, Kind: KindAlpha},
+		Field{Pos: 101, Len: 4, Kind: KindNumeric},
+		Field{Pos: 105, Len: 1, Kind: KindNumeric},
+		Field{Pos: 106, Len: 1, Kind: KindAlpha},
+		Field{Pos: 225, Len: 1, Kind: KindNumeric},
+		Field{Name: "PrazoBaixa", Pos: 226, Len: 2, Kind: KindNumeric},
+		Field{Pos: 230, Len: 11, Kind: KindAlpha},
+	)
+	l.SegmentoT = override(l.SegmentoT,
+		Field{Name: "Agencia", Pos: 18, Len: 4, Kind: KindNumeric},
+		Field{Name: "AgenciaDV", Pos: 22, Len: 1, Kind: KindAlpha},
+		Field{Name: "Conta", Pos: 23, Len: 9, Kind: KindNumeric},
+		Field{Name: "ContaDV", Pos: 32, Len: 1, Kind: KindAlpha},
+		Field{Pos: 33, Len: 8, Kind: KindAlpha},
+		Field{Name: "NossoNumero", Pos: 41, Len: 13, Kind: KindNumeric},
+		Field{Name: "Carteira", Pos: 54, Len: 1, Kind: KindNumeric},
+		Field{Name: "NumeroDocumento", Pos: 55, Len: 15, Kind: KindAlpha},
+		Field{Name: "Vencimento", Pos: 70, Len: 8, Kind: KindDate},
+		Field{Name: "Valor", Pos: 78, Len: 15, Kind: KindNumeric},
+		Field{Name: "BancoCobrador", Pos: 93, Len: 3, Kind: KindNumeric},
+		Field{Name: "AgenciaCobradora", Pos: 96, Len: 4, Kind: KindNumeric},
+		Field{Name: "AgenciaCobradoraDV", Pos: 100, Len: 1, Kind: KindAlpha},
+		Field{Name: "UsoEmpresa", Pos: 101, Len: 25, Kind: KindAlpha},
+		Field{Name: "Moeda", Pos: 126, Len: 2, Kind: KindNumeric},
+		Field{Name: "Pagador", Pos: 128, Len: 1, Kind: KindTipoInscricao},
+		Field{Name: "Pagador", Pos: 129, Len: 15, Kind: KindInscricao},
+		Field{Name: "PagadorNome", Pos: 144, Len: 40, Kind: KindAlpha},
+		Field{Name: "ContaCobranca", Pos: 184, Len: 10, Kind: KindNumeric},
+		Field{Name: "Tarifa", Pos: 194, Len: 15, Kind: KindNumeric},
+		Field{Name: "Motivos", Pos: 209, Len: 10, Kind: KindAlpha},
+		Field{Pos: 219, Len: 22, Kind: KindAlpha},
+	)
+	return l
+}
+
+// Itau240 returns the layout of the CNAB 240 files of cobrança of the Itaú Unibanco (341).
+//
+// The Itaú identifies the beneficiário by the Agencia of 4 digits and the Conta of 5 digits with its
+// DAC in the ContaDV, and has no Convenio. The NossoNumero has 9 digits, the last one being its DAC.
+func Itau240() Layout240 {
+	l := FEBRABAN240("341")
+
+	contaCorrente := func(pos int) []Field {
+		return []Field{
+			{Pos: pos, Len: 1, Kind: KindNumeric},
+			{Name: "Agencia", Pos: pos + 1, Len: 4, Kind: KindNumeric},
+			{Pos: pos + 5, Len: 1, Kind: KindAlpha},
+			{Pos: pos + 6, Len: 7, Kind: KindNumeric},
+			{Name: "Conta", Pos: pos + 13, Len: 5, Kind: KindNumeric},
+			{Pos: pos + 18, Len: 1, Kind: KindAlpha},
+			{Name: "ContaDV", Pos: pos + 19, Len: 1, Kind: KindAlpha},
+		}
+	}
+
+	l.HeaderArquivo = override(l.HeaderArquivo, append(contaCorrente(53),
+		Field{Pos: 33, Len: 20, Kind: KindAlpha},
+		Field{Pos: 172, Len: 69, Kind: KindAlpha},
+	)...)
+	l.HeaderLote = override(l.HeaderLote, append(contaCorrente(54),
+		Field{Pos: 34, Len: 20, Kind: KindAlpha},
+		Field{Pos: 104, Len: 80, Kind: KindAlpha},
+	)...)
+	l.SegmentoP = override(l.SegmentoP, append(contaCorrente(18),
+		Field{Name: "Carteira", Pos: 38, Len: 3, Kind: KindNumeric},
+		Field{Name: "NossoNumero", Pos: 41, Len: 9, Kind: KindNumeric},
+		Field{Pos: 50, Len: 8, Kind: KindAlpha},
+		Field{Pos: 58, Len: 5, Kind: KindNumeric},
+		Field{Name: "NumeroDocumento", Pos: 63, Len: 10, Kind: KindAlpha},
+		Field{Pos: 73, Len: 5, Kind: KindAlpha},
+		Field{Pos: 101, Len: 6, Kind: KindNumeric},
+		Field{Name: "PrazoBaixa", Pos: 225, Len: 2, Kind: KindNumeric},
+		Field{Pos: 227, Len: 13, Kind: KindNumeric},
+	)...)
+	l.SegmentoT = override(l.SegmentoT, append(contaCorrente(18),
+		Field{Name: "Carteira", Pos: 38, Len: 3, Kind: KindNumeric},
+		Field{Name: "NossoNumero", Pos: 41, Len: 9, Kind: KindNumeric},
+		Field{Pos: 50, Len: 9, Kind: KindAlpha},
+		Field{Name: "NumeroDocumento", Pos: 59, Len: 10, Kind: KindAlpha},
+		Field{Pos: 69, Len: 5, Kind: KindAlpha},
+		Field{Name: "PagadorNome", Pos: 149, Len: 30, Kind: KindAlpha},
+		Field{Pos: 179, Len: 20, Kind: KindAlpha},
+		Field{Name: "Motivos", Pos: 214, Len: 8, Kind: KindAlpha},
+	)...)
+	return l
+}
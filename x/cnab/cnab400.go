@@ -0,0 +1,480 @@
+package cnab
+
+import (
+	"bufio"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/phenpessoa/br/x/address"
+)
+
+const width400 = 400
+
+// Header400 is the header of a CNAB 400 file (registro 0).
+type Header400 struct {
+	Agencia       string
+	Conta         string
+	ContaDV       string
+	CodigoEmpresa string
+	NomeEmpresa   string
+	DataGravacao  time.Time
+
+	// NumeroSequencial is the sequence number of the remessa or of the retorno.
+	NumeroSequencial int
+
+	// DataCredito is the date the amounts of a retorno are credited to the account.
+	DataCredito time.Time
+}
+
+// Detalhe400 holds the data of a título of a CNAB 400 file (registro 1).
+//
+// The layout of each bank uses only some of its fields.
+type Detalhe400 struct {
+	Beneficiario  Inscricao
+	Agencia       string
+	Conta         string
+	ContaDV       string
+	Carteira      string
+	UsoEmpresa    string
+	NossoNumero   string
+	NossoNumeroDV string
+	CodigoMulta   string
+	Multa         int64
+	EmissaoBoleto int
+
+	// Ocorrencia is the code of the instruction of a remessa or of the occurrence of a retorno.
+	Ocorrencia int
+
+	NumeroDocumento string
+	Vencimento      time.Time
+
+	// Valor is the amount of the título, in centavos.
+	Valor int64
+
+	Especie         int
+	Aceite          string
+	Emissao         time.Time
+	Instrucao1      int
+	Instrucao2      int
+	JurosDia        int64
+	DataDesconto    time.Time
+	Desconto        int64
+	IOF             int64
+	Abatimento      int64
+	Pagador         Inscricao
+	PagadorNome     string
+	PagadorEndereco string
+	PagadorBairro   string
+	PagadorCEP      address.CEP
+	PagadorCidade   string
+	PagadorUF       address.UF
+	Mensagem1       string
+	AvalistaNome    string
+	DataMora        time.Time
+	PrazoProtesto   int
+
+	// The fields below are only in the retornos. In them, Desconto, IOF and Abatimento are the
+	// amounts granted or charged on the payment.
+
+	DataOcorrencia   time.Time
+	BancoCobrador    string
+	AgenciaCobradora string
+
+	// Tarifa is the fee charged by the bank for the occurrence, in centavos.
+	Tarifa int64
+
+	OutrasDespesas int64
+
+	// ValorPago is the amount paid by the pagador, in centavos.
+	ValorPago int64
+
+	JurosMora      int64
+	OutrosCreditos int64
+
+	// DataCredito is the date ValorPago is credited to the account.
+	DataCredito time.Time
+
+	// Motivos holds the codes explaining the occurrence, such as the reasons of a rejection.
+	Motivos string
+}
+
+// Trailer400 is the trailer of a CNAB 400 file (registro 9).
+//
+// The remessas have no totals. In the retornos, the totals are the ones of the títulos in
+// cobrança simples informed by the bank, not of the Detalhes of the file, so they are not checked.
+type Trailer400 struct {
+	QuantidadeTitulos int
+
+	// ValorTotal is the amount of the títulos, in centavos.
+	ValorTotal int64
+
+	NumeroAviso string
+}
+
+// File400 is a CNAB 400 file.
+type File400 struct {
+	Header   Header400
+	Detalhes []Detalhe400
+	Trailer  Trailer400
+}
+
+// Layout400 describes the records of the CNAB 400 files of a bank.
+//
+// The remessas and the retornos of a bank have different layouts.
+type Layout400 struct {
+	Header  []Field
+	Detalhe []Field
+	Trailer []Field
+}
+
+// Read400 reads a CNAB 400 file with the given layout.
+//
+// The returned error is a *FieldError reporting the line and the column of the invalid field,
+// or an error of r.
+func Read400(r io.Reader, l Layout400) (File400, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return File400{}, err
+	}
+
+	lns := lines(string(data))
+	if len(lns) < 2 {
+		return File400{}, &FieldError{Line: len(lns) + 1, Err: ErrRecord}
+	}
+
+	var f File400
+	for i, line := range lns {
+		n := i + 1
+		if err := checkLength(line, n, width400); err != nil {
+			return File400{}, err
+		}
+
+		rec := record{line: n, sequencial: n}
+
+		switch registro := line[0]; {
+		case n == 1:
+			if registro != '0' {
+				return File400{}, recordError(n, 1)
+			}
+			if err := decode(line, l.Header, reflect.ValueOf(&f.Header).Elem(), rec); err != nil {
+				return File400{}, err
+			}
+		case n == len(lns):
+			if registro != '9' {
+				return File400{}, recordError(n, 1)
+			}
+			if err := decode(line, l.Trailer, reflect.ValueOf(&f.Trailer).Elem(), rec); err != nil {
+				return File400{}, err
+			}
+		case registro == '1':
+			var d Detalhe400
+			if err := decode(line, l.Detalhe, reflect.ValueOf(&d).Elem(), rec); err != nil {
+				return File400{}, err
+			}
+			f.Detalhes = append(f.Detalhes, d)
+		default:
+			return File400{}, recordError(n, 1)
+		}
+	}
+
+	return f, nil
+}
+
+// Write400 writes a CNAB 400 file with the given layout, with CRLF line endings.
+//
+// The returned error is a *FieldError reporting the line and the column of the field that can not be
+// written, or an error of w.
+func Write400(w io.Writer, l Layout400, f File400) error {
+	bw := bufio.NewWriter(w)
+	line := make([]byte, width400, width400+2)
+	n := 0
+
+	write := func(fields []Field, v any) error {
+		n++
+		if err := encode(line, fields, reflect.ValueOf(v), record{line: n, sequencial: n}); err != nil {
+			return err
+		}
+		_, err := bw.Write(append(line, '\r', '\n'))
+		return err
+	}
+
+	if err := write(l.Header, f.Header); err != nil {
+		return err
+	}
+
+	for _, d := range f.Detalhes {
+		if err := write(l.Detalhe, d); err != nil {
+			return err
+		}
+	}
+
+	if err := write(l.Trailer, f.Trailer); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// trailer400 is the trailer of the remessas of CNAB 400 with no totals.
+func trailer400() []Field {
+	return []Field{
+		{Pos: 1, Len: 1, Kind: KindConstant, Value: "9"},
+		{Pos: 395, Len: 6, Kind: KindSequencial},
+	}
+}
+
+// Bradesco400 returns the layout of the remessas of cobrança in CNAB 400 of the Banco Bradesco (237).
+func Bradesco400() Layout400 {
+	return Layout400{
+		Header: []Field{
+			{Pos: 1, Len: 1, Kind: KindConstant, Value: "0"},
+			{Pos: 2, Len: 1, Kind: KindConstant, Value: "1"},
+			{Pos: 3, Len: 7, Kind: KindConstant, Value: "REMESSA"},
+			{Pos: 10, Len: 2, Kind: KindConstant, Value: "01"},
+			{Pos: 12, Len: 15, Kind: KindConstant, Value: "COBRANCA"},
+			{Name: "CodigoEmpresa", Pos: 27, Len: 20, Kind: KindNumeric},
+			{Name: "NomeEmpresa", Pos: 47, Len: 30, Kind: KindAlpha},
+			{Pos: 77, Len: 3, Kind: KindConstant, Value: "237"},
+			{Pos: 80, Len: 15, Kind: KindConstant, Value: "BRADESCO"},
+			{Name: "DataGravacao", Pos: 95, Len: 6, Kind: KindDate},
+			{Pos: 109, Len: 2, Kind: KindConstant, Value: "MX"},
+			{Name: "NumeroSequencial", Pos: 111, Len: 7, Kind: KindNumeric},
+			{Pos: 395, Len: 6, Kind: KindSequencial},
+		},
+		Detalhe: []Field{
+			{Pos: 1, Len: 1, Kind: KindConstant, Value: "1"},
+			{Pos: 2, Len: 19, Kind: KindNumeric},
+			{Pos: 21, Len: 1, Kind: KindNumeric},
+			{Name: "Carteira", Pos: 22, Len: 3, Kind: KindNumeric},
+			{Name: "Agencia", Pos: 25, Len: 5, Kind: KindNumeric},
+			{Name: "Conta", Pos: 30, Len: 7, Kind: KindNumeric},
+			{Name: "ContaDV", Pos: 37, Len: 1, Kind: KindAlpha},
+			{Name: "UsoEmpresa", Pos: 38, Len: 25, Kind: KindAlpha},
+			{Pos: 63, Len: 3, Kind: KindNumeric},
+			{Name: "CodigoMulta", Pos: 66, Len: 1, Kind: KindNumeric},
+			{Name: "Multa", Pos: 67, Len: 4, Kind: KindNumeric},
+			{Name: "NossoNumero", Pos: 71, Len: 11, Kind: KindNumeric},
+			{Name: "NossoNumeroDV", Pos: 82, Len: 1, Kind: KindAlpha},
+			{Pos: 83, Len: 10, Kind: KindNumeric},
+			{Name: "EmissaoBoleto", Pos: 93, Len: 1, Kind: KindNumeric},
+			{Pos: 94, Len: 1, Kind: KindConstant, Value: "N"},
+			{Name: "Ocorrencia", Pos: 109, Len: 2, Kind: KindNumeric},
+			{Name: "NumeroDocumento", Pos: 111, Len: 10, Kind: KindAlpha},
+			{Name: "Vencimento", Pos: 121, Len: 6, Kind: KindDate},
+			{Name: "Valor", Pos: 127, Len: 13, Kind: KindNumeric},
+			{Pos: 140, Len: 8, Kind: KindNumeric},
+			{Name: "Especie", Pos: 148, Len: 2, Kind: KindNumeric},
+			{Name: "Aceite", Pos: 150, Len: 1, Kind: KindAlpha},
+			{Name: "Emissao", Pos: 151, Len: 6, Kind: KindDate},
+			{Name: "Instrucao1", Pos: 157, Len: 2, Kind: KindNumeric},
+			{Name: "Instrucao2", Pos: 159, Len: 2, Kind: KindNumeric},
+			{Name: "JurosDia", Pos: 161, Len: 13, Kind: KindNumeric},
+			{Name: "DataDesconto", Pos: 174, Len: 6, Kind: KindDate},
+			{Name: "Desconto", Pos: 180, Len: 13, Kind: KindNumeric},
+			{Name: "IOF", Pos: 193, Len: 13, Kind: KindNumeric},
+			{Name: "Abatimento", Pos: 206, Len: 13, Kind: KindNumeric},
+			{Name: "Pagador", Pos: 219, Len: 2, Kind: KindTipoInscricao},
+			{Name: "Pagador", Pos: 221, Len: 14, Kind: KindInscricao},
+			{Name: "PagadorNome", Pos: 235, Len: 40, Kind: KindAlpha},
+			{Name: "PagadorEndereco", Pos: 275, Len: 40, Kind: KindAlpha},
+			{Name: "Mensagem1", Pos: 315, Len: 12, Kind: KindAlpha},
+			{Name: "PagadorCEP", Pos: 327, Len: 8, Kind: KindNumeric},
+			{Name: "AvalistaNome", Pos: 335, Len: 60, Kind: KindAlpha},
+			{Pos: 395, Len: 6, Kind: KindSequencial},
+		},
+		Trailer: trailer400(),
+	}
+}
+
+// Itau400 returns the layout of the remessas of cobrança in CNAB 400 of the Itaú Unibanco (341).
+func Itau400() Layout400 {
+	return Layout400{
+		Header: []Field{
+			{Pos: 1, Len: 1, Kind: KindConstant, Value: "0"},
+			{Pos: 2, Len: 1, Kind: KindConstant, Value: "1"},
+			{Pos: 3, Len: 7, Kind: KindConstant, Value: "REMESSA"},
+			{Pos: 10, Len: 2, Kind: KindConstant, Value: "01"},
+			{Pos: 12, Len: 15, Kind: KindConstant, Value: "COBRANCA"},
+			{Name: "Agencia", Pos: 27, Len: 4, Kind: KindNumeric},
+			{Pos: 31, Len: 2, Kind: KindNumeric},
+			{Name: "Conta", Pos: 33, Len: 5, Kind: KindNumeric},
+			{Name: "ContaDV", Pos: 38, Len: 1, Kind: KindAlpha},
+			{Name: "NomeEmpresa", Pos: 47, Len: 30, Kind: KindAlpha},
+			{Pos: 77, Len: 3, Kind: KindConstant, Value: "341"},
+			{Pos: 80, Len: 15, Kind: KindConstant, Value: "BANCO ITAU SA"},
+			{Name: "DataGravacao", Pos: 95, Len: 6, Kind: KindDate},
+			{Pos: 395, Len: 6, Kind: KindSequencial},
+		},
+		Detalhe: []Field{
+			{Pos: 1, Len: 1, Kind: KindConstant, Value: "1"},
+			{Name: "Beneficiario", Pos: 2, Len: 2, Kind: KindTipoInscricao},
+			{Name: "Beneficiario", Pos: 4, Len: 14, Kind: KindInscricao},
+			{Name: "Agencia", Pos: 18, Len: 4, Kind: KindNumeric},
+			{Pos: 22, Len: 2, Kind: KindNumeric},
+			{Name: "Conta", Pos: 24, Len: 5, Kind: KindNumeric},
+			{Name: "ContaDV", Pos: 29, Len: 1, Kind: KindAlpha},
+			{Pos: 34, Len: 4, Kind: KindNumeric},
+			{Name: "UsoEmpresa", Pos: 38, Len: 25, Kind: KindAlpha},
+			{Name: "NossoNumero", Pos: 63, Len: 8, Kind: KindNumeric},
+			{Pos: 71, Len: 13, Kind: KindNumeric},
+			{Name: "Carteira", Pos: 84, Len: 3, Kind: KindNumeric},
+			{Pos: 108, Len: 1, Kind: KindConstant, Value: "I"},
+			{Name: "Ocorrencia", Pos: 109, Len: 2, Kind: KindNumeric},
+			{Name: "NumeroDocumento", Pos: 111, Len: 10, Kind: KindAlpha},
+			{Name: "Vencimento", Pos: 121, Len: 6, Kind: KindDate},
+			{Name: "Valor", Pos: 127, Len: 13, Kind: KindNumeric},
+			{Pos: 140, Len: 3, Kind: KindConstant, Value: "341"},
+			{Pos: 143, Len: 5, Kind: KindNumeric},
+			{Name: "Especie", Pos: 148, Len: 2, Kind: KindNumeric},
+			{Name: "Aceite", Pos: 150, Len: 1, Kind: KindAlpha},
+			{Name: "Emissao", Pos: 151, Len: 6, Kind: KindDate},
+			{Name: "Instrucao1", Pos: 157, Len: 2, Kind: KindNumeric},
+			{Name: "Instrucao2", Pos: 159, Len: 2, Kind: KindNumeric},
+			{Name: "JurosDia", Pos: 161, Len: 13, Kind: KindNumeric},
+			{Name: "DataDesconto", Pos: 174, Len: 6, Kind: KindDate},
+			{Name: "Desconto", Pos: 180, Len: 13, Kind: KindNumeric},
+			{Name: "IOF", Pos: 193, Len: 13, Kind: KindNumeric},
+			{Name: "Abatimento", Pos: 206, Len: 13, Kind: KindNumeric},
+			{Name: "Pagador", Pos: 219, Len: 2, Kind: KindTipoInscricao},
+			{Name: "Pagador", Pos: 221, Len: 14, Kind: KindInscricao},
+			{Name: "PagadorNome", Pos: 235, Len: 30, Kind: KindAlpha},
+			{Name: "PagadorEndereco", Pos: 275, Len: 40, Kind: KindAlpha},
+			{Name: "PagadorBairro", Pos: 315, Len: 12, Kind: KindAlpha},
+			{Name: "PagadorCEP", Pos: 327, Len: 8, Kind: KindNumeric},
+			{Name: "PagadorCidade", Pos: 335, Len: 15, Kind: KindAlpha},
+			{Name: "PagadorUF", Pos: 350, Len: 2, Kind: KindAlpha},
+			{Name: "AvalistaNome", Pos: 352, Len: 30, Kind: KindAlpha},
+			{Name: "DataMora", Pos: 386, Len: 6, Kind: KindDate},
+			{Name: "PrazoProtesto", Pos: 392, Len: 2, Kind: KindNumeric},
+			{Pos: 395, Len: 6, Kind: KindSequencial},
+		},
+		Trailer: trailer400(),
+	}
+}
+
+// trailerRetorno400 is the trailer of the retornos of CNAB 400, with the totals of the cobrança simples.
+func trailerRetorno400(banco string) []Field {
+	return []Field{
+		{Pos: 1, Len: 1, Kind: KindConstant, Value: "9"},
+		{Pos: 2, Len: 1, Kind: KindConstant, Value: "2"},
+		{Pos: 3, Len: 2, Kind: KindConstant, Value: "01"},
+		{Pos: 5, Len: 3, Kind: KindConstant, Value: banco},
+		{Name: "QuantidadeTitulos", Pos: 18, Len: 8, Kind: KindNumeric},
+		{Name: "ValorTotal", Pos: 26, Len: 14, Kind: KindNumeric},
+		{Name: "NumeroAviso", Pos: 40, Len: 8, Kind: KindAlpha},
+		{Pos: 395, Len: 6, Kind: KindSequencial},
+	}
+}
+
+// Bradesco400Retorno returns the layout of the retornos of cobrança in CNAB 400 of the Banco Bradesco (237).
+func Bradesco400Retorno() Layout400 {
+	return Layout400{
+		Header: []Field{
+			{Pos: 1, Len: 1, Kind: KindConstant, Value: "0"},
+			{Pos: 2, Len: 1, Kind: KindConstant, Value: "2"},
+			{Pos: 3, Len: 7, Kind: KindConstant, Value: "RETORNO"},
+			{Pos: 10, Len: 2, Kind: KindConstant, Value: "01"},
+			{Pos: 12, Len: 15, Kind: KindConstant, Value: "COBRANCA"},
+			{Name: "CodigoEmpresa", Pos: 27, Len: 20, Kind: KindNumeric},
+			{Name: "NomeEmpresa", Pos: 47, Len: 30, Kind: KindAlpha},
+			{Pos: 77, Len: 3, Kind: KindConstant, Value: "237"},
+			{Pos: 80, Len: 15, Kind: KindConstant, Value: "BRADESCO"},
+			{Name: "DataGravacao", Pos: 95, Len: 6, Kind: KindDate},
+			{Pos: 101, Len: 8, Kind: KindNumeric},
+			{Name: "NumeroSequencial", Pos: 109, Len: 5, Kind: KindNumeric},
+			{Name: "DataCredito", Pos: 380, Len: 6, Kind: KindDate},
+			{Pos: 395, Len: 6, Kind: KindSequencial},
+		},
+		Detalhe: []Field{
+			{Pos: 1, Len: 1, Kind: KindConstant, Value: "1"},
+			{Name: "Beneficiario", Pos: 2, Len: 2, Kind: KindTipoInscricao},
+			{Name: "Beneficiario", Pos: 4, Len: 14, Kind: KindInscricao},
+			{Pos: 18, Len: 4, Kind: KindNumeric},
+			{Name: "Carteira", Pos: 22, Len: 3, Kind: KindNumeric},
+			{Name: "Agencia", Pos: 25, Len: 5, Kind: KindNumeric},
+			{Name: "Conta", Pos: 30, Len: 7, Kind: KindNumeric},
+			{Name: "ContaDV", Pos: 37, Len: 1, Kind: KindAlpha},
+			{Name: "UsoEmpresa", Pos: 38, Len: 25, Kind: KindAlpha},
+			{Name: "NossoNumero", Pos: 71, Len: 11, Kind: KindNumeric},
+			{Name: "NossoNumeroDV", Pos: 82, Len: 1, Kind: KindAlpha},
+			{Name: "Ocorrencia", Pos: 109, Len: 2, Kind: KindNumeric},
+			{Name: "DataOcorrencia", Pos: 111, Len: 6, Kind: KindDate},
+			{Name: "NumeroDocumento", Pos: 117, Len: 10, Kind: KindAlpha},
+			{Name: "Vencimento", Pos: 147, Len: 6, Kind: KindDate},
+			{Name: "Valor", Pos: 153, Len: 13, Kind: KindNumeric},
+			{Name: "BancoCobrador", Pos: 166, Len: 3, Kind: KindNumeric},
+			{Name: "AgenciaCobradora", Pos: 169, Len: 5, Kind: KindNumeric},
+			{Name: "Tarifa", Pos: 176, Len: 13, Kind: KindNumeric},
+			{Name: "OutrasDespesas", Pos: 189, Len: 13, Kind: KindNumeric},
+			{Name: "IOF", Pos: 215, Len: 13, Kind: KindNumeric},
+			{Name: "Abatimento", Pos: 228, Len: 13, Kind: KindNumeric},
+			{Name: "Desconto", Pos: 241, Len: 13, Kind: KindNumeric},
+			{Name: "ValorPago", Pos: 254, Len: 13, Kind: KindNumeric},
+			{Name: "JurosMora", Pos: 267, Len: 13, Kind: KindNumeric},
+			{Name: "OutrosCreditos", Pos: 280, Len: 13, Kind: KindNumeric},
+			{Name: "DataCredito", Pos: 296, Len: 6, Kind: KindDate},
+			{Name: "Motivos", Pos: 319, Len: 10, Kind: KindAlpha},
+			{Pos: 395, Len: 6, Kind: KindSequencial},
+		},
+		Trailer: trailerRetorno400("237"),
+	}
+}
+
+// Itau400Retorno returns the layout of the retornos of cobrança in CNAB 400 of the Itaú Unibanco (341).
+func Itau400Retorno() Layout400 {
+	return Layout400{
+		Header: []Field{
+			{Pos: 1, Len: 1, Kind: KindConstant, Value: "0"},
+			{Pos: 2, Len: 1, Kind: KindConstant, Value: "2"},
+			{Pos: 3, Len: 7, Kind: KindConstant, Value: "RETORNO"},
+			{Pos: 10, Len: 2, Kind: KindConstant, Value: "01"},
+			{Pos: 12, Len: 15, Kind: KindConstant, Value: "COBRANCA"},
+			{Name: "Agencia", Pos: 27, Len: 4, Kind: KindNumeric},
+			{Pos: 31, Len: 2, Kind: KindNumeric},
+			{Name: "Conta", Pos: 33, Len: 5, Kind: KindNumeric},
+			{Name: "ContaDV", Pos: 38, Len: 1, Kind: KindAlpha},
+			{Name: "NomeEmpresa", Pos: 47, Len: 30, Kind: KindAlpha},
+			{Pos: 77, Len: 3, Kind: KindConstant, Value: "341"},
+			{Pos: 80, Len: 15, Kind: KindAlpha},
+			{Name: "DataGravacao", Pos: 95, Len: 6, Kind: KindDate},
+			{Pos: 101, Len: 5, Kind: KindNumeric},
+			{Pos: 106, Len: 3, Kind: KindConstant, Value: "BPI"},
+			{Name: "NumeroSequencial", Pos: 109, Len: 5, Kind: KindNumeric},
+			{Name: "DataCredito", Pos: 114, Len: 6, Kind: KindDate},
+			{Pos: 395, Len: 6, Kind: KindSequencial},
+		},
+		Detalhe: []Field{
+			{Pos: 1, Len: 1, Kind: KindConstant, Value: "1"},
+			{Name: "Beneficiario", Pos: 2, Len: 2, Kind: KindTipoInscricao},
+			{Name: "Beneficiario", Pos: 4, Len: 14, Kind: KindInscricao},
+			{Name: "Agencia", Pos: 18, Len: 4, Kind: KindNumeric},
+			{Pos: 22, Len: 2, Kind: KindNumeric},
+			{Name: "Conta", Pos: 24, Len: 5, Kind: KindNumeric},
+			{Name: "ContaDV", Pos: 29, Len: 1, Kind: KindAlpha},
+			{Name: "UsoEmpresa", Pos: 38, Len: 25, Kind: KindAlpha},
+			{Name: "NossoNumero", Pos: 63, Len: 8, Kind: KindNumeric},
+			{Name: "Carteira", Pos: 83, Len: 3, Kind: KindNumeric},
+			{Name: "NossoNumeroDV", Pos: 94, Len: 1, Kind: KindAlpha},
+			{Name: "Ocorrencia", Pos: 109, Len: 2, Kind: KindNumeric},
+			{Name: "DataOcorrencia", Pos: 111, Len: 6, Kind: KindDate},
+			{Name: "NumeroDocumento", Pos: 117, Len: 10, Kind: KindAlpha},
+			{Name: "Vencimento", Pos: 147, Len: 6, Kind: KindDate},
+			{Name: "Valor", Pos: 153, Len: 13, Kind: KindNumeric},
+			{Name: "BancoCobrador", Pos: 166, Len: 3, Kind: KindNumeric},
+			{Name: "AgenciaCobradora", Pos: 169, Len: 4, Kind: KindNumeric},
+			{Name: "Tarifa", Pos: 176, Len: 13, Kind: KindNumeric},
+			{Name: "IOF", Pos: 215, Len: 13, Kind: KindNumeric},
+			{Name: "Abatimento", Pos: 228, Len: 13, Kind: KindNumeric},
+			{Name: "Desconto", Pos: 241, Len: 13, Kind: KindNumeric},
+			{Name: "ValorPago", Pos: 254, Len: 13, Kind: KindNumeric},
+			{Name: "JurosMora", Pos: 267, Len: 13, Kind: KindNumeric},
+			{Name: "OutrosCreditos", Pos: 280, Len: 13, Kind: KindNumeric},
+			{Name: "DataCredito", Pos: 296, Len: 6, Kind: KindDate},
+			{Name: "PagadorNome", Pos: 325, Len: 30, Kind: KindAlpha},
+			{Name: "Motivos", Pos: 378, Len: 8, Kind: KindAlpha},
+			{Pos: 395, Len: 6, Kind: KindSequencial},
+		},
+		Trailer: trailerRetorno400("341"),
+	}
+}
@@ -0,0 +1,912 @@
+package cnab
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/phenpessoa/br/x/address"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+var remessa240 = File240{
+	Header: HeaderArquivo{
+		Empresa:          Inscricao{CNPJ: "11222333000181"},
+		Convenio:         "123456",
+		Agencia:          "01234",
+		AgenciaDV:        "5",
+		Conta:            "000000012345",
+		ContaDV:          "6",
+		NomeEmpresa:      "EMPRESA EXEMPLO LTDA",
+		NomeBanco:        "BANCO DO BRASIL S.A.",
+		RemessaRetorno:   CodigoRemessa,
+		DataGeracao:      time.Date(2026, time.October, 18, 14, 30, 5, 0, time.UTC),
+		NumeroSequencial: 42,
+		VersaoLayout:     "103",
+	},
+	Lotes: []Lote{{
+		Header: HeaderLote{
+			Operacao:             "R",
+			Servico:              1,
+			VersaoLayout:         "060",
+			Empresa:              Inscricao{CNPJ: "11222333000181"},
+			Convenio:             "123456",
+			Agencia:              "01234",
+			AgenciaDV:            "5",
+			Conta:                "000000012345",
+			ContaDV:              "6",
+			NomeEmpresa:          "EMPRESA EXEMPLO LTDA",
+			NumeroRemessaRetorno: 42,
+			DataGravacao:         date(2026, time.October, 18),
+		},
+		Segmentos: []Segmento{
+			SegmentoP{
+				Movimento:       1,
+				Agencia:         "01234",
+				AgenciaDV:       "5",
+				Conta:           "000000012345",
+				ContaDV:         "6",
+				NossoNumero:     "12345670000000001",
+				Carteira:        7,
+				NumeroDocumento: "NF-1001",
+				Vencimento:      date(2026, time.November, 30),
+				Valor:           123456,
+				Especie:         2,
+				Aceite:          "N",
+				Emissao:         date(2026, time.October, 18),
+				CodigoJuros:     3,
+				Moeda:           9,
+			},
+			SegmentoQ{
+				Movimento:       1,
+				Pagador:         Inscricao{CPF: "52998224725"},
+				PagadorNome:     "FULANO DE TAL",
+				PagadorEndereco: "RUA DAS FLORES 100",
+				PagadorBairro:   "CENTRO",
+				PagadorCEP:      "01001-000",
+				PagadorCidade:   "SAO PAULO",
+				PagadorUF:       address.SP,
+			},
+			SegmentoR{
+				Movimento:   1,
+				CodigoMulta: "2",
+				DataMulta:   date(2026, time.December, 1),
+				Multa:       200,
+				Mensagem3:   "NAO RECEBER APOS 30 DIAS",
+			},
+		},
+	}},
+}
+
+var retorno240 = File240{
+	Header: HeaderArquivo{
+		Empresa:          Inscricao{CPF: "52998224725"},
+		RemessaRetorno:   CodigoRetorno,
+		DataGeracao:      time.Date(2026, time.December, 2, 6, 0, 0, 0, time.UTC),
+		NumeroSequencial: 7,
+	},
+	Lotes: []Lote{{
+		Header: HeaderLote{Operacao: "T", Servico: 1},
+		Segmentos: []Segmento{
+			SegmentoT{
+				Movimento:   6,
+				NossoNumero: "12345670000000001",
+				Vencimento:  date(2026, time.November, 30),
+				Valor:       123456,
+				Pagador:     Inscricao{CPF: "52998224725"},
+				PagadorNome: "FULANO DE TAL",
+				Tarifa:      250,
+				Motivos:     "00",
+			},
+			SegmentoU{
+				Movimento:      6,
+				Encargos:       200,
+				ValorPago:      123656,
+				ValorLiquido:   123406,
+				DataOcorrencia: date(2026, time.December, 1),
+				DataCredito:    date(2026, time.December, 2),
+			},
+		},
+		Trailer: TrailerLote{QuantidadeCobrancaSimples: 1, ValorCobrancaSimples: 123456},
+	}},
+}
+
+func BenchmarkRead240(b *testing.B) {
+	var buf bytes.Buffer
+	if err := Write240(&buf, FEBRABAN240("001"), remessa240); err != nil {
+		b.Fatalf("failed to write file: %v", err)
+	}
+
+	b.ReportAllocs()
+	for range b.N {
+		_, _ = Read240(bytes.NewReader(buf.Bytes()), FEBRABAN240("001"))
+	}
+}
+
+func TestWrite240(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write240(&buf, FEBRABAN240("001"), remessa240); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	s := buf.String()
+	if !strings.HasSuffix(s, "\r\n") {
+		t.Fatalf("expected CRLF line endings")
+	}
+
+	lns := strings.Split(strings.TrimSuffix(s, "\r\n"), "\r\n")
+	if len(lns) != 7 {
+		t.Fatalf("expected 7 lines, got %d", len(lns))
+	}
+
+	for _, tt := range []struct {
+		line int
+		pos  int
+		want string
+	}{
+		{1, 1, "00100000"},
+		{1, 18, "211222333000181"},
+		{1, 143, "118102026143005000042103"},
+		{2, 1, "00100011R01  060 2011222333000181"},
+		{3, 1, "0010001300001P 01"},
+		{3, 78, "30112026000000000123456"},
+		{4, 9, "00002Q 011000052998224725"},
+		{4, 129, "01001000SAO PAULO      SP0"},
+		{5, 66, "201122026000000000000200"},
+		{6, 1, "00100015         000005"},
+		{7, 1, "00199999         000001000007"},
+	} {
+		if got := lns[tt.line-1][tt.pos-1 : tt.pos-1+len(tt.want)]; got != tt.want {
+			t.Errorf("line %d, column %d:\nwant: %q\ngot: %q", tt.line, tt.pos, tt.want, got)
+		}
+	}
+
+	for i, l := range lns {
+		if len(l) != 240 {
+			t.Errorf("line %d: expected 240 columns, got %d", i+1, len(l))
+		}
+	}
+}
+
+func TestRead240(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		file File240
+	}{
+		{"remessa", remessa240},
+		{"retorno", retorno240},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write240(&buf, FEBRABAN240("341"), tt.file); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+			written := buf.String()
+
+			got, err := Read240(&buf, FEBRABAN240("341"))
+			if err != nil {
+				t.Fatalf("failed to read file: %v", err)
+			}
+
+			// The numeric strings are read with all their digits, so the files are compared instead.
+			var again bytes.Buffer
+			if err := Write240(&again, FEBRABAN240("341"), got); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+
+			if again.String() != written {
+				t.Errorf("\nwant: %q\ngot: %q", written, again.String())
+			}
+
+			if got.Header.Empresa != tt.file.Header.Empresa || !got.Header.DataGeracao.Equal(tt.file.Header.DataGeracao) {
+				t.Errorf("\nwant: %+v\ngot: %+v", tt.file.Header, got.Header)
+			}
+
+			if len(got.Lotes) != 1 || len(got.Lotes[0].Segmentos) != len(tt.file.Lotes[0].Segmentos) {
+				t.Fatalf("\nwant: %+v\ngot: %+v", tt.file.Lotes, got.Lotes)
+			}
+
+			if got.Trailer.QuantidadeLotes != 1 || got.Trailer.QuantidadeRegistros != len(tt.file.Lotes[0].Segmentos)+4 {
+				t.Errorf("unexpected trailer: %+v", got.Trailer)
+			}
+		})
+	}
+}
+
+func TestRead240_Segmentos(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write240(&buf, FEBRABAN240("001"), remessa240); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	f, err := Read240(&buf, FEBRABAN240("001"))
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+
+	segmentos := f.Lotes[0].Segmentos
+
+	p, ok := segmentos[0].(SegmentoP)
+	if !ok {
+		t.Fatalf("expected a SegmentoP, got %T", segmentos[0])
+	}
+	if p.Valor != 123456 || !p.Vencimento.Equal(date(2026, time.November, 30)) || p.NossoNumero != "12345670000000001" {
+		t.Errorf("unexpected segmento P: %+v", p)
+	}
+
+	q, ok := segmentos[1].(SegmentoQ)
+	if !ok {
+		t.Fatalf("expected a SegmentoQ, got %T", segmentos[1])
+	}
+	if q.Pagador != (Inscricao{CPF: "52998224725"}) || q.PagadorCEP != "01001000" || q.PagadorUF != address.SP || !q.Avalista.IsZero() {
+		t.Errorf("unexpected segmento Q: %+v", q)
+	}
+
+	r, ok := segmentos[2].(SegmentoR)
+	if !ok {
+		t.Fatalf("expected a SegmentoR, got %T", segmentos[2])
+	}
+	if r.Multa != 200 || r.CodigoMulta != "2" || r.Mensagem3 != "NAO RECEBER APOS 30 DIAS" {
+		t.Errorf("unexpected segmento R: %+v", r)
+	}
+}
+
+func TestRead400(t *testing.T) {
+	file := File400{
+		Header: Header400{
+			Agencia:      "0057",
+			Conta:        "12345",
+			ContaDV:      "7",
+			NomeEmpresa:  "EMPRESA EXEMPLO LTDA",
+			DataGravacao: date(2026, time.October, 18),
+		},
+		Detalhes: []Detalhe400{{
+			Beneficiario:    Inscricao{CNPJ: "11222333000181"},
+			Agencia:         "0057",
+			Conta:           "12345",
+			ContaDV:         "7",
+			Carteira:        "109",
+			UsoEmpresa:      "PEDIDO 1001",
+			NossoNumero:     "12345678",
+			Ocorrencia:      1,
+			NumeroDocumento: "NF-1001",
+			Vencimento:      date(2026, time.November, 30),
+			Valor:           123456,
+			Especie:         1,
+			Aceite:          "N",
+			Emissao:         date(2026, time.October, 18),
+			JurosDia:        41,
+			Pagador:         Inscricao{CPF: "52998224725"},
+			PagadorNome:     "FULANO DE TAL",
+			PagadorEndereco: "RUA DAS FLORES 100",
+			PagadorBairro:   "CENTRO",
+			PagadorCEP:      "01001000",
+			PagadorCidade:   "SAO PAULO",
+			PagadorUF:       address.SP,
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := Write400(&buf, Itau400(), file); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	lns := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	if len(lns) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lns))
+	}
+
+	for _, tt := range []struct {
+		line int
+		pos  int
+		want string
+	}{
+		{1, 1, "01REMESSA01COBRANCA       005700123457"},
+		{1, 77, "341BANCO ITAU SA  181026"},
+		{1, 395, "000001"},
+		{2, 1, "10211222333000181005700123457"},
+		{2, 63, "123456780000000000000109"},
+		{2, 108, "I01NF-1001   301126000000012345634100000"},
+		{2, 219, "0100052998224725"},
+		{2, 327, "01001000SAO PAULO      SP"},
+		{2, 395, "000002"},
+		{3, 1, "9"},
+		{3, 395, "000003"},
+	} {
+		if got := lns[tt.line-1][tt.pos-1 : tt.pos-1+len(tt.want)]; got != tt.want {
+			t.Errorf("line %d, column %d:\nwant: %q\ngot: %q", tt.line, tt.pos, tt.want, got)
+		}
+	}
+
+	got, err := Read400(&buf, Itau400())
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+
+	if !reflect.DeepEqual(file, got) {
+		t.Errorf("\nwant: %+v\ngot: %+v", file, got)
+	}
+}
+
+func TestRead400_Bradesco(t *testing.T) {
+	file := File400{
+		Header: Header400{
+			CodigoEmpresa:    "00000000000004567890",
+			NomeEmpresa:      "EMPRESA EXEMPLO LTDA",
+			DataGravacao:     date(2026, time.October, 18),
+			NumeroSequencial: 12,
+		},
+		Detalhes: []Detalhe400{{
+			Carteira:        "009",
+			Agencia:         "01234",
+			Conta:           "0012345",
+			ContaDV:         "6",
+			CodigoMulta:     "2",
+			Multa:           200,
+			NossoNumero:     "00000000123",
+			NossoNumeroDV:   "P",
+			EmissaoBoleto:   2,
+			Ocorrencia:      1,
+			NumeroDocumento: "NF-1001",
+			Vencimento:      date(2026, time.November, 30),
+			Valor:           123456,
+			Especie:         1,
+			Aceite:          "N",
+			Emissao:         date(2026, time.October, 18),
+			Pagador:         Inscricao{CNPJ: "11222333000181"},
+			PagadorNome:     "EMPRESA PAGADORA SA",
+			PagadorCEP:      "01001000",
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := Write400(&buf, Bradesco400(), file); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	got, err := Read400(&buf, Bradesco400())
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+
+	if !reflect.DeepEqual(file, got) {
+		t.Errorf("\nwant: %+v\ngot: %+v", file, got)
+	}
+}
+
+func TestRead400_Retorno(t *testing.T) {
+	detalhe := Detalhe400{
+		Beneficiario:     Inscricao{CNPJ: "11222333000181"},
+		Agencia:          "01234",
+		Conta:            "0012345",
+		ContaDV:          "6",
+		Carteira:         "009",
+		UsoEmpresa:       "PEDIDO 1001",
+		NossoNumero:      "00000000123",
+		NossoNumeroDV:    "P",
+		Ocorrencia:       6,
+		DataOcorrencia:   date(2026, time.November, 28),
+		NumeroDocumento:  "NF-1001",
+		Vencimento:       date(2026, time.November, 30),
+		Valor:            123456,
+		BancoCobrador:    "237",
+		AgenciaCobradora: "01234",
+		Tarifa:           250,
+		Desconto:         1000,
+		ValorPago:        122456,
+		JurosMora:        0,
+		DataCredito:      date(2026, time.November, 29),
+		Motivos:          "00",
+	}
+
+	itau := detalhe
+	itau.Agencia, itau.Conta, itau.ContaDV = "0057", "12345", "7"
+	itau.Carteira, itau.NossoNumero, itau.NossoNumeroDV = "109", "12345678", "3"
+	itau.BancoCobrador, itau.AgenciaCobradora = "341", "0057"
+	itau.OutrasDespesas = 0
+	itau.PagadorNome = "FULANO DE TAL"
+
+	for _, tt := range []struct {
+		name    string
+		layout  Layout400
+		file    File400
+		columns map[[2]int]string
+	}{
+		{
+			name:   "bradesco",
+			layout: Bradesco400Retorno(),
+			file: File400{
+				Header: Header400{
+					CodigoEmpresa:    "00000000000004567890",
+					NomeEmpresa:      "EMPRESA EXEMPLO LTDA",
+					DataGravacao:     date(2026, time.November, 29),
+					NumeroSequencial: 12,
+					DataCredito:      date(2026, time.November, 29),
+				},
+				Detalhes: []Detalhe400{detalhe},
+				Trailer:  Trailer400{QuantidadeTitulos: 15, ValorTotal: 9876543, NumeroAviso: "00000012"},
+			},
+			columns: map[[2]int]string{
+				{1, 1}:   "02RETORNO01COBRANCA",
+				{1, 380}: "291126",
+				{2, 1}:   "1021122233300018100000090123400123456PEDIDO 1001",
+				{2, 109}: "06281126NF-1001   ",
+				{2, 176}: "0000000000250",
+				{2, 254}: "0000000122456",
+				{2, 296}: "291126",
+				{3, 1}:   "9201237",
+				{3, 18}:  "000000150000000987654300000012",
+			},
+		},
+		{
+			name:   "itau",
+			layout: Itau400Retorno(),
+			file: File400{
+				Header: Header400{
+					Agencia:          "0057",
+					Conta:            "12345",
+					ContaDV:          "7",
+					NomeEmpresa:      "EMPRESA EXEMPLO LTDA",
+					DataGravacao:     date(2026, time.November, 29),
+					NumeroSequencial: 3,
+					DataCredito:      date(2026, time.November, 29),
+				},
+				Detalhes: []Detalhe400{itau},
+				Trailer:  Trailer400{QuantidadeTitulos: 1, ValorTotal: 123456, NumeroAviso: "00000001"},
+			},
+			columns: map[[2]int]string{
+				{1, 1}:   "02RETORNO01COBRANCA",
+				{1, 106}: "BPI00003291126",
+				{2, 1}:   "10211222333000181005700123457",
+				{2, 63}:  "12345678",
+				{2, 83}:  "109",
+				{2, 254}: "0000000122456",
+				{2, 325}: "FULANO DE TAL",
+				{3, 1}:   "9201341",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write400(&buf, tt.layout, tt.file); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+
+			lns := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+			for pos, want := range tt.columns {
+				if got := lns[pos[0]-1][pos[1]-1 : pos[1]-1+len(want)]; got != want {
+					t.Errorf("line %d, column %d:\nwant: %q\ngot: %q", pos[0], pos[1], want, got)
+				}
+			}
+
+			got, err := Read400(strings.NewReader(buf.String()), tt.layout)
+			if err != nil {
+				t.Fatalf("failed to read file: %v", err)
+			}
+
+			if !reflect.DeepEqual(tt.file, got) {
+				t.Errorf("\nwant: %+v\ngot: %+v", tt.file, got)
+			}
+
+			// Banks leave the amounts and the dates that do not apply to the occurrence blank.
+			blanked := replace(t, buf.String(), 2, 267, strings.Repeat(" ", 13))
+			blanked = replace(t, blanked, 2, 296, strings.Repeat(" ", 6))
+			got, err = Read400(strings.NewReader(blanked), tt.layout)
+			if err != nil {
+				t.Fatalf("failed to read file with blank fields: %v", err)
+			}
+
+			if d := got.Detalhes[0]; d.JurosMora != 0 || !d.DataCredito.IsZero() {
+				t.Errorf("unexpected blank fields: %+v", d)
+			}
+		})
+	}
+}
+
+func TestRead400_ItauRetornoHeader(t *testing.T) {
+	// The columns of the header and of the trailer of the retorno, as listed in the manual of the Itaú.
+	header := "0" + "2" + "RETORNO" + "01" + "COBRANCA       " + "0057" + "00" + "12345" + "7" +
+		strings.Repeat(" ", 8) + "EMPRESA EXEMPLO LTDA          " + "341" + "BANCO ITAU SA  " +
+		"281126" + "01600" + "BPI" + "00003" + "301126" + strings.Repeat(" ", 275) + "000001"
+	trailer := "9" + "2" + "01" + "341" + strings.Repeat(" ", 10) + "00000001" + "00000000123456" +
+		"00000001" + strings.Repeat(" ", 347) + "000002"
+
+	f, err := Read400(strings.NewReader(header+"\r\n"+trailer+"\r\n"), Itau400Retorno())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Header400{
+		Agencia:          "0057",
+		Conta:            "12345",
+		ContaDV:          "7",
+		NomeEmpresa:      "EMPRESA EXEMPLO LTDA",
+		DataGravacao:     date(2026, time.November, 28),
+		NumeroSequencial: 3,
+		DataCredito:      date(2026, time.November, 30),
+	}
+	if f.Header != want {
+		t.Errorf("\nwant: %+v\ngot: %+v", want, f.Header)
+	}
+
+	if want := (Trailer400{QuantidadeTitulos: 1, ValorTotal: 123456, NumeroAviso: "00000001"}); f.Trailer != want {
+		t.Errorf("\nwant: %+v\ngot: %+v", want, f.Trailer)
+	}
+}
+
+// replace returns the lines of the file with s written at the line and the column.
+func replace(t *testing.T, file string, line, col int, s string) string {
+	t.Helper()
+	lns := strings.Split(file, "\r\n")
+	l := lns[line-1]
+	lns[line-1] = l[:col-1] + s + l[col-1+len(s):]
+	return strings.Join(lns, "\r\n")
+}
+
+func TestRead240_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write240(&buf, FEBRABAN240("001"), remessa240); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	file := buf.String()
+
+	for _, tt := range []struct {
+		name   string
+		file   string
+		line   int
+		column int
+		err    error
+	}{
+		{"empty", "", 1, 0, ErrLength},
+		{"short line", strings.Replace(file, "FULANO DE TAL ", "FULANO DE TAL", 1), 4, 0, ErrLength},
+		{"banco", replace(t, file, 3, 1, "341"), 3, 1, ErrValue},
+		{"valor", replace(t, file, 3, 90, "1A"), 3, 86, ErrValue},
+		{"vencimento", replace(t, file, 3, 78, "31022026"), 3, 78, ErrValue},
+		{"cpf", replace(t, file, 4, 33, "6"), 4, 19, ErrValue},
+		{"tipo inscricao", replace(t, file, 4, 18, "3"), 4, 18, ErrValue},
+		{"uf", replace(t, file, 4, 152, "XX"), 4, 152, ErrValue},
+		{"lote", replace(t, file, 4, 4, "0002"), 4, 4, ErrCount},
+		{"sequencial", replace(t, file, 4, 9, "00003"), 4, 9, ErrCount},
+		{"unknown segment", replace(t, file, 5, 14, "Z"), 5, 14, ErrRecord},
+		{"segment outside lote", replace(t, file, 2, 8, "3"), 2, 8, ErrRecord},
+		{"quantidade registros lote", replace(t, file, 6, 18, "000004"), 6, 18, ErrCount},
+		{"quantidade lotes", replace(t, file, 7, 18, "000002"), 7, 18, ErrCount},
+		{"missing trailer", file[:strings.LastIndex(strings.TrimSuffix(file, "\r\n"), "\r\n")], 6, 8, ErrRecord},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read240(strings.NewReader(tt.file), FEBRABAN240("001"))
+			if !errors.Is(err, ErrInvalidFile) || !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			var fe *FieldError
+			if !errors.As(err, &fe) {
+				t.Fatalf("expected a *FieldError, got %T", err)
+			}
+
+			if fe.Line != tt.line || fe.Column != tt.column {
+				t.Errorf("expected line %d, column %d, got line %d, column %d", tt.line, tt.column, fe.Line, fe.Column)
+			}
+		})
+	}
+}
+
+func TestWrite_Errors(t *testing.T) {
+	withSegmento := func(s Segmento) File240 {
+		f := remessa240
+		f.Lotes = []Lote{{Header: f.Lotes[0].Header, Segmentos: []Segmento{s}}}
+		return f
+	}
+
+	for _, tt := range []struct {
+		name   string
+		layout Layout240
+		file   File240
+		line   int
+		column int
+		err    error
+	}{
+		{"too long", FEBRABAN240("001"), withSegmento(SegmentoQ{PagadorNome: strings.Repeat("A", 41)}), 3, 34, ErrValue},
+		{"non ascii", FEBRABAN240("001"), withSegmento(SegmentoQ{PagadorNome: "JOSÉ"}), 3, 34, ErrValue},
+		{"negative valor", FEBRABAN240("001"), withSegmento(SegmentoP{Valor: -1}), 3, 86, ErrValue},
+		{"invalid cpf", FEBRABAN240("001"), withSegmento(SegmentoQ{Pagador: Inscricao{CPF: "12345678900"}}), 3, 19, ErrValue},
+		{"both documents", FEBRABAN240("001"), withSegmento(SegmentoQ{Pagador: Inscricao{CPF: "52998224725", CNPJ: "11222333000181"}}), 3, 18, ErrValue},
+		{"invalid cep", FEBRABAN240("001"), withSegmento(SegmentoQ{PagadorCEP: "123"}), 3, 129, ErrValue},
+		{"unsupported segment", Layout240{HeaderArquivo: FEBRABAN240("001").HeaderArquivo, HeaderLote: FEBRABAN240("001").HeaderLote}, withSegmento(SegmentoT{}), 3, 14, ErrRecord},
+		{"unknown field", func() Layout240 {
+			l := FEBRABAN240("001")
+			l.SegmentoR = append(l.SegmentoR, Field{Name: "Foo", Pos: 200, Len: 1, Kind: KindAlpha})
+			return l
+		}(), withSegmento(SegmentoR{}), 3, 200, ErrLayout},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Write240(new(bytes.Buffer), tt.layout, tt.file)
+			if !errors.Is(err, ErrInvalidFile) || !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			var fe *FieldError
+			if !errors.As(err, &fe) {
+				t.Fatalf("expected a *FieldError, got %T", err)
+			}
+
+			if fe.Line != tt.line || fe.Column != tt.column {
+				t.Errorf("expected line %d, column %d, got line %d, column %d", tt.line, tt.column, fe.Line, fe.Column)
+			}
+		})
+	}
+}
+
+func TestFieldError(t *testing.T) {
+	err := &FieldError{Line: 3, Column: 86, Field: "Valor", Err: ErrValue}
+	if got, want := err.Error(), "br: invalid cnab field value: line 3, column 86 (Valor)"; got != want {
+		t.Errorf("\nwant: %s\ngot: %s", want, got)
+	}
+}
+
+func TestBankLayouts240(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		layout  Layout240
+		file    File240
+		columns map[[2]int]string
+		check   func(t *testing.T, f File240)
+	}{
+		{
+			name:   "banco do brasil",
+			layout: BancoDoBrasil240(),
+			file: File240{
+				Header: HeaderArquivo{
+					Empresa:          Inscricao{CNPJ: "11222333000181"},
+					Convenio:         "1234567",
+					Carteira:         17,
+					VariacaoCarteira: 19,
+					Agencia:          "01234",
+					AgenciaDV:        "5",
+					Conta:            "000000012345",
+					ContaDV:          "6",
+					RemessaRetorno:   CodigoRemessa,
+					DataGeracao:      date(2026, time.October, 18),
+				},
+				Lotes: []Lote{{
+					Header: HeaderLote{Operacao: "R", Servico: 1, Convenio: "1234567", Carteira: 17, VariacaoCarteira: 19},
+					Segmentos: []Segmento{
+						SegmentoP{Movimento: 1, Agencia: "01234", Conta: "000000012345", NossoNumero: "12345670000000001"},
+					},
+				}},
+			},
+			columns: map[[2]int]string{
+				{1, 1}:  "00100000",
+				{1, 33}: "001234567001417019  01234500000001234",
+				{2, 34}: "001234567001417019  00000",
+				{3, 18}: "01234 000000012345  12345670000000001   ",
+			},
+			check: func(t *testing.T, f File240) {
+				if h := f.Header; h.Convenio != "001234567" || h.Carteira != 17 || h.VariacaoCarteira != 19 {
+					t.Errorf("unexpected header: %+v", h)
+				}
+			},
+		},
+		{
+			name:   "caixa",
+			layout: Caixa240(),
+			file: File240{
+				Header: HeaderArquivo{
+					Empresa:        Inscricao{CNPJ: "11222333000181"},
+					Convenio:       "123456",
+					Agencia:        "01234",
+					AgenciaDV:      "5",
+					NomeBanco:      "CAIXA ECONOMICA FEDERAL",
+					RemessaRetorno: CodigoRemessa,
+					DataGeracao:    date(2026, time.October, 18),
+				},
+				Lotes: []Lote{{
+					Header: HeaderLote{Operacao: "R", Servico: 1, Convenio: "123456", Agencia: "01234", AgenciaDV: "5"},
+					Segmentos: []Segmento{
+						SegmentoP{
+							Movimento:       1,
+							Agencia:         "01234",
+							AgenciaDV:       "5",
+							Conta:           "123456",
+							NossoNumero:     "14000000000000001",
+							Carteira:        1,
+							NumeroDocumento: "NF-1001",
+							Vencimento:      date(2026, time.November, 30),
+							Valor:           123456,
+						},
+						SegmentoT{
+							Movimento:       6,
+							Conta:           "123456",
+							NossoNumero:     "14000000000000001",
+							NumeroDocumento: "NF-1001",
+							Vencimento:      date(2026, time.November, 30),
+						},
+					},
+				}},
+			},
+			columns: map[[2]int]string{
+				{1, 1}:  "10400000",
+				{1, 33}: "00000000000000000000012345123456" + "00000000",
+				{2, 34}: "123456" + "00000000000000" + "012345123456" + "00000000",
+				{3, 18}: "012345123456" + "00000000000" + "14000000000000001" + "1",
+				{3, 63}: "NF-1001        30112026000000000123456",
+				{4, 24}: "123456" + "0000000000" + "14000000000000001" + " " + "0" + "NF-1001        30112026",
+			},
+			check: func(t *testing.T, f File240) {
+				if h := f.Lotes[0].Header; h.Convenio != "123456" || h.Agencia != "01234" {
+					t.Errorf("unexpected header: %+v", h)
+				}
+				if p := f.Lotes[0].Segmentos[0].(SegmentoP); p.Conta != "123456" || p.NossoNumero != "14000000000000001" || p.NumeroDocumento != "NF-1001" {
+					t.Errorf("unexpected segmento P: %+v", p)
+				}
+			},
+		},
+		{
+			name:   "santander",
+			layout: Santander240(),
+			file: File240{
+				Header: HeaderArquivo{
+					Empresa:          Inscricao{CNPJ: "11222333000181"},
+					Convenio:         "123400001234567",
+					NomeEmpresa:      "EMPRESA EXEMPLO LTDA",
+					NomeBanco:        "BANCO SANTANDER",
+					RemessaRetorno:   CodigoRemessa,
+					DataGeracao:      date(2026, time.October, 18),
+					NumeroSequencial: 1,
+					VersaoLayout:     "040",
+				},
+				Lotes: []Lote{{
+					Header: HeaderLote{
+						Operacao:     "R",
+						Servico:      1,
+						VersaoLayout: "030",
+						Empresa:      Inscricao{CNPJ: "11222333000181"},
+						Convenio:     "123400001234567",
+						NomeEmpresa:  "EMPRESA EXEMPLO LTDA",
+					},
+					Segmentos: []Segmento{
+						SegmentoP{
+							Movimento:       1,
+							Agencia:         "1234",
+							AgenciaDV:       "5",
+							Conta:           "013000123",
+							ContaDV:         "4",
+							ContaCobranca:   "013000123",
+							ContaCobrancaDV: "4",
+							NossoNumero:     "0000000000019",
+							Carteira:        5,
+							NumeroDocumento: "NF-1001",
+							PrazoBaixa:      30,
+						},
+						SegmentoT{
+							Movimento:       6,
+							Agencia:         "1234",
+							AgenciaDV:       "5",
+							Conta:           "013000123",
+							ContaDV:         "4",
+							NossoNumero:     "0000000000019",
+							Carteira:        5,
+							NumeroDocumento: "NF-1001",
+							Valor:           123456,
+							Pagador:         Inscricao{CPF: "52998224725"},
+							PagadorNome:     "FULANO DE TAL",
+							ContaCobranca:   "0130001234",
+							Tarifa:          250,
+						},
+					},
+				}},
+			},
+			columns: map[[2]int]string{
+				{1, 1}:   "03300000        2011222333000181123400001234567" + strings.Repeat(" ", 25) + "EMPRESA EXEMPLO LTDA",
+				{1, 143}: "118102026      000001040" + strings.Repeat(" ", 74),
+				{2, 9}:   "R01  030 2011222333000181" + strings.Repeat(" ", 20) + "123400001234567     EMPRESA EXEMPLO LTDA",
+				{3, 18}:  "1234501300012340130001234  000000000001950 ",
+				{3, 224}: "0030" + "00" + strings.Repeat(" ", 11),
+				{4, 18}:  "12345013000123" + "4" + strings.Repeat(" ", 8) + "00000000000195NF-1001        ",
+				{4, 126}: "001000052998224725FULANO DE TAL",
+				{4, 184}: "0130001234000000000000250",
+			},
+			check: func(t *testing.T, f File240) {
+				if h := f.Header; h.Convenio != "123400001234567" || h.Empresa.CNPJ != "11222333000181" {
+					t.Errorf("unexpected header: %+v", h)
+				}
+				if tt := f.Lotes[0].Segmentos[1].(SegmentoT); tt.NossoNumero != "0000000000019" || tt.ContaCobranca != "0130001234" || tt.Tarifa != 250 {
+					t.Errorf("unexpected segmento T: %+v", tt)
+				}
+			},
+		},
+		{
+			name:   "itau",
+			layout: Itau240(),
+			file: File240{
+				Header: HeaderArquivo{
+					Empresa:        Inscricao{CNPJ: "11222333000181"},
+					Agencia:        "0057",
+					Conta:          "12345",
+					ContaDV:        "7",
+					RemessaRetorno: CodigoRemessa,
+					DataGeracao:    date(2026, time.October, 18),
+				},
+				Lotes: []Lote{{
+					Header: HeaderLote{Operacao: "R", Servico: 1, Agencia: "0057", Conta: "12345", ContaDV: "7"},
+					Segmentos: []Segmento{
+						SegmentoP{
+							Movimento:       1,
+							Agencia:         "0057",
+							Conta:           "12345",
+							ContaDV:         "7",
+							Carteira:        109,
+							NossoNumero:     "123456784",
+							NumeroDocumento: "NF-1001",
+							Vencimento:      date(2026, time.November, 30),
+							PrazoBaixa:      30,
+						},
+						SegmentoT{
+							Movimento:       6,
+							Agencia:         "0057",
+							Conta:           "12345",
+							ContaDV:         "7",
+							Carteira:        109,
+							NossoNumero:     "123456784",
+							NumeroDocumento: "NF-1001",
+							Vencimento:      date(2026, time.November, 30),
+							PagadorNome:     "FULANO DE TAL",
+							Motivos:         "AA",
+						},
+					},
+				}},
+			},
+			columns: map[[2]int]string{
+				{1, 33}:  strings.Repeat(" ", 20) + "00057 000000012345 7",
+				{2, 34}:  strings.Repeat(" ", 20) + "00057 000000012345 7",
+				{3, 18}:  "00057 000000012345 7109123456784" + strings.Repeat(" ", 8) + "00000NF-1001        30112026",
+				{3, 224}: "030" + strings.Repeat("0", 13) + " ",
+				{4, 18}:  "00057 000000012345 7109123456784" + strings.Repeat(" ", 9) + "NF-1001        30112026",
+				{4, 149}: "FULANO DE TAL" + strings.Repeat(" ", 37),
+				{4, 214}: "AA      ",
+			},
+			check: func(t *testing.T, f File240) {
+				if p := f.Lotes[0].Segmentos[0].(SegmentoP); p.Agencia != "0057" || p.Conta != "12345" || p.Carteira != 109 || p.NossoNumero != "123456784" {
+					t.Errorf("unexpected segmento P: %+v", p)
+				}
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write240(&buf, tt.layout, tt.file); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+			written := buf.String()
+
+			lns := strings.Split(strings.TrimSuffix(written, "\r\n"), "\r\n")
+			for pos, want := range tt.columns {
+				if got := lns[pos[0]-1][pos[1]-1 : pos[1]-1+len(want)]; got != want {
+					t.Errorf("line %d, column %d:\nwant: %q\ngot: %q", pos[0], pos[1], want, got)
+				}
+			}
+
+			got, err := Read240(&buf, tt.layout)
+			if err != nil {
+				t.Fatalf("failed to read file: %v", err)
+			}
+
+			var again bytes.Buffer
+			if err := Write240(&again, tt.layout, got); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+
+			if again.String() != written {
+				t.Errorf("\nwant: %q\ngot: %q", written, again.String())
+			}
+
+			tt.check(t, got)
+		})
+	}
+}
@@ -0,0 +1,266 @@
+// Package icpbrasil provides functions for reading the fields that the ICP-Brasil stores in the
+// certificates of people (e-CPF) and of companies (e-CNPJ).
+//
+// The fields are otherName entries of the subject alternative names of the certificate, identified
+// by the OIDs 2.16.76.1.3.x defined in the DOC-ICP-04. Each value is validated with the types of
+// the br package.
+package icpbrasil
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/phenpessoa/br"
+	"github.com/phenpessoa/br/x/address"
+)
+
+var (
+	// ErrNotICPBrasil is returned when the certificate has neither the fields of an e-CPF nor the
+	// ones of an e-CNPJ.
+	ErrNotICPBrasil = errors.New("br: certificate has no icp-brasil fields")
+
+	// ErrInvalidField is matched by every *FieldError.
+	ErrInvalidField = errors.New("br: invalid icp-brasil field")
+
+	// ErrFieldLength is returned when a field is shorter than its layout.
+	ErrFieldLength = errors.New("br: invalid icp-brasil field length")
+)
+
+// The OIDs of the otherName entries read by Parse.
+var (
+	// OIDTitular holds the birth date, the CPF, the PIS and the RG of the holder of an e-CPF.
+	OIDTitular = asn1.ObjectIdentifier{2, 16, 76, 1, 3, 1}
+
+	// OIDNomeResponsavel holds the name of the person responsible for an e-CNPJ.
+	OIDNomeResponsavel = asn1.ObjectIdentifier{2, 16, 76, 1, 3, 2}
+
+	// OIDCNPJ holds the CNPJ of the holder of an e-CNPJ.
+	OIDCNPJ = asn1.ObjectIdentifier{2, 16, 76, 1, 3, 3}
+
+	// OIDResponsavel holds the birth date, the CPF, the PIS and the RG of the person responsible
+	// for an e-CNPJ.
+	OIDResponsavel = asn1.ObjectIdentifier{2, 16, 76, 1, 3, 4}
+)
+
+var oidSubjectAltName = asn1.ObjectIdentifier{2, 5, 29, 17}
+
+// FieldError is the error returned when a field of the certificate is not valid.
+//
+// It matches ErrInvalidField and Err with errors.Is.
+type FieldError struct {
+	// OID is the OID of the otherName entry.
+	OID asn1.ObjectIdentifier
+
+	// Field is the name of the field inside the entry, such as "cpf" or "nascimento".
+	Field string
+
+	// Err is the reason the field is not valid, such as ErrFieldLength or br.ErrInvalidCPF.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s %s (%s): %s", ErrInvalidField, e.OID, e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() []error {
+	return []error{ErrInvalidField, e.Err}
+}
+
+// PessoaFisica holds the fields of a person. Fields filled with zeros in the certificate, meaning
+// they were not informed, are left empty.
+type PessoaFisica struct {
+	Nascimento time.Time
+	CPF        br.CPF
+	PIS        br.PIS
+	RG         br.RG
+
+	// OrgaoExpedidor is the issuer of the RG, such as "SSP".
+	OrgaoExpedidor string
+
+	// UF is the UF that issued the RG.
+	UF address.UF
+}
+
+// Fields holds the ICP-Brasil fields of a certificate.
+//
+// The Titular is set for an e-CPF. The CNPJ, the NomeResponsavel and the Responsavel are set for
+// an e-CNPJ.
+type Fields struct {
+	Titular         PessoaFisica
+	CNPJ            br.CNPJ
+	NomeResponsavel string
+	Responsavel     PessoaFisica
+}
+
+// IsECNPJ reports whether the certificate belongs to a company.
+func (f Fields) IsECNPJ() bool {
+	return f.CNPJ != ""
+}
+
+// Parse reads the ICP-Brasil fields of the certificate.
+//
+// Parse does not verify the certificate; use x509.Certificate.Verify with the roots of the
+// ICP-Brasil for that.
+//
+// The returned error is either ErrNotICPBrasil or a *FieldError.
+func Parse(cert *x509.Certificate) (Fields, error) {
+	values, err := otherNames(cert)
+	if err != nil {
+		return Fields{}, err
+	}
+
+	var (
+		f     Fields
+		found bool
+	)
+
+	if v, ok := values[OIDTitular.String()]; ok {
+		if f.Titular, err = parsePessoaFisica(OIDTitular, v); err != nil {
+			return Fields{}, err
+		}
+		found = true
+	}
+
+	if v, ok := values[OIDCNPJ.String()]; ok {
+		if !isZeros(v) {
+			if f.CNPJ = br.CNPJ(v); len(v) != 14 || !f.CNPJ.IsValid() {
+				return Fields{}, &FieldError{OID: OIDCNPJ, Field: "cnpj", Err: br.ErrInvalidCNPJ}
+			}
+		}
+		found = true
+	}
+
+	if v, ok := values[OIDResponsavel.String()]; ok {
+		if f.Responsavel, err = parsePessoaFisica(OIDResponsavel, v); err != nil {
+			return Fields{}, err
+		}
+	}
+
+	f.NomeResponsavel = strings.TrimSpace(values[OIDNomeResponsavel.String()])
+
+	if !found {
+		return Fields{}, ErrNotICPBrasil
+	}
+
+	return f, nil
+}
+
+// otherNames returns the values of the otherName entries of the subject alternative names of the
+// certificate, by OID.
+func otherNames(cert *x509.Certificate) (map[string]string, error) {
+	values := make(map[string]string)
+
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidSubjectAltName) {
+			continue
+		}
+
+		var names []asn1.RawValue
+		if _, err := asn1.Unmarshal(ext.Value, &names); err != nil {
+			return nil, ErrNotICPBrasil
+		}
+
+		for _, name := range names {
+			if name.Class != asn1.ClassContextSpecific || name.Tag != 0 {
+				continue
+			}
+
+			// The value is an OCTET STRING, or a PrintableString in older certificates, wrapped in
+			// an explicit [0] tag.
+			var (
+				other struct {
+					ID    asn1.ObjectIdentifier
+					Value asn1.RawValue
+				}
+				inner asn1.RawValue
+			)
+			if _, err := asn1.UnmarshalWithParams(name.FullBytes, &other, "tag:0"); err != nil {
+				continue
+			}
+			if _, err := asn1.Unmarshal(other.Value.Bytes, &inner); err != nil {
+				continue
+			}
+
+			values[other.ID.String()] = string(inner.Bytes)
+		}
+	}
+
+	return values, nil
+}
+
+// parsePessoaFisica reads the fields of a person: the birth date as DDMMAAAA, the CPF, the PIS, the
+// RG padded to 15 characters and the issuer of the RG followed by its UF, padded to 6 characters.
+// Only the birth date and the CPF are mandatory.
+func parsePessoaFisica(oid asn1.ObjectIdentifier, v string) (PessoaFisica, error) {
+	if len(v) < 19 {
+		return PessoaFisica{}, &FieldError{OID: oid, Field: "length", Err: ErrFieldLength}
+	}
+
+	var pf PessoaFisica
+
+	if nascimento := v[0:8]; !isZeros(nascimento) {
+		t, err := time.Parse("02012006", nascimento)
+		if err != nil {
+			return PessoaFisica{}, &FieldError{OID: oid, Field: "nascimento", Err: err}
+		}
+		pf.Nascimento = t
+	}
+
+	if cpf := v[8:19]; !isZeros(cpf) {
+		if pf.CPF = br.CPF(cpf); !pf.CPF.IsValid() {
+			return PessoaFisica{}, &FieldError{OID: oid, Field: "cpf", Err: br.ErrInvalidCPF}
+		}
+	}
+
+	if len(v) >= 30 {
+		if pis := v[19:30]; !isZeros(pis) {
+			if pf.PIS = br.PIS(pis); !pf.PIS.IsValid() {
+				return PessoaFisica{}, &FieldError{OID: oid, Field: "pis", Err: br.ErrInvalidPIS}
+			}
+		}
+	}
+
+	if len(v) < 45 {
+		return pf, nil
+	}
+
+	rg := strings.TrimSpace(v[30:45])
+	if isZeros(rg) {
+		return pf, nil
+	}
+
+	emissor := strings.ToUpper(strings.TrimSpace(v[45:min(len(v), 51)]))
+	if len(emissor) < 2 {
+		return PessoaFisica{}, &FieldError{OID: oid, Field: "uf", Err: address.ErrInvalidUF}
+	}
+
+	uf, err := address.NewUFFromStr(emissor[len(emissor)-2:])
+	if err != nil {
+		return PessoaFisica{}, &FieldError{OID: oid, Field: "uf", Err: err}
+	}
+
+	// The RG is padded with zeros to the left. The RG of SP has 9 characters, which may start with
+	// a zero.
+	rg = strings.TrimLeft(rg, "0")
+	if uf == address.SP && len(rg) < 9 {
+		rg = strings.Repeat("0", 9-len(rg)) + rg
+	}
+
+	pf.RG = br.RG(rg)
+	if _, err := pf.RG.Validate(uf); err != nil {
+		return PessoaFisica{}, &FieldError{OID: oid, Field: "rg", Err: err}
+	}
+
+	pf.OrgaoExpedidor = strings.TrimSpace(emissor[:len(emissor)-2])
+	pf.UF = uf
+
+	return pf, nil
+}
+
+func isZeros(s string) bool {
+	return strings.Trim(s, "0 ") == ""
+}
@@ -0,0 +1,240 @@
+package icpbrasil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/phenpessoa/br"
+	"github.com/phenpessoa/br/x/address"
+)
+
+type otherName struct {
+	oid   asn1.ObjectIdentifier
+	value string
+	tag   int
+}
+
+// newCertificate creates a self-signed certificate with the given otherName entries, plus an email,
+// as issued by the ICP-Brasil.
+func newCertificate(t testing.TB, names ...otherName) *x509.Certificate {
+	t.Helper()
+
+	san := []asn1.RawValue{{Class: asn1.ClassContextSpecific, Tag: 1, Bytes: []byte("contato@example.com")}}
+	for _, n := range names {
+		tag := n.tag
+		if tag == 0 {
+			tag = asn1.TagOctetString
+		}
+
+		inner, err := asn1.Marshal(asn1.RawValue{Tag: tag, Bytes: []byte(n.value)})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := asn1.MarshalWithParams(struct {
+			ID    asn1.ObjectIdentifier
+			Value asn1.RawValue
+		}{n.oid, asn1.RawValue{Class: asn1.ClassContextSpecific, IsCompound: true, Bytes: inner}}, "tag:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		san = append(san, asn1.RawValue{FullBytes: b})
+	}
+
+	ext, err := asn1.Marshal(san)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(1),
+		Subject:         pkix.Name{CommonName: "TESTE"},
+		NotBefore:       time.Now(),
+		NotAfter:        time.Now().Add(time.Hour),
+		ExtraExtensions: []pkix.Extension{{Id: oidSubjectAltName, Value: ext}},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert
+}
+
+func TestParse_ECPF(t *testing.T) {
+	cert := newCertificate(t,
+		otherName{oid: OIDTitular, value: "15031985" + "12345678909" + "12012345672" + "000000012345672" + "SSPSP "},
+		otherName{oid: asn1.ObjectIdentifier{2, 16, 76, 1, 3, 6}, value: "000000000000"},
+	)
+
+	f, err := Parse(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Fields{
+		Titular: PessoaFisica{
+			Nascimento:     time.Date(1985, time.March, 15, 0, 0, 0, 0, time.UTC),
+			CPF:            "12345678909",
+			PIS:            "12012345672",
+			RG:             "012345672",
+			OrgaoExpedidor: "SSP",
+			UF:             address.SP,
+		},
+	}
+	if f != want {
+		t.Errorf("\nwant: %+v\ngot: %+v", want, f)
+	}
+
+	if f.IsECNPJ() {
+		t.Error("e-CPF reported as e-CNPJ")
+	}
+}
+
+func TestParse_ECNPJ(t *testing.T) {
+	cert := newCertificate(t,
+		otherName{oid: OIDResponsavel, value: "01011980" + "12345678909" + "00000000000" + "000000000000000" + "000000"},
+		otherName{oid: OIDNomeResponsavel, value: "FULANO DE TAL", tag: asn1.TagPrintableString},
+		otherName{oid: OIDCNPJ, value: "11222333000181"},
+		otherName{oid: asn1.ObjectIdentifier{2, 16, 76, 1, 3, 7}, value: "000000000000"},
+	)
+
+	f, err := Parse(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Fields{
+		CNPJ:            "11222333000181",
+		NomeResponsavel: "FULANO DE TAL",
+		Responsavel: PessoaFisica{
+			Nascimento: time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC),
+			CPF:        "12345678909",
+		},
+	}
+	if f != want {
+		t.Errorf("\nwant: %+v\ngot: %+v", want, f)
+	}
+
+	if !f.IsECNPJ() {
+		t.Error("e-CNPJ not reported as e-CNPJ")
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		names []otherName
+		err   error
+		field string
+	}{
+		{
+			name: "no fields",
+			err:  ErrNotICPBrasil,
+		},
+		{
+			name:  "only responsavel",
+			names: []otherName{{oid: OIDResponsavel, value: "01011980" + "12345678909"}},
+			err:   ErrNotICPBrasil,
+		},
+		{
+			name:  "invalid cnpj",
+			names: []otherName{{oid: OIDCNPJ, value: "11222333000182"}},
+			err:   br.ErrInvalidCNPJ,
+			field: "cnpj",
+		},
+		{
+			name:  "invalid cpf",
+			names: []otherName{{oid: OIDTitular, value: "15031985" + "12345678900"}},
+			err:   br.ErrInvalidCPF,
+			field: "cpf",
+		},
+		{
+			name:  "invalid responsavel cpf",
+			names: []otherName{{oid: OIDCNPJ, value: "11222333000181"}, {oid: OIDResponsavel, value: "01011980" + "11111111112"}},
+			err:   br.ErrInvalidCPF,
+			field: "cpf",
+		},
+		{
+			name:  "invalid pis",
+			names: []otherName{{oid: OIDTitular, value: "15031985" + "12345678909" + "12012345673"}},
+			err:   br.ErrInvalidPIS,
+			field: "pis",
+		},
+		{
+			name:  "invalid rg",
+			names: []otherName{{oid: OIDTitular, value: "15031985" + "12345678909" + "00000000000" + "000000012345673" + "SSPSP "}},
+			err:   br.ErrRGCheckDigit,
+			field: "rg",
+		},
+		{
+			name:  "invalid uf",
+			names: []otherName{{oid: OIDTitular, value: "15031985" + "12345678909" + "00000000000" + "000000012345672" + "SSPXX "}},
+			err:   address.ErrInvalidUF,
+			field: "uf",
+		},
+		{
+			name:  "invalid nascimento",
+			names: []otherName{{oid: OIDTitular, value: "31021985" + "12345678909"}},
+			field: "nascimento",
+		},
+		{
+			name:  "short",
+			names: []otherName{{oid: OIDTitular, value: "15031985" + "1234"}},
+			err:   ErrFieldLength,
+			field: "length",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(newCertificate(t, tc.names...))
+			if tc.err != nil && !errors.Is(err, tc.err) {
+				t.Errorf("\nwant: %s\ngot: %v", tc.err, err)
+			}
+
+			if tc.field == "" {
+				return
+			}
+
+			var fe *FieldError
+			if !errors.As(err, &fe) || !errors.Is(err, ErrInvalidField) {
+				t.Fatalf("\nwant: *FieldError\ngot: %v", err)
+			}
+			if fe.Field != tc.field {
+				t.Errorf("\nwant: %s\ngot: %s", tc.field, fe.Field)
+			}
+		})
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	cert := newCertificate(b,
+		otherName{oid: OIDResponsavel, value: "01011980" + "12345678909" + "00000000000" + "000000000000000" + "000000"},
+		otherName{oid: OIDNomeResponsavel, value: "FULANO DE TAL"},
+		otherName{oid: OIDCNPJ, value: "11222333000181"},
+	)
+
+	b.ReportAllocs()
+	for range b.N {
+		_, _ = Parse(cert)
+	}
+}
@@ -0,0 +1,292 @@
+package nfe
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+)
+
+// xmlNode is an element of the tree read by parseTree. Its names and attributes are kept with their
+// prefixes, as written in the document, so that they can be canonicalized.
+type xmlNode struct {
+	parent   *xmlNode
+	name     xml.Name
+	attrs    []xml.Attr
+	children []any // *xmlNode, xml.CharData or xml.ProcInst
+}
+
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+var (
+	errMultipleRoots = errors.New("multiple root elements")
+	errUnexpectedEnd = errors.New("unexpected end element")
+)
+
+// parseTree reads the elements, the text and the processing instructions of the document.
+// Comments and directives are dropped, as they are not part of the canonical form.
+func parseTree(data []byte) (*xmlNode, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+
+	var root, cur *xmlNode
+	for {
+		tok, err := d.RawToken()
+		if err != nil {
+			if err == io.EOF && root != nil && cur == nil {
+				return root, nil
+			}
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{parent: cur, name: tok.Name, attrs: slices.Clone(tok.Attr)}
+			if cur == nil {
+				if root != nil {
+					return nil, errMultipleRoots
+				}
+				root = n
+			} else {
+				cur.children = append(cur.children, n)
+			}
+			cur = n
+		case xml.EndElement:
+			if cur == nil || cur.name != tok.Name {
+				return nil, errUnexpectedEnd
+			}
+			cur = cur.parent
+		case xml.CharData:
+			if cur != nil {
+				cur.children = append(cur.children, tok.Copy())
+			}
+		case xml.ProcInst:
+			if cur != nil {
+				cur.children = append(cur.children, tok.Copy())
+			}
+		}
+	}
+}
+
+// attr returns the value of the unprefixed attribute with the given name.
+func (n *xmlNode) attr(name string) (string, bool) {
+	for _, a := range n.attrs {
+		if a.Name.Space == "" && a.Name.Local == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// namespaces returns the namespaces in scope of the element, by prefix. The default namespace has
+// an empty prefix.
+func (n *xmlNode) namespaces() map[string]string {
+	var path []*xmlNode
+	for e := n; e != nil; e = e.parent {
+		path = append(path, e)
+	}
+
+	scope := make(map[string]string)
+	for i := len(path) - 1; i >= 0; i-- {
+		declare(scope, path[i])
+	}
+	return scope
+}
+
+// declare adds the namespaces declared by the element to scope.
+func declare(scope map[string]string, n *xmlNode) {
+	for _, a := range n.attrs {
+		switch {
+		case a.Name.Space == "xmlns":
+			scope[a.Name.Local] = a.Value
+		case a.Name.Space == "" && a.Name.Local == "xmlns":
+			scope[""] = a.Value
+		}
+	}
+}
+
+// namespace returns the namespace of the element.
+func (n *xmlNode) namespace() string {
+	return n.namespaces()[n.name.Space]
+}
+
+// find returns the first child element with the given local name.
+func (n *xmlNode) find(local string) *xmlNode {
+	for _, c := range n.children {
+		if e, ok := c.(*xmlNode); ok && e.name.Local == local {
+			return e
+		}
+	}
+	return nil
+}
+
+// text returns the text of the element, without its child elements.
+func (n *xmlNode) text() string {
+	var sb strings.Builder
+	for _, c := range n.children {
+		if t, ok := c.(xml.CharData); ok {
+			sb.Write(t)
+		}
+	}
+	return sb.String()
+}
+
+// canonicalize writes the subtree of n in the Canonical XML 1.0 form, without comments, as a
+// document subset: the namespaces in scope are declared on n. The element omit and its subtree
+// are left out, as done by the enveloped signature transform.
+func canonicalize(n, omit *xmlNode) []byte {
+	var buf bytes.Buffer
+	c14nElement(&buf, n, nil, n.namespaces(), omit)
+	return buf.Bytes()
+}
+
+func c14nElement(buf *bytes.Buffer, n *xmlNode, rendered, scope map[string]string, omit *xmlNode) {
+	buf.WriteByte('<')
+	writeName(buf, n.name)
+
+	prefixes := make([]string, 0, len(scope))
+	for prefix := range scope {
+		prefixes = append(prefixes, prefix)
+	}
+	slices.Sort(prefixes)
+
+	for _, prefix := range prefixes {
+		uri := scope[prefix]
+		if prev, ok := rendered[prefix]; ok && prev == uri || prefix == "" && uri == "" && rendered[""] == "" {
+			continue
+		}
+		if prefix == "" {
+			buf.WriteString(` xmlns="`)
+		} else {
+			buf.WriteString(` xmlns:` + prefix + `="`)
+		}
+		escapeAttr(buf, uri)
+		buf.WriteByte('"')
+	}
+
+	type attr struct {
+		space string
+		xml.Attr
+	}
+
+	var attrs []attr
+	for _, a := range n.attrs {
+		switch {
+		case a.Name.Space == "xmlns", a.Name.Space == "" && a.Name.Local == "xmlns":
+			continue
+		case a.Name.Space == "xml":
+			attrs = append(attrs, attr{xmlNamespace, a})
+		case a.Name.Space != "":
+			attrs = append(attrs, attr{scope[a.Name.Space], a})
+		default:
+			attrs = append(attrs, attr{"", a})
+		}
+	}
+
+	slices.SortFunc(attrs, func(a, b attr) int {
+		if c := strings.Compare(a.space, b.space); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name.Local, b.Name.Local)
+	})
+
+	for _, a := range attrs {
+		buf.WriteByte(' ')
+		writeName(buf, a.Name)
+		buf.WriteString(`="`)
+		escapeAttr(buf, a.Value)
+		buf.WriteByte('"')
+	}
+
+	buf.WriteByte('>')
+
+	for _, c := range n.children {
+		switch c := c.(type) {
+		case *xmlNode:
+			if c == omit {
+				continue
+			}
+			childScope := scope
+			if hasNamespaces(c) {
+				childScope = make(map[string]string, len(scope)+1)
+				for k, v := range scope {
+					childScope[k] = v
+				}
+				declare(childScope, c)
+			}
+			c14nElement(buf, c, scope, childScope, omit)
+		case xml.CharData:
+			escapeText(buf, string(c))
+		case xml.ProcInst:
+			buf.WriteString("<?" + c.Target)
+			if len(c.Inst) > 0 {
+				buf.WriteByte(' ')
+				buf.Write(c.Inst)
+			}
+			buf.WriteString("?>")
+		}
+	}
+
+	buf.WriteString("</")
+	writeName(buf, n.name)
+	buf.WriteByte('>')
+}
+
+func hasNamespaces(n *xmlNode) bool {
+	for _, a := range n.attrs {
+		if a.Name.Space == "xmlns" || a.Name.Space == "" && a.Name.Local == "xmlns" {
+			return true
+		}
+	}
+	return false
+}
+
+func writeName(buf *bytes.Buffer, name xml.Name) {
+	if name.Space != "" {
+		buf.WriteString(name.Space)
+		buf.WriteByte(':')
+	}
+	buf.WriteString(name.Local)
+}
+
+func escapeText(buf *bytes.Buffer, s string) {
+	for i := range len(s) {
+		switch c := s[i]; c {
+		case '&':
+			buf.WriteString("&amp;")
+		case '<':
+			buf.WriteString("&lt;")
+		case '>':
+			buf.WriteString("&gt;")
+		case '\r':
+			buf.WriteString("&#xD;")
+		default:
+			buf.WriteByte(c)
+		}
+	}
+}
+
+func escapeAttr(buf *bytes.Buffer, s string) {
+	for i := range len(s) {
+		switch c := s[i]; c {
+		case '&':
+			buf.WriteString("&amp;")
+		case '<':
+			buf.WriteString("&lt;")
+		case '"':
+			buf.WriteString("&quot;")
+		case '\t':
+			buf.WriteString("&#x9;")
+		case '\n':
+			buf.WriteString("&#xA;")
+		case '\r':
+			buf.WriteString("&#xD;")
+		default:
+			buf.WriteByte(c)
+		}
+	}
+}
@@ -0,0 +1,1076 @@
+// Package nfe provides functions for reading the XML of the Nota Fiscal Eletrônica (NF-e) and of
+// the Nota Fiscal de Consumidor Eletrônica (NFC-e), in the layout 4.00.
+//
+// Parse reads both the NFe element, as signed by the emitter, and the nfeProc element, distributed
+// with the authorization protocol of the SEFAZ. The values are converted to the types of the br and
+// address packages, and the fields encoded in the chave de acesso are checked against the XML.
+//
+// Verify checks offline the XMLDSig signature of the emitter, and optionally the chain of its
+// ICP-Brasil certificate up to caller-supplied roots.
+//
+// This package does not transmit documents to the SEFAZ.
+package nfe
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/phenpessoa/br"
+	"github.com/phenpessoa/br/x/address"
+)
+
+var (
+	// ErrInvalidNFe is matched by every error returned by Parse.
+	ErrInvalidNFe = errors.New("br: invalid nfe")
+
+	// ErrMissingField is returned when a required field is missing.
+	ErrMissingField = errors.New("br: missing nfe field")
+
+	// ErrInvalidField is returned when a field has an invalid value.
+	ErrInvalidField = errors.New("br: invalid nfe field")
+
+	// ErrChaveMismatch is returned when a field does not match the chave de acesso.
+	ErrChaveMismatch = errors.New("br: nfe field does not match the chave de acesso")
+)
+
+// FieldError is the error returned when a field of the XML is missing, is invalid or does not match
+// the chave de acesso.
+//
+// It matches ErrInvalidNFe and Err with errors.Is.
+type FieldError struct {
+	// Path is the path of the field inside the infNFe element, such as "emit/CNPJ" or
+	// "det[2]/prod/vProd". Attributes start with @, such as "@Id". The fields of the protocol
+	// start with "protNFe".
+	Path string
+
+	// Err is one of ErrMissingField, ErrInvalidField or ErrChaveMismatch.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error() + " " + e.Path
+}
+
+func (e *FieldError) Unwrap() []error {
+	return []error{ErrInvalidNFe, e.Err}
+}
+
+// Versao is the version of the layout read by this package.
+const Versao = "4.00"
+
+// NFe is a Nota Fiscal Eletrônica or a Nota Fiscal de Consumidor Eletrônica.
+type NFe struct {
+	// Chave is the chave de acesso of the document.
+	Chave br.ChaveAcesso
+
+	// Ide identifies the document.
+	Ide Ide
+
+	// Emit is the emitter of the document.
+	Emit Emitente
+
+	// Dest is the recipient of the document. It may be nil for an NFC-e.
+	Dest *Destinatario
+
+	// Itens are the products and services of the document, in order.
+	Itens []Item
+
+	// Total holds the totals of the document.
+	Total Total
+
+	// InfAdFisco is the additional information of interest of the tax authorities.
+	InfAdFisco string
+
+	// InfCpl is the additional information of interest of the taxpayer.
+	InfCpl string
+
+	// QRCode is the content of the QR Code of an NFC-e.
+	QRCode string
+
+	// Protocolo is the authorization protocol of the SEFAZ. It is nil when the XML is not an nfeProc.
+	Protocolo *Protocolo
+}
+
+// TipoOperacao tells whether the document is of an entry or an exit of goods (tpNF).
+type TipoOperacao uint8
+
+const (
+	// Entrada is an entry of goods.
+	Entrada TipoOperacao = 0
+
+	// Saida is an exit of goods.
+	Saida TipoOperacao = 1
+)
+
+// Ambiente is the environment the document was emitted in (tpAmb).
+type Ambiente uint8
+
+const (
+	// Producao is the production environment, with legal validity.
+	Producao Ambiente = 1
+
+	// Homologacao is the test environment, without legal validity.
+	Homologacao Ambiente = 2
+)
+
+// Finalidade is the purpose of the document (finNFe).
+type Finalidade uint8
+
+const (
+	// FinalidadeNormal is a normal document.
+	FinalidadeNormal Finalidade = 1
+
+	// FinalidadeComplementar complements the values of another document.
+	FinalidadeComplementar Finalidade = 2
+
+	// FinalidadeAjuste adjusts the bookkeeping of taxes.
+	FinalidadeAjuste Finalidade = 3
+
+	// FinalidadeDevolucao returns goods.
+	FinalidadeDevolucao Finalidade = 4
+)
+
+// Ide identifies the document.
+type Ide struct {
+	// UF is the state of the emitter.
+	UF address.UF
+
+	// CodigoNumerico is the random code chosen by the emitter (cNF).
+	CodigoNumerico int
+
+	// NaturezaOperacao describes the operation, such as "Venda de mercadoria".
+	NaturezaOperacao string
+
+	// Modelo is either br.ModeloNFe or br.ModeloNFCe.
+	Modelo br.ModeloDocumento
+
+	// Serie is the series of the document.
+	Serie int
+
+	// Numero is the number of the document.
+	Numero int
+
+	// Emissao is the date and time of emission.
+	Emissao time.Time
+
+	// SaidaEntrada is the date and time of the exit or the entry of the goods, if informed.
+	SaidaEntrada time.Time
+
+	// TipoOperacao tells whether the document is of an entry or an exit.
+	TipoOperacao TipoOperacao
+
+	// CodigoMunicipio is the IBGE code of the city where the taxable event happened.
+	CodigoMunicipio string
+
+	// TipoEmissao is how the document was emitted.
+	TipoEmissao br.TipoEmissao
+
+	// Ambiente is the environment the document was emitted in.
+	Ambiente Ambiente
+
+	// Finalidade is the purpose of the document.
+	Finalidade Finalidade
+
+	// ConsumidorFinal tells whether the recipient is the final consumer.
+	ConsumidorFinal bool
+}
+
+// CRT is the tax regime of the emitter (Código de Regime Tributário).
+type CRT uint8
+
+const (
+	// SimplesNacional is the Simples Nacional.
+	SimplesNacional CRT = 1
+
+	// SimplesNacionalExcesso is the Simples Nacional with gross revenue above the sublimit.
+	SimplesNacionalExcesso CRT = 2
+
+	// RegimeNormal is the normal regime.
+	RegimeNormal CRT = 3
+
+	// SimplesNacionalMEI is the Simples Nacional of the Microempreendedor Individual.
+	SimplesNacionalMEI CRT = 4
+)
+
+// Emitente is the emitter of the document.
+type Emitente struct {
+	// CNPJ is the CNPJ of the emitter, empty when it has a CPF.
+	CNPJ br.CNPJ
+
+	// CPF is the CPF of the emitter, empty when it has a CNPJ.
+	CPF br.CPF
+
+	// Nome is the legal name of the emitter.
+	Nome string
+
+	// Fantasia is the trade name of the emitter.
+	Fantasia string
+
+	// Endereco is the address of the emitter.
+	Endereco Endereco
+
+	// IE is the inscrição estadual of the emitter, as written in the XML.
+	IE string
+
+	// IEST is the inscrição estadual of the emitter as tax substitute in the UF of the recipient.
+	IEST string
+
+	// IM is the inscrição municipal of the emitter.
+	IM string
+
+	// CNAE is the main CNAE of the emitter.
+	CNAE string
+
+	// CRT is the tax regime of the emitter.
+	CRT CRT
+}
+
+// Destinatario is the recipient of the document.
+type Destinatario struct {
+	// CNPJ is the CNPJ of the recipient, if it has one.
+	CNPJ br.CNPJ
+
+	// CPF is the CPF of the recipient, if it has one.
+	CPF br.CPF
+
+	// IDEstrangeiro is the document of a foreign recipient. It may be empty even for foreigners.
+	IDEstrangeiro string
+
+	// Nome is the name of the recipient.
+	Nome string
+
+	// Endereco is the address of the recipient. It may be nil for an NFC-e.
+	Endereco *Endereco
+
+	// IndicadorIE tells whether the recipient pays the ICMS: 1 if it does, 2 if it is exempt and 9 if
+	// it does not.
+	IndicadorIE int
+
+	// IE is the inscrição estadual of the recipient, as written in the XML.
+	IE string
+
+	// Email is the email of the recipient.
+	Email string
+}
+
+// Endereco is an address of the document. The UF of a recipient abroad, written as EX in the XML,
+// is address.ZZ.
+type Endereco struct {
+	Logradouro      string
+	Numero          string
+	Complemento     string
+	Bairro          string
+	CodigoMunicipio string
+	Municipio       string
+	UF              address.UF
+	CEP             address.CEP
+	CodigoPais      string
+	Pais            string
+	Fone            string
+}
+
+// Address converts the Endereco to an address.Address. The number is appended to the logradouro. The
+// UF of an address abroad is left empty, as an address.Address does not hold address.ZZ.
+func (e Endereco) Address() address.Address {
+	logradouro := e.Logradouro
+	if e.Numero != "" {
+		logradouro += ", " + e.Numero
+	}
+
+	uf := e.UF
+	if uf == address.ZZ {
+		uf = 0
+	}
+
+	return address.Address{
+		UF:          uf,
+		CEP:         e.CEP,
+		Localidade:  e.Municipio,
+		Logradouro:  logradouro,
+		Complemento: e.Complemento,
+		Bairro:      e.Bairro,
+	}
+}
+
+// Decimal is a non-negative decimal number as written in the XML, such as 1.5000. It is used by the
+// quantities, the unit values and the rates, which may have more than 2 decimal places.
+type Decimal string
+
+// IsValid checks whether the Decimal is made of digits, optionally followed by a dot and more digits.
+func (d Decimal) IsValid() bool {
+	i, f, hasDot := strings.Cut(string(d), ".")
+	return i != "" && isNumeric(i) && isNumeric(f) && (!hasDot || f != "")
+}
+
+// Float64 returns the Decimal as a float64, or 0 if it is invalid.
+func (d Decimal) Float64() float64 {
+	if !d.IsValid() {
+		return 0
+	}
+	f, _ := strconv.ParseFloat(string(d), 64)
+	return f
+}
+
+// Item is a product or a service of the document (det).
+type Item struct {
+	// Numero is the number of the item, starting at 1.
+	Numero int
+
+	// Codigo is the code of the product used by the emitter.
+	Codigo string
+
+	// EAN is the GTIN of the product, or "SEM GTIN".
+	EAN string
+
+	// Descricao is the description of the product.
+	Descricao string
+
+	// NCM is the code of the Nomenclatura Comum do Mercosul of the product.
+	NCM string
+
+	// CEST is the Código Especificador da Substituição Tributária of the product.
+	CEST string
+
+	// CFOP is the Código Fiscal de Operações e Prestações of the item.
+	CFOP br.CFOP
+
+	// Unidade is the commercial unit, such as UN or KG.
+	Unidade string
+
+	// Quantidade is the commercial quantity.
+	Quantidade Decimal
+
+	// ValorUnitario is the commercial unit value.
+	ValorUnitario Decimal
+
+	// Valor is the gross value of the item, in centavos.
+	Valor int64
+
+	// Frete is the freight of the item, in centavos.
+	Frete int64
+
+	// Seguro is the insurance of the item, in centavos.
+	Seguro int64
+
+	// Desconto is the discount of the item, in centavos.
+	Desconto int64
+
+	// Outros are the other expenses of the item, in centavos.
+	Outros int64
+
+	// Impostos are the taxes of the item.
+	Impostos Impostos
+
+	// InfAdProd is the additional information of the item.
+	InfAdProd string
+}
+
+// Impostos are the taxes of an Item. The groups not present in the XML are nil.
+type Impostos struct {
+	// ValorTotalTributos is the approximate value of all the taxes of the item, in centavos.
+	ValorTotalTributos int64
+
+	ICMS   *ICMS
+	IPI    *IPI
+	PIS    *Contribuicao
+	COFINS *Contribuicao
+}
+
+// ICMS is the ICMS of an Item.
+type ICMS struct {
+	// Grupo is the name of the group of the XML, such as ICMS00 or ICMSSN102.
+	Grupo string
+
+	// Origem is the origin of the goods, from 0 to 8.
+	Origem int
+
+	// CST is the CST, or the CSOSN for the Simples Nacional.
+	CST string
+
+	// BaseCalculo is the base of calculation, in centavos.
+	BaseCalculo int64
+
+	// Aliquota is the rate, in percent.
+	Aliquota Decimal
+
+	// Valor is the value of the ICMS, in centavos.
+	Valor int64
+
+	// ValorFCP is the value of the Fundo de Combate à Pobreza, in centavos.
+	ValorFCP int64
+
+	// BaseCalculoST is the base of calculation of the tax substitution, in centavos.
+	BaseCalculoST int64
+
+	// AliquotaST is the rate of the tax substitution, in percent.
+	AliquotaST Decimal
+
+	// ValorST is the value of the tax substitution, in centavos.
+	ValorST int64
+}
+
+// IPI is the IPI of an Item.
+type IPI struct {
+	// Enquadramento is the legal framework code (cEnq).
+	Enquadramento string
+
+	// CST is the CST of the IPI.
+	CST string
+
+	// BaseCalculo is the base of calculation, in centavos.
+	BaseCalculo int64
+
+	// Aliquota is the rate, in percent.
+	Aliquota Decimal
+
+	// Valor is the value of the IPI, in centavos.
+	Valor int64
+}
+
+// Contribuicao is the PIS or the COFINS of an Item.
+type Contribuicao struct {
+	// Grupo is the name of the group of the XML, such as PISAliq or COFINSNT.
+	Grupo string
+
+	// CST is the CST of the contribution.
+	CST string
+
+	// BaseCalculo is the base of calculation, in centavos.
+	BaseCalculo int64
+
+	// Aliquota is the rate, in percent.
+	Aliquota Decimal
+
+	// Valor is the value of the contribution, in centavos.
+	Valor int64
+}
+
+// Total holds the totals of the document (ICMSTot), in centavos.
+type Total struct {
+	BaseCalculoICMS int64
+	ICMS            int64
+	ICMSDesonerado  int64
+	FCP             int64
+	BaseCalculoST   int64
+	ST              int64
+	FCPST           int64
+	Produtos        int64
+	Frete           int64
+	Seguro          int64
+	Desconto        int64
+	II              int64
+	IPI             int64
+	PIS             int64
+	COFINS          int64
+	Outros          int64
+	Tributos        int64
+
+	// NF is the value of the document.
+	NF int64
+}
+
+// Protocolo is the authorization protocol of the SEFAZ (protNFe).
+type Protocolo struct {
+	// Ambiente is the environment the document was authorized in.
+	Ambiente Ambiente
+
+	// VersaoAplicativo is the version of the application of the SEFAZ.
+	VersaoAplicativo string
+
+	// Chave is the chave de acesso of the document.
+	Chave br.ChaveAcesso
+
+	// Recebimento is the date and time the SEFAZ received the document.
+	Recebimento time.Time
+
+	// Numero is the number of the protocol.
+	Numero string
+
+	// DigestValue is the digest of the document received by the SEFAZ, in base64.
+	DigestValue string
+
+	// Status is the status code, such as 100 for an authorized document.
+	Status int
+
+	// Motivo describes the status.
+	Motivo string
+}
+
+// Autorizado reports whether the Status is of an authorized document.
+func (p Protocolo) Autorizado() bool {
+	return p.Status == 100 || p.Status == 150
+}
+
+// Parse reads the XML of an NF-e or an NFC-e, either the NFe element or the nfeProc element with
+// the authorization protocol.
+//
+// The chave de acesso of the Id attribute is checked against the UF, the model, the series, the number,
+// the emission type, the numeric code, the check digit, the month of emission and the emitter of the
+// document, and against the chave of the protocol.
+//
+// Parse does not verify the signature of the document; use ParseVerified for that.
+//
+// The returned error matches ErrInvalidNFe. The errors of the fields are a *FieldError.
+func Parse(data []byte) (NFe, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+
+	var root xml.StartElement
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return NFe{}, fmt.Errorf("%w: %w", ErrInvalidNFe, err)
+		}
+		if se, ok := tok.(xml.StartElement); ok {
+			root = se
+			break
+		}
+	}
+
+	var (
+		proc xmlNFeProc
+		err  error
+	)
+	switch root.Name.Local {
+	case "nfeProc":
+		err = d.DecodeElement(&proc, &root)
+	case "NFe":
+		err = d.DecodeElement(&proc.NFe, &root)
+	default:
+		return NFe{}, fmt.Errorf("%w: unexpected element %s", ErrInvalidNFe, root.Name.Local)
+	}
+	if err != nil {
+		return NFe{}, fmt.Errorf("%w: %w", ErrInvalidNFe, err)
+	}
+
+	return build(proc, root.Name.Local == "nfeProc")
+}
+
+// build converts the decoded document. The protocol is read only if withProtocol is set.
+func build(proc xmlNFeProc, withProtocol bool) (NFe, error) {
+	p := &parser{}
+	nfe := p.nfe(proc.NFe)
+	if withProtocol {
+		nfe.Protocolo = p.protocolo(proc.ProtNFe)
+	}
+
+	if p.err != nil {
+		return NFe{}, p.err
+	}
+
+	if err := checkChave(nfe); err != nil {
+		return NFe{}, err
+	}
+
+	return nfe, nil
+}
+
+// checkChave checks the fields encoded in the chave de acesso against the document.
+func checkChave(nfe NFe) error {
+	f := nfe.Chave.Fields()
+
+	mismatch := func(path string) error {
+		return &FieldError{Path: path, Err: ErrChaveMismatch}
+	}
+
+	switch {
+	case f.UF != nfe.Ide.UF:
+		return mismatch("ide/cUF")
+	case f.CodigoNumerico != nfe.Ide.CodigoNumerico:
+		return mismatch("ide/cNF")
+	case f.Modelo != nfe.Ide.Modelo:
+		return mismatch("ide/mod")
+	case f.Serie != nfe.Ide.Serie:
+		return mismatch("ide/serie")
+	case f.Numero != nfe.Ide.Numero:
+		return mismatch("ide/nNF")
+	case f.Ano != nfe.Ide.Emissao.Year() || f.Mes != nfe.Ide.Emissao.Month():
+		return mismatch("ide/dhEmi")
+	case f.TipoEmissao != nfe.Ide.TipoEmissao:
+		return mismatch("ide/tpEmis")
+	}
+
+	if nfe.Emit.CNPJ != "" {
+		if nfe.Chave.CNPJ() != br.CNPJ(nfe.Emit.CNPJ.AlphaNumerical()) {
+			return mismatch("emit/CNPJ")
+		}
+	} else if nfe.Chave.CPF() != nfe.Emit.CPF {
+		return mismatch("emit/CPF")
+	}
+
+	if nfe.Protocolo != nil && nfe.Protocolo.Chave.Digits() != nfe.Chave.Digits() {
+		return mismatch("protNFe/infProt/chNFe")
+	}
+
+	return nil
+}
+
+// parser converts the values of the XML, keeping the first error found.
+type parser struct {
+	err error
+}
+
+func (p *parser) fail(path string, err error) {
+	if p.err == nil {
+		p.err = &FieldError{Path: path, Err: err}
+	}
+}
+
+func (p *parser) required(path, s string) string {
+	if s == "" {
+		p.fail(path, ErrMissingField)
+	}
+	return s
+}
+
+// int reads a non-negative integer of at most maxLen digits. Empty optional values are 0.
+func (p *parser) int(path, s string, maxLen int, required bool) int {
+	if s == "" {
+		if required {
+			p.fail(path, ErrMissingField)
+		}
+		return 0
+	}
+
+	if len(s) > maxLen || !isNumeric(s) {
+		p.fail(path, ErrInvalidField)
+		return 0
+	}
+
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// valor reads an amount of money with up to 2 decimal places, in centavos. Empty optional values are 0.
+func (p *parser) valor(path, s string, required bool) int64 {
+	if s == "" {
+		if required {
+			p.fail(path, ErrMissingField)
+		}
+		return 0
+	}
+
+	i, f, hasDot := strings.Cut(s, ".")
+	if i == "" || len(i) > 15 || !isNumeric(i) || len(f) > 2 || !isNumeric(f) || (hasDot && f == "") {
+		p.fail(path, ErrInvalidField)
+		return 0
+	}
+
+	f += strings.Repeat("0", 2-len(f))
+	n, _ := strconv.ParseInt(i+f, 10, 64)
+	return n
+}
+
+// decimal reads a Decimal. Empty optional values are an empty Decimal.
+func (p *parser) decimal(path, s string, required bool) Decimal {
+	if s == "" {
+		if required {
+			p.fail(path, ErrMissingField)
+		}
+		return ""
+	}
+
+	d := Decimal(s)
+	if !d.IsValid() {
+		p.fail(path, ErrInvalidField)
+		return ""
+	}
+	return d
+}
+
+// time reads a date and time in the UTC format of the layout, such as 2017-06-01T10:00:00-03:00.
+func (p *parser) time(path, s string, required bool) time.Time {
+	if s == "" {
+		if required {
+			p.fail(path, ErrMissingField)
+		}
+		return time.Time{}
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		p.fail(path, ErrInvalidField)
+		return time.Time{}
+	}
+	return t
+}
+
+// uf parses a UF. When exterior is set, EX is accepted as the UF of an address abroad.
+func (p *parser) uf(path, s string, exterior bool) address.UF {
+	if exterior && s == "EX" {
+		return address.ZZ
+	}
+
+	if s == "" {
+		p.fail(path, ErrMissingField)
+		return 0
+	}
+
+	uf, err := address.NewUFFromStr(s)
+	if err != nil || len(s) != 2 {
+		p.fail(path, ErrInvalidField)
+		return 0
+	}
+	return uf
+}
+
+func (p *parser) cep(path, s string) address.CEP {
+	if s == "" {
+		return ""
+	}
+
+	cep, err := address.NewCEP(s)
+	if err != nil {
+		p.fail(path, ErrInvalidField)
+		return ""
+	}
+	return cep
+}
+
+func (p *parser) cnpj(path, s string) br.CNPJ {
+	cnpj := br.CNPJ(s)
+	if s != "" && (len(s) != 14 || !cnpj.IsValid()) {
+		p.fail(path, ErrInvalidField)
+		return ""
+	}
+	return cnpj
+}
+
+func (p *parser) cpf(path, s string) br.CPF {
+	cpf := br.CPF(s)
+	if s != "" && (len(s) != 11 || !cpf.IsValid()) {
+		p.fail(path, ErrInvalidField)
+		return ""
+	}
+	return cpf
+}
+
+func (p *parser) cfop(path, s string) br.CFOP {
+	if s == "" {
+		p.fail(path, ErrMissingField)
+		return ""
+	}
+
+	cfop := br.CFOP(s)
+	if len(s) != 4 || !cfop.IsValid() {
+		p.fail(path, ErrInvalidField)
+		return ""
+	}
+	return cfop
+}
+
+func (p *parser) nfe(x xmlNFe) NFe {
+	inf := x.InfNFe
+
+	if inf.Versao != Versao {
+		p.fail("@versao", ErrInvalidField)
+	}
+
+	var nfe NFe
+
+	id, ok := strings.CutPrefix(p.required("@Id", inf.ID), "NFe")
+	nfe.Chave = br.ChaveAcesso(id)
+	if inf.ID != "" && (!ok || len(id) != 44 || !nfe.Chave.IsValid()) {
+		p.fail("@Id", ErrInvalidField)
+	}
+
+	nfe.Ide = p.ide(inf.Ide)
+	nfe.Emit = p.emit(inf.Emit)
+	if inf.Dest != nil {
+		nfe.Dest = p.dest(*inf.Dest)
+	}
+
+	if len(inf.Det) == 0 {
+		p.fail("det", ErrMissingField)
+	}
+
+	nfe.Itens = make([]Item, len(inf.Det))
+	for i, det := range inf.Det {
+		nfe.Itens[i] = p.item(fmt.Sprintf("det[%d]", i+1), det)
+	}
+
+	nfe.Total = p.total(inf.Total.ICMSTot)
+
+	if inf.InfAdic != nil {
+		nfe.InfAdFisco = inf.InfAdic.InfAdFisco
+		nfe.InfCpl = inf.InfAdic.InfCpl
+	}
+
+	nfe.QRCode = strings.TrimSpace(x.InfSupl.QRCode)
+
+	if dv := p.required("ide/cDV", inf.Ide.CDV); nfe.Chave.IsValid() && dv != nfe.Chave.Digits()[43:] {
+		p.fail("ide/cDV", ErrChaveMismatch)
+	}
+
+	return nfe
+}
+
+func (p *parser) ide(x xmlIde) Ide {
+	ide := Ide{
+		CodigoNumerico:   p.int("ide/cNF", x.CNF, 8, true),
+		NaturezaOperacao: p.required("ide/natOp", x.NatOp),
+		Modelo:           br.ModeloDocumento(p.int("ide/mod", x.Mod, 2, true)),
+		Serie:            p.int("ide/serie", x.Serie, 3, true),
+		Numero:           p.int("ide/nNF", x.NNF, 9, true),
+		Emissao:          p.time("ide/dhEmi", x.DhEmi, true),
+		SaidaEntrada:     p.time("ide/dhSaiEnt", x.DhSaiEnt, false),
+		TipoOperacao:     TipoOperacao(p.int("ide/tpNF", x.TpNF, 1, true)),
+		CodigoMunicipio:  p.required("ide/cMunFG", x.CMunFG),
+		TipoEmissao:      br.TipoEmissao(p.int("ide/tpEmis", x.TpEmis, 1, true)),
+		Ambiente:         Ambiente(p.int("ide/tpAmb", x.TpAmb, 1, true)),
+		Finalidade:       Finalidade(p.int("ide/finNFe", x.FinNFe, 1, true)),
+		ConsumidorFinal:  x.IndFinal == "1",
+	}
+
+	if code := p.int("ide/cUF", x.CUF, 2, true); code != 0 {
+		uf, err := address.NewUF(code)
+		if err != nil {
+			p.fail("ide/cUF", ErrInvalidField)
+		}
+		ide.UF = uf
+	}
+
+	if ide.Modelo != br.ModeloNFe && ide.Modelo != br.ModeloNFCe {
+		p.fail("ide/mod", ErrInvalidField)
+	}
+
+	if ide.TipoOperacao != Entrada && ide.TipoOperacao != Saida {
+		p.fail("ide/tpNF", ErrInvalidField)
+	}
+
+	if ide.Ambiente != Producao && ide.Ambiente != Homologacao {
+		p.fail("ide/tpAmb", ErrInvalidField)
+	}
+
+	return ide
+}
+
+func (p *parser) emit(x xmlEmit) Emitente {
+	emit := Emitente{
+		CNPJ:     p.cnpj("emit/CNPJ", x.CNPJ),
+		CPF:      p.cpf("emit/CPF", x.CPF),
+		Nome:     p.required("emit/xNome", x.XNome),
+		Fantasia: x.XFant,
+		IE:       p.required("emit/IE", x.IE),
+		IEST:     x.IEST,
+		IM:       x.IM,
+		CNAE:     x.CNAE,
+		CRT:      CRT(p.int("emit/CRT", x.CRT, 1, true)),
+	}
+
+	if x.CNPJ == "" && x.CPF == "" {
+		p.fail("emit/CNPJ", ErrMissingField)
+	}
+
+	if x.EnderEmit == nil {
+		p.fail("emit/enderEmit", ErrMissingField)
+	} else {
+		emit.Endereco = p.endereco("emit/enderEmit", *x.EnderEmit, false)
+	}
+
+	return emit
+}
+
+func (p *parser) dest(x xmlDest) *Destinatario {
+	dest := &Destinatario{
+		CNPJ:        p.cnpj("dest/CNPJ", x.CNPJ),
+		CPF:         p.cpf("dest/CPF", x.CPF),
+		Nome:        x.XNome,
+		IndicadorIE: p.int("dest/indIEDest", x.IndIEDest, 1, true),
+		IE:          x.IE,
+		Email:       x.Email,
+	}
+
+	if x.IDEstrangeiro != nil {
+		dest.IDEstrangeiro = *x.IDEstrangeiro
+	} else if x.CNPJ == "" && x.CPF == "" {
+		p.fail("dest/CNPJ", ErrMissingField)
+	}
+
+	if x.EnderDest != nil {
+		endereco := p.endereco("dest/enderDest", *x.EnderDest, true)
+		dest.Endereco = &endereco
+	}
+
+	return dest
+}
+
+func (p *parser) endereco(path string, x xmlEndereco, exterior bool) Endereco {
+	return Endereco{
+		Logradouro:      p.required(path+"/xLgr", x.XLgr),
+		Numero:          p.required(path+"/nro", x.Nro),
+		Complemento:     x.XCpl,
+		Bairro:          p.required(path+"/xBairro", x.XBairro),
+		CodigoMunicipio: p.required(path+"/cMun", x.CMun),
+		Municipio:       p.required(path+"/xMun", x.XMun),
+		UF:              p.uf(path+"/UF", x.UF, exterior),
+		CEP:             p.cep(path+"/CEP", x.CEP),
+		CodigoPais:      x.CPais,
+		Pais:            x.XPais,
+		Fone:            x.Fone,
+	}
+}
+
+func (p *parser) item(path string, x xmlDet) Item {
+	prod := path + "/prod/"
+	item := Item{
+		Numero:        p.int(path+"/@nItem", x.NItem, 3, true),
+		Codigo:        p.required(prod+"cProd", x.Prod.CProd),
+		EAN:           x.Prod.CEAN,
+		Descricao:     p.required(prod+"xProd", x.Prod.XProd),
+		NCM:           p.required(prod+"NCM", x.Prod.NCM),
+		CEST:          x.Prod.CEST,
+		CFOP:          p.cfop(prod+"CFOP", x.Prod.CFOP),
+		Unidade:       p.required(prod+"uCom", x.Prod.UCom),
+		Quantidade:    p.decimal(prod+"qCom", x.Prod.QCom, true),
+		ValorUnitario: p.decimal(prod+"vUnCom", x.Prod.VUnCom, true),
+		Valor:         p.valor(prod+"vProd", x.Prod.VProd, true),
+		Frete:         p.valor(prod+"vFrete", x.Prod.VFrete, false),
+		Seguro:        p.valor(prod+"vSeg", x.Prod.VSeg, false),
+		Desconto:      p.valor(prod+"vDesc", x.Prod.VDesc, false),
+		Outros:        p.valor(prod+"vOutro", x.Prod.VOutro, false),
+		InfAdProd:     x.InfAdProd,
+	}
+
+	imposto := path + "/imposto/"
+	item.Impostos.ValorTotalTributos = p.valor(imposto+"vTotTrib", x.Imposto.VTotTrib, false)
+
+	if x.Imposto.ICMS != nil {
+		item.Impostos.ICMS = p.icms(imposto+"ICMS/", x.Imposto.ICMS.Group)
+	}
+
+	if x.Imposto.IPI != nil {
+		item.Impostos.IPI = p.ipi(imposto+"IPI/", *x.Imposto.IPI)
+	}
+
+	if x.Imposto.PIS != nil {
+		g := x.Imposto.PIS.Group
+		item.Impostos.PIS = p.contribuicao(imposto+"PIS/", g, "pPIS", g.PPIS, "vPIS", g.VPIS)
+	}
+
+	if x.Imposto.COFINS != nil {
+		g := x.Imposto.COFINS.Group
+		item.Impostos.COFINS = p.contribuicao(imposto+"COFINS/", g, "pCOFINS", g.PCOFINS, "vCOFINS", g.VCOFINS)
+	}
+
+	return item
+}
+
+func (p *parser) icms(path string, x xmlTax) *ICMS {
+	path += x.XMLName.Local + "/"
+
+	icms := &ICMS{
+		Grupo:         x.XMLName.Local,
+		Origem:        p.int(path+"orig", x.Orig, 1, true),
+		CST:           x.CST,
+		BaseCalculo:   p.valor(path+"vBC", x.VBC, false),
+		Aliquota:      p.decimal(path+"pICMS", x.PICMS, false),
+		Valor:         p.valor(path+"vICMS", x.VICMS, false),
+		ValorFCP:      p.valor(path+"vFCP", x.VFCP, false),
+		BaseCalculoST: p.valor(path+"vBCST", x.VBCST, false),
+		AliquotaST:    p.decimal(path+"pICMSST", x.PICMSST, false),
+		ValorST:       p.valor(path+"vICMSST", x.VICMSST, false),
+	}
+
+	if icms.CST == "" {
+		icms.CST = p.required(path+"CSOSN", x.CSOSN)
+	}
+
+	return icms
+}
+
+func (p *parser) ipi(path string, x xmlIPI) *IPI {
+	ipi := &IPI{Enquadramento: p.required(path+"cEnq", x.CEnq)}
+
+	switch {
+	case x.IPITrib != nil:
+		path += "IPITrib/"
+		ipi.CST = p.required(path+"CST", x.IPITrib.CST)
+		ipi.BaseCalculo = p.valor(path+"vBC", x.IPITrib.VBC, false)
+		ipi.Aliquota = p.decimal(path+"pIPI", x.IPITrib.PIPI, false)
+		ipi.Valor = p.valor(path+"vIPI", x.IPITrib.VIPI, true)
+	case x.IPINT != nil:
+		ipi.CST = p.required(path+"IPINT/CST", x.IPINT.CST)
+	default:
+		p.fail(path+"IPITrib", ErrMissingField)
+	}
+
+	return ipi
+}
+
+// contribuicao reads the PIS or the COFINS, whose rate and value are named after the tax.
+func (p *parser) contribuicao(path string, x xmlTax, aliquotaTag, aliquota, valorTag, valor string) *Contribuicao {
+	path += x.XMLName.Local + "/"
+
+	return &Contribuicao{
+		Grupo:       x.XMLName.Local,
+		CST:         p.required(path+"CST", x.CST),
+		BaseCalculo: p.valor(path+"vBC", x.VBC, false),
+		Aliquota:    p.decimal(path+aliquotaTag, aliquota, false),
+		Valor:       p.valor(path+valorTag, valor, false),
+	}
+}
+
+func (p *parser) total(x xmlICMSTot) Total {
+	const path = "total/ICMSTot/"
+	return Total{
+		BaseCalculoICMS: p.valor(path+"vBC", x.VBC, true),
+		ICMS:            p.valor(path+"vICMS", x.VICMS, true),
+		ICMSDesonerado:  p.valor(path+"vICMSDeson", x.VICMSDeson, false),
+		FCP:             p.valor(path+"vFCP", x.VFCP, false),
+		BaseCalculoST:   p.valor(path+"vBCST", x.VBCST, true),
+		ST:              p.valor(path+"vST", x.VST, true),
+		FCPST:           p.valor(path+"vFCPST", x.VFCPST, false),
+		Produtos:        p.valor(path+"vProd", x.VProd, true),
+		Frete:           p.valor(path+"vFrete", x.VFrete, true),
+		Seguro:          p.valor(path+"vSeg", x.VSeg, true),
+		Desconto:        p.valor(path+"vDesc", x.VDesc, true),
+		II:              p.valor(path+"vII", x.VII, true),
+		IPI:             p.valor(path+"vIPI", x.VIPI, true),
+		PIS:             p.valor(path+"vPIS", x.VPIS, true),
+		COFINS:          p.valor(path+"vCOFINS", x.VCOFINS, true),
+		Outros:          p.valor(path+"vOutro", x.VOutro, true),
+		NF:              p.valor(path+"vNF", x.VNF, true),
+		Tributos:        p.valor(path+"vTotTrib", x.VTotTrib, false),
+	}
+}
+
+func (p *parser) protocolo(x xmlProtNFe) *Protocolo {
+	const path = "protNFe/infProt/"
+
+	if x.InfProt == nil {
+		p.fail("protNFe/infProt", ErrMissingField)
+		return nil
+	}
+
+	inf := x.InfProt
+	prot := &Protocolo{
+		Ambiente:         Ambiente(p.int(path+"tpAmb", inf.TpAmb, 1, true)),
+		VersaoAplicativo: inf.VerAplic,
+		Chave:            br.ChaveAcesso(p.required(path+"chNFe", inf.ChNFe)),
+		Recebimento:      p.time(path+"dhRecbto", inf.DhRecbto, true),
+		Numero:           inf.NProt,
+		DigestValue:      inf.DigVal,
+		Status:           p.int(path+"cStat", inf.CStat, 3, true),
+		Motivo:           inf.XMotivo,
+	}
+
+	if prot.Chave != "" && !prot.Chave.IsValid() {
+		p.fail(path+"chNFe", ErrInvalidField)
+	}
+
+	return prot
+}
+
+func isNumeric(s string) bool {
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,423 @@
+package nfe
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/phenpessoa/br"
+	"github.com/phenpessoa/br/x/address"
+)
+
+func readTestdata(t testing.TB, name string) string {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestParse(t *testing.T) {
+	nfe, err := Parse([]byte(readTestdata(t, "nfeProc.xml")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := br.ChaveAcesso("35240311222333000181550010000012341876543215"); nfe.Chave != want {
+		t.Errorf("\nwant: %s\ngot: %s", want, nfe.Chave)
+	}
+
+	brt := time.FixedZone("", -3*60*60)
+	wantIde := Ide{
+		UF:               address.SP,
+		CodigoNumerico:   87654321,
+		NaturezaOperacao: "Venda de mercadoria",
+		Modelo:           br.ModeloNFe,
+		Serie:            1,
+		Numero:           1234,
+		Emissao:          time.Date(2024, time.March, 15, 10, 30, 0, 0, brt),
+		SaidaEntrada:     time.Date(2024, time.March, 15, 14, 0, 0, 0, brt),
+		TipoOperacao:     Saida,
+		CodigoMunicipio:  "3550308",
+		TipoEmissao:      br.EmissaoNormal,
+		Ambiente:         Producao,
+		Finalidade:       FinalidadeNormal,
+		ConsumidorFinal:  true,
+	}
+	gotIde := nfe.Ide
+	if !gotIde.Emissao.Equal(wantIde.Emissao) || !gotIde.SaidaEntrada.Equal(wantIde.SaidaEntrada) {
+		t.Errorf("\nwant: %s %s\ngot: %s %s", wantIde.Emissao, wantIde.SaidaEntrada, gotIde.Emissao, gotIde.SaidaEntrada)
+	}
+	gotIde.Emissao, gotIde.SaidaEntrada = wantIde.Emissao, wantIde.SaidaEntrada
+	if gotIde != wantIde {
+		t.Errorf("\nwant: %+v\ngot: %+v", wantIde, gotIde)
+	}
+
+	wantEmit := Emitente{
+		CNPJ:     "11222333000181",
+		Nome:     "Comércio de Exemplo Ltda",
+		Fantasia: "Exemplo",
+		Endereco: Endereco{
+			Logradouro:      "Avenida Paulista",
+			Numero:          "1000",
+			Complemento:     "Sala 1",
+			Bairro:          "Bela Vista",
+			CodigoMunicipio: "3550308",
+			Municipio:       "São Paulo",
+			UF:              address.SP,
+			CEP:             "01310100",
+			CodigoPais:      "1058",
+			Pais:            "Brasil",
+			Fone:            "1133334444",
+		},
+		IE:  "110042490114",
+		CRT: RegimeNormal,
+	}
+	if nfe.Emit != wantEmit {
+		t.Errorf("\nwant: %+v\ngot: %+v", wantEmit, nfe.Emit)
+	}
+
+	if nfe.Dest == nil || nfe.Dest.CPF != "12345678909" || nfe.Dest.CNPJ != "" || nfe.Dest.IndicadorIE != 9 ||
+		nfe.Dest.Email != "fulano@example.com" {
+		t.Fatalf("unexpected dest: %+v", nfe.Dest)
+	}
+
+	if nfe.Dest.Endereco == nil || nfe.Dest.Endereco.UF != address.RJ || nfe.Dest.Endereco.CEP != "20011000" {
+		t.Errorf("unexpected dest address: %+v", nfe.Dest.Endereco)
+	}
+
+	if len(nfe.Itens) != 2 {
+		t.Fatalf("\nwant: 2 items\ngot: %d", len(nfe.Itens))
+	}
+
+	item := nfe.Itens[0]
+	if item.Numero != 1 || item.Codigo != "001" || item.NCM != "73181500" || item.CEST != "1000100" ||
+		item.CFOP != "6102" || item.Unidade != "UN" || item.Quantidade != "10.0000" ||
+		item.ValorUnitario != "15.5000000000" || item.Valor != 15500 {
+		t.Errorf("unexpected item: %+v", item)
+	}
+
+	wantICMS := ICMS{Grupo: "ICMS00", CST: "00", BaseCalculo: 15500, Aliquota: "18.00", Valor: 2790}
+	if item.Impostos.ICMS == nil || *item.Impostos.ICMS != wantICMS {
+		t.Errorf("\nwant: %+v\ngot: %+v", wantICMS, item.Impostos.ICMS)
+	}
+
+	wantIPI := IPI{Enquadramento: "999", CST: "50", BaseCalculo: 15500, Aliquota: "5.00", Valor: 775}
+	if item.Impostos.IPI == nil || *item.Impostos.IPI != wantIPI {
+		t.Errorf("\nwant: %+v\ngot: %+v", wantIPI, item.Impostos.IPI)
+	}
+
+	wantPIS := Contribuicao{Grupo: "PISAliq", CST: "01", BaseCalculo: 15500, Aliquota: "1.65", Valor: 256}
+	if item.Impostos.PIS == nil || *item.Impostos.PIS != wantPIS {
+		t.Errorf("\nwant: %+v\ngot: %+v", wantPIS, item.Impostos.PIS)
+	}
+
+	wantCOFINS := Contribuicao{Grupo: "COFINSAliq", CST: "01", BaseCalculo: 15500, Aliquota: "7.60", Valor: 1178}
+	if item.Impostos.COFINS == nil || *item.Impostos.COFINS != wantCOFINS {
+		t.Errorf("\nwant: %+v\ngot: %+v", wantCOFINS, item.Impostos.COFINS)
+	}
+
+	if item.Impostos.ValorTotalTributos != 4000 {
+		t.Errorf("\nwant: %d\ngot: %d", 4000, item.Impostos.ValorTotalTributos)
+	}
+
+	item = nfe.Itens[1]
+	if item.Quantidade.Float64() != 2.5 || item.Desconto != 1000 || item.InfAdProd != "Lote 42" {
+		t.Errorf("unexpected item: %+v", item)
+	}
+
+	if item.Impostos.ICMS == nil || item.Impostos.ICMS.Grupo != "ICMSSN102" || item.Impostos.ICMS.CST != "102" {
+		t.Errorf("unexpected icms: %+v", item.Impostos.ICMS)
+	}
+
+	if item.Impostos.IPI == nil || item.Impostos.IPI.CST != "53" || item.Impostos.IPI.Valor != 0 {
+		t.Errorf("unexpected ipi: %+v", item.Impostos.IPI)
+	}
+
+	if item.Impostos.COFINS == nil || item.Impostos.COFINS.Grupo != "COFINSNT" || item.Impostos.COFINS.CST != "07" {
+		t.Errorf("unexpected cofins: %+v", item.Impostos.COFINS)
+	}
+
+	wantTotal := Total{
+		BaseCalculoICMS: 15500,
+		ICMS:            2790,
+		Produtos:        25500,
+		Desconto:        1000,
+		IPI:             775,
+		PIS:             256,
+		COFINS:          1178,
+		Tributos:        5000,
+		NF:              25275,
+	}
+	if nfe.Total != wantTotal {
+		t.Errorf("\nwant: %+v\ngot: %+v", wantTotal, nfe.Total)
+	}
+
+	if nfe.InfCpl != "Pedido 987" {
+		t.Errorf("\nwant: %s\ngot: %s", "Pedido 987", nfe.InfCpl)
+	}
+
+	if nfe.Protocolo == nil {
+		t.Fatal("expected protocol")
+	}
+
+	if !nfe.Protocolo.Autorizado() || nfe.Protocolo.Numero != "135240000000001" || nfe.Protocolo.Chave != nfe.Chave ||
+		nfe.Protocolo.Ambiente != Producao || !nfe.Protocolo.Recebimento.Equal(time.Date(2024, time.March, 15, 13, 30, 5, 0, time.UTC)) {
+		t.Errorf("unexpected protocol: %+v", nfe.Protocolo)
+	}
+}
+
+func TestParse_NFe(t *testing.T) {
+	data := readTestdata(t, "nfeProc.xml")
+	data = data[strings.Index(data, "<NFe ") : strings.Index(data, "</NFe>")+len("</NFe>")]
+
+	nfe, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if nfe.Protocolo != nil {
+		t.Errorf("unexpected protocol: %+v", nfe.Protocolo)
+	}
+
+	if nfe.Ide.Numero != 1234 {
+		t.Errorf("\nwant: %d\ngot: %d", 1234, nfe.Ide.Numero)
+	}
+}
+
+const nfce = `<NFe xmlns="http://www.portalfiscal.inf.br/nfe">
+<infNFe versao="4.00" Id="NFe43250111222333000181650020000000101000001237">
+<ide><cUF>43</cUF><cNF>00000123</cNF><natOp>VENDA</natOp><mod>65</mod><serie>2</serie><nNF>10</nNF>
+<dhEmi>2025-01-31T23:59:59-03:00</dhEmi><tpNF>1</tpNF><idDest>1</idDest><cMunFG>4314902</cMunFG><tpImp>4</tpImp>
+<tpEmis>1</tpEmis><cDV>7</cDV><tpAmb>2</tpAmb><finNFe>1</finNFe><indFinal>1</indFinal><indPres>1</indPres>
+<procEmi>0</procEmi><verProc>1</verProc></ide>
+<emit><CNPJ>11222333000181</CNPJ><xNome>Loja</xNome><enderEmit><xLgr>Rua A</xLgr><nro>S/N</nro>
+<xBairro>Centro</xBairro><cMun>4314902</cMun><xMun>Porto Alegre</xMun><UF>RS</UF></enderEmit>
+<IE>0960000001</IE><CRT>1</CRT></emit>
+<det nItem="1"><prod><cProd>1</cProd><cEAN>SEM GTIN</cEAN><xProd>Cafe</xProd><NCM>21011110</NCM><CFOP>5102</CFOP>
+<uCom>UN</uCom><qCom>1</qCom><vUnCom>5</vUnCom><vProd>5</vProd><indTot>1</indTot></prod>
+<imposto><ICMS><ICMSSN102><orig>0</orig><CSOSN>102</CSOSN></ICMSSN102></ICMS></imposto></det>
+<total><ICMSTot><vBC>0.00</vBC><vICMS>0.00</vICMS><vBCST>0.00</vBCST><vST>0.00</vST><vProd>5.00</vProd>
+<vFrete>0.00</vFrete><vSeg>0.00</vSeg><vDesc>0.00</vDesc><vII>0.00</vII><vIPI>0.00</vIPI><vPIS>0.00</vPIS>
+<vCOFINS>0.00</vCOFINS><vOutro>0.00</vOutro><vNF>5.00</vNF></ICMSTot></total>
+</infNFe>
+<infNFeSupl><qrCode><![CDATA[https://www.sefaz.rs.gov.br/NFCE/NFCE-COM.aspx?p=43250111222333000181650020000000101000001237|2|2|1|ABC]]></qrCode>
+<urlChave>www.sefaz.rs.gov.br/nfce/consulta</urlChave></infNFeSupl>
+</NFe>`
+
+func TestParse_NFCe(t *testing.T) {
+	nfe, err := Parse([]byte(nfce))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if nfe.Ide.Modelo != br.ModeloNFCe || nfe.Ide.Ambiente != Homologacao || nfe.Emit.CRT != SimplesNacional {
+		t.Errorf("unexpected ide: %+v", nfe.Ide)
+	}
+
+	if nfe.Dest != nil {
+		t.Errorf("unexpected dest: %+v", nfe.Dest)
+	}
+
+	if nfe.Emit.Endereco.CEP != "" || nfe.Emit.Endereco.UF != address.RS {
+		t.Errorf("unexpected address: %+v", nfe.Emit.Endereco)
+	}
+
+	if nfe.Itens[0].Valor != 500 || nfe.Itens[0].Impostos.IPI != nil || nfe.Itens[0].Impostos.PIS != nil {
+		t.Errorf("unexpected item: %+v", nfe.Itens[0])
+	}
+
+	if !strings.HasPrefix(nfe.QRCode, "https://www.sefaz.rs.gov.br/NFCE/NFCE-COM.aspx?p=4325") {
+		t.Errorf("unexpected qr code: %s", nfe.QRCode)
+	}
+}
+
+func TestParse_Exportacao(t *testing.T) {
+	data := readTestdata(t, "nfeProc-exportacao.xml")
+
+	nfe, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if nfe.Dest == nil || nfe.Dest.Endereco == nil {
+		t.Fatalf("missing dest: %+v", nfe.Dest)
+	}
+
+	if nfe.Dest.IDEstrangeiro != "A1234567" || nfe.Dest.CPF != "" || nfe.Dest.CNPJ != "" {
+		t.Errorf("unexpected dest: %+v", nfe.Dest)
+	}
+
+	if got := nfe.Dest.Endereco.UF; got != address.ZZ {
+		t.Errorf("\nwant: %s\ngot: %s", address.ZZ, got)
+	}
+
+	if got := nfe.Dest.Endereco.Address().UF; got != 0 {
+		t.Errorf("\nwant: 0\ngot: %d", got)
+	}
+
+	if got := nfe.Itens[0].CFOP; got != "7101" {
+		t.Errorf("\nwant: %s\ngot: %s", "7101", got)
+	}
+
+	t.Run("json", func(t *testing.T) {
+		b, err := json.Marshal(nfe)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got NFe
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(got.Dest, nfe.Dest) {
+			t.Errorf("\nwant: %+v\ngot: %+v", nfe.Dest, got.Dest)
+		}
+	})
+
+	t.Run("emitter abroad", func(t *testing.T) {
+		_, err := Parse([]byte(strings.Replace(data, "<UF>SP</UF>", "<UF>EX</UF>", 1)))
+
+		var fieldErr *FieldError
+		if !errors.As(err, &fieldErr) || fieldErr.Path != "emit/enderEmit/UF" || !errors.Is(err, ErrInvalidField) {
+			t.Errorf("\nwant: %v\ngot: %v", ErrInvalidField, err)
+		}
+	})
+}
+
+func TestParse_Errors(t *testing.T) {
+	data := readTestdata(t, "nfeProc.xml")
+
+	for _, tc := range []struct {
+		name string
+		old  string
+		new  string
+		path string
+		err  error
+	}{
+		{"missing id", ` Id="NFe35240311222333000181550010000012341876543215"`, "", "@Id", ErrMissingField},
+		{"id without prefix", `Id="NFe3524`, `Id="CTe3524`, "@Id", ErrInvalidField},
+		{"invalid chave", `Id="NFe35240311222333000181550010000012341876543215"`, `Id="NFe35240311222333000181550010000012341876543216"`, "@Id", ErrInvalidField},
+		{"version", `<infNFe Id="NFe35240311222333000181550010000012341876543215" versao="4.00">`, `<infNFe Id="NFe35240311222333000181550010000012341876543215" versao="3.10">`, "@versao", ErrInvalidField},
+		{"uf", "<cUF>35</cUF>", "<cUF>33</cUF>", "ide/cUF", ErrChaveMismatch},
+		{"invalid uf", "<cUF>35</cUF>", "<cUF>99</cUF>", "ide/cUF", ErrInvalidField},
+		{"cnf", "<cNF>87654321</cNF>", "<cNF>87654320</cNF>", "ide/cNF", ErrChaveMismatch},
+		{"model", "<mod>55</mod>", "<mod>65</mod>", "ide/mod", ErrChaveMismatch},
+		{"cte model", "<mod>55</mod>", "<mod>57</mod>", "ide/mod", ErrInvalidField},
+		{"serie", "<serie>1</serie>", "<serie>2</serie>", "ide/serie", ErrChaveMismatch},
+		{"number", "<nNF>1234</nNF>", "<nNF>1235</nNF>", "ide/nNF", ErrChaveMismatch},
+		{"missing number", "<nNF>1234</nNF>", "", "ide/nNF", ErrMissingField},
+		{"month", "<dhEmi>2024-03-15", "<dhEmi>2024-04-15", "ide/dhEmi", ErrChaveMismatch},
+		{"date", "<dhEmi>2024-03-15T10:30:00-03:00", "<dhEmi>15/03/2024", "ide/dhEmi", ErrInvalidField},
+		{"emission type", "<tpEmis>1</tpEmis>", "<tpEmis>9</tpEmis>", "ide/tpEmis", ErrChaveMismatch},
+		{"check digit", "<cDV>5</cDV>", "<cDV>4</cDV>", "ide/cDV", ErrChaveMismatch},
+		{"emitter", "<CNPJ>11222333000181</CNPJ>", "<CNPJ>11444777000161</CNPJ>", "emit/CNPJ", ErrChaveMismatch},
+		{"invalid emitter", "<CNPJ>11222333000181</CNPJ>", "<CNPJ>11222333000182</CNPJ>", "emit/CNPJ", ErrInvalidField},
+		{"emitter uf", "<UF>SP</UF>", "<UF>São Paulo</UF>", "emit/enderEmit/UF", ErrInvalidField},
+		{"emitter cep", "<CEP>01310100</CEP>", "<CEP>0131010</CEP>", "emit/enderEmit/CEP", ErrInvalidField},
+		{"dest cpf", "<CPF>12345678909</CPF>", "<CPF>12345678900</CPF>", "dest/CPF", ErrInvalidField},
+		{"dest without document", "<CPF>12345678909</CPF>", "", "dest/CNPJ", ErrMissingField},
+		{"item value", "<vProd>155.00</vProd>", "<vProd>155,00</vProd>", "det[1]/prod/vProd", ErrInvalidField},
+		{"item value decimals", "<vProd>100.00</vProd>", "<vProd>100.001</vProd>", "det[2]/prod/vProd", ErrInvalidField},
+		{"item cfop", "<CFOP>6102</CFOP>", "<CFOP>6100</CFOP>", "det[1]/prod/CFOP", ErrInvalidField},
+		{"item quantity", "<qCom>2.5000</qCom>", "<qCom>2.</qCom>", "det[2]/prod/qCom", ErrInvalidField},
+		{"icms", "<vICMS>27.90</vICMS>\n            </ICMS00>", "<vICMS>-27.90</vICMS>\n            </ICMS00>", "det[1]/imposto/ICMS/ICMS00/vICMS", ErrInvalidField},
+		{"pis", "<pPIS>1.65</pPIS>", "<pPIS>1,65</pPIS>", "det[1]/imposto/PIS/PISAliq/pPIS", ErrInvalidField},
+		{"total", "<vNF>252.75</vNF>", "", "total/ICMSTot/vNF", ErrMissingField},
+		{"protocol", "<chNFe>35240311222333000181550010000012341876543215</chNFe>", "<chNFe>35240311222333000181550010000012351876543212</chNFe>", "protNFe/infProt/chNFe", ErrChaveMismatch},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if !strings.Contains(data, tc.old) {
+				t.Fatalf("testdata does not contain %q", tc.old)
+			}
+
+			_, err := Parse([]byte(strings.Replace(data, tc.old, tc.new, 1)))
+			if !errors.Is(err, ErrInvalidNFe) || !errors.Is(err, tc.err) {
+				t.Fatalf("\nwant: %v\ngot: %v", tc.err, err)
+			}
+
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Path != tc.path {
+				t.Errorf("\nwant path: %s\ngot: %v", tc.path, err)
+			}
+		})
+	}
+}
+
+func TestParse_Malformed(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		data string
+	}{
+		{"empty", ""},
+		{"not xml", "NFe"},
+		{"other document", `<cteProc versao="4.00"></cteProc>`},
+		{"unclosed", `<NFe><infNFe>`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tc.data)); !errors.Is(err, ErrInvalidNFe) {
+				t.Errorf("\nwant: %v\ngot: %v", ErrInvalidNFe, err)
+			}
+		})
+	}
+}
+
+func TestEndereco_Address(t *testing.T) {
+	e := Endereco{
+		Logradouro:  "Avenida Paulista",
+		Numero:      "1000",
+		Complemento: "Sala 1",
+		Bairro:      "Bela Vista",
+		Municipio:   "São Paulo",
+		UF:          address.SP,
+		CEP:         "01310100",
+	}
+
+	want := address.Address{
+		UF:          address.SP,
+		CEP:         "01310100",
+		Localidade:  "São Paulo",
+		Logradouro:  "Avenida Paulista, 1000",
+		Complemento: "Sala 1",
+		Bairro:      "Bela Vista",
+	}
+
+	if got := e.Address(); got != want {
+		t.Errorf("\nwant: %+v\ngot: %+v", want, got)
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	for _, tc := range []struct {
+		d     Decimal
+		valid bool
+		f     float64
+	}{
+		{"1", true, 1},
+		{"1.5000", true, 1.5},
+		{"0.0000000001", true, 0.0000000001},
+		{"1.", false, 0},
+		{".5", false, 0},
+		{"-1", false, 0},
+		{"1,5", false, 0},
+		{"", false, 0},
+	} {
+		if tc.d.IsValid() != tc.valid || tc.d.Float64() != tc.f {
+			t.Errorf("\ndecimal: %s\nwant: %v %v\ngot: %v %v", tc.d, tc.valid, tc.f, tc.d.IsValid(), tc.d.Float64())
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	data := []byte(readTestdata(b, "nfeProc.xml"))
+	b.ReportAllocs()
+	for range b.N {
+		_, _ = Parse(data)
+	}
+}
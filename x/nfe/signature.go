@@ -0,0 +1,442 @@
+package nfe
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/phenpessoa/br"
+	"github.com/phenpessoa/br/x/icpbrasil"
+)
+
+var (
+	// ErrInvalidSignature is matched by every error returned by Verify.
+	ErrInvalidSignature = errors.New("br: invalid nfe signature")
+
+	// ErrDigestMismatch is returned when the infNFe element was changed after it was signed.
+	ErrDigestMismatch = errors.New("br: nfe digest does not match")
+
+	// ErrUntrustedCertificate is returned when the certificate does not chain up to the given roots.
+	ErrUntrustedCertificate = errors.New("br: untrusted nfe certificate")
+)
+
+// The algorithms of XMLDSig accepted by Verify.
+const (
+	nsXMLDSig = "http://www.w3.org/2000/09/xmldsig#"
+
+	algC14N      = "http://www.w3.org/TR/2001/REC-xml-c14n-20010315"
+	algEnveloped = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+	algRSASHA1   = "http://www.w3.org/2000/09/xmldsig#rsa-sha1"
+	algRSASHA256 = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	algSHA1      = "http://www.w3.org/2000/09/xmldsig#sha1"
+	algSHA256    = "http://www.w3.org/2001/04/xmlenc#sha256"
+)
+
+// Signature is a verified signature of an NF-e.
+type Signature struct {
+	// Certificate is the certificate that signed the document.
+	Certificate *x509.Certificate
+
+	// Chains are the chains from the Certificate to the roots given to Verify. It is nil when no
+	// roots are given.
+	Chains [][]*x509.Certificate
+
+	// CNPJ is the CNPJ of the holder of an e-CNPJ certificate, read with icpbrasil.Parse.
+	CNPJ br.CNPJ
+
+	// CPF is the CPF of the holder of an e-CPF certificate, read with icpbrasil.Parse.
+	CPF br.CPF
+}
+
+// VerifyOptions configures Verify.
+type VerifyOptions struct {
+	// Roots are the trusted root certificates, such as the ones of the ICP-Brasil. If nil, the
+	// certificate chain is not validated, and only the integrity of the document is verified.
+	Roots *x509.CertPool
+
+	// Intermediates are the intermediate certificates, in addition to the ones in the signature.
+	Intermediates *x509.CertPool
+
+	// CurrentTime is the time the certificate must be valid at. If zero, the time of emission of the
+	// document is used, so that old documents signed by expired certificates can still be verified.
+	CurrentTime time.Time
+}
+
+// Verify verifies offline the enveloped XMLDSig signature of the XML of an NF-e or an NFC-e, either
+// the NFe element or the nfeProc element.
+//
+// The signature must reference the infNFe element, canonicalized with Canonical XML 1.0, and be
+// signed with RSA-SHA1 or RSA-SHA256 by the certificate of the X509Data. If opts.Roots is not nil,
+// the certificate must also chain up to one of them.
+//
+// The NFe element must have a single infNFe and a single Signature, optionally with an infNFeSupl,
+// and the nfeProc element a single NFe and at most one protNFe. Documents with other or repeated
+// elements are rejected, as Parse could read the fields of an element that was not signed.
+//
+// Verify does not parse the fields of the document; use ParseVerified for that. The CNPJ of the
+// certificate usually shares its first 8 digits with the CNPJ of the emitter, but that is not checked.
+//
+// The returned error matches ErrInvalidSignature.
+func Verify(data []byte, opts VerifyOptions) (Signature, error) {
+	sig, _, err := verify(data, opts)
+	return sig, err
+}
+
+// ParseVerified verifies the signature of the document as Verify does and parses it as Parse does.
+// The fields of the infNFe element are decoded from the exact canonical form whose digest was
+// verified, so they can not come from another element of the document.
+//
+// The Protocolo and the QRCode are read from the protNFe and the infNFeSupl elements, which are not
+// covered by the signature of the emitter.
+//
+// The returned error matches ErrInvalidSignature when the signature is not valid, and ErrInvalidNFe
+// when the document is not.
+func ParseVerified(data []byte, opts VerifyOptions) (NFe, Signature, error) {
+	sig, doc, err := verify(data, opts)
+	if err != nil {
+		return NFe{}, Signature{}, err
+	}
+
+	var proc xmlNFeProc
+	if err := xml.Unmarshal(canonicalize(doc.infNFe, nil), &proc.NFe.InfNFe); err != nil {
+		return NFe{}, Signature{}, fmt.Errorf("%w: %w", ErrInvalidNFe, err)
+	}
+
+	if doc.infNFeSupl != nil {
+		if err := xml.Unmarshal(canonicalize(doc.infNFeSupl, nil), &proc.NFe.InfSupl); err != nil {
+			return NFe{}, Signature{}, fmt.Errorf("%w: %w", ErrInvalidNFe, err)
+		}
+	}
+
+	if doc.protNFe != nil {
+		if err := xml.Unmarshal(canonicalize(doc.protNFe, nil), &proc.ProtNFe); err != nil {
+			return NFe{}, Signature{}, fmt.Errorf("%w: %w", ErrInvalidNFe, err)
+		}
+	}
+
+	nfe, err := build(proc, doc.protNFe != nil)
+	if err != nil {
+		return NFe{}, Signature{}, err
+	}
+
+	return nfe, sig, nil
+}
+
+// signedNFe holds the elements of a document checked by verify.
+type signedNFe struct {
+	// infNFe is the element whose digest was verified.
+	infNFe *xmlNode
+
+	// infNFeSupl and protNFe are nil when the document does not have them.
+	infNFeSupl *xmlNode
+	protNFe    *xmlNode
+}
+
+func verify(data []byte, opts VerifyOptions) (Signature, signedNFe, error) {
+	root, err := parseTree(data)
+	if err != nil {
+		return Signature{}, signedNFe{}, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	var doc signedNFe
+
+	nfe := root
+	if root.name.Local == "nfeProc" {
+		elems, err := elements(root, "NFe", "protNFe")
+		if err != nil {
+			return Signature{}, signedNFe{}, err
+		}
+		nfe, doc.protNFe = elems["NFe"], elems["protNFe"]
+	}
+	if nfe == nil || nfe.name.Local != "NFe" {
+		return Signature{}, signedNFe{}, fmt.Errorf("%w: missing NFe element", ErrInvalidSignature)
+	}
+
+	elems, err := elements(nfe, "infNFe", "infNFeSupl", "Signature")
+	if err != nil {
+		return Signature{}, signedNFe{}, err
+	}
+	doc.infNFe, doc.infNFeSupl = elems["infNFe"], elems["infNFeSupl"]
+
+	infNFe := doc.infNFe
+	if infNFe == nil {
+		return Signature{}, signedNFe{}, fmt.Errorf("%w: missing infNFe element", ErrInvalidSignature)
+	}
+
+	sig := elems["Signature"]
+	if sig == nil || sig.namespace() != nsXMLDSig {
+		return Signature{}, signedNFe{}, fmt.Errorf("%w: missing Signature element", ErrInvalidSignature)
+	}
+
+	signedInfo, err := child(sig, "SignedInfo")
+	if err != nil {
+		return Signature{}, signedNFe{}, err
+	}
+
+	if err := checkAlgorithm(signedInfo, "CanonicalizationMethod", algC14N); err != nil {
+		return Signature{}, signedNFe{}, err
+	}
+
+	signatureHash, err := signatureMethod(signedInfo)
+	if err != nil {
+		return Signature{}, signedNFe{}, err
+	}
+
+	if err := checkDigest(signedInfo, infNFe, sig); err != nil {
+		return Signature{}, signedNFe{}, err
+	}
+
+	certs, err := certificates(sig)
+	if err != nil {
+		return Signature{}, signedNFe{}, err
+	}
+
+	value, err := child(sig, "SignatureValue")
+	if err != nil {
+		return Signature{}, signedNFe{}, err
+	}
+
+	signature, err := decodeBase64(value.text())
+	if err != nil {
+		return Signature{}, signedNFe{}, fmt.Errorf("%w: SignatureValue: %w", ErrInvalidSignature, err)
+	}
+
+	pub, ok := certs[0].PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return Signature{}, signedNFe{}, fmt.Errorf("%w: the certificate does not have an RSA key", ErrInvalidSignature)
+	}
+
+	h := signatureHash.New()
+	h.Write(canonicalize(signedInfo, nil))
+	if err := rsa.VerifyPKCS1v15(pub, signatureHash, h.Sum(nil), signature); err != nil {
+		return Signature{}, signedNFe{}, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	out := Signature{Certificate: certs[0]}
+	if f, err := icpbrasil.Parse(certs[0]); err == nil {
+		out.CNPJ, out.CPF = f.CNPJ, f.Titular.CPF
+	}
+
+	if opts.Roots == nil {
+		return out, doc, nil
+	}
+
+	intermediates := x509.NewCertPool()
+	if opts.Intermediates != nil {
+		intermediates = opts.Intermediates.Clone()
+	}
+	for _, c := range certs[1:] {
+		intermediates.AddCert(c)
+	}
+
+	currentTime := opts.CurrentTime
+	if currentTime.IsZero() {
+		currentTime = emissao(infNFe)
+	}
+
+	out.Chains, err = certs[0].Verify(x509.VerifyOptions{
+		Roots:         opts.Roots,
+		Intermediates: intermediates,
+		CurrentTime:   currentTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return Signature{}, signedNFe{}, fmt.Errorf("%w: %w: %w", ErrInvalidSignature, ErrUntrustedCertificate, err)
+	}
+
+	return out, doc, nil
+}
+
+// elements returns the child elements of n by local name. It fails if n has a child element that is
+// not in allowed or more than one element with the same name.
+func elements(n *xmlNode, allowed ...string) (map[string]*xmlNode, error) {
+	out := make(map[string]*xmlNode, len(allowed))
+	for _, c := range n.children {
+		e, ok := c.(*xmlNode)
+		if !ok {
+			continue
+		}
+
+		if !slices.Contains(allowed, e.name.Local) {
+			return nil, fmt.Errorf("%w: unexpected %s element in %s", ErrInvalidSignature, e.name.Local, n.name.Local)
+		}
+
+		if out[e.name.Local] != nil {
+			return nil, fmt.Errorf("%w: multiple %s elements in %s", ErrInvalidSignature, e.name.Local, n.name.Local)
+		}
+
+		out[e.name.Local] = e
+	}
+	return out, nil
+}
+
+// child returns the child element of the signature with the given local name.
+func child(n *xmlNode, local string) (*xmlNode, error) {
+	c := n.find(local)
+	if c == nil {
+		return nil, fmt.Errorf("%w: missing %s element", ErrInvalidSignature, local)
+	}
+	return c, nil
+}
+
+func checkAlgorithm(n *xmlNode, local string, algorithms ...string) error {
+	c, err := child(n, local)
+	if err != nil {
+		return err
+	}
+
+	alg, _ := c.attr("Algorithm")
+	for _, a := range algorithms {
+		if alg == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: unsupported %s %q", ErrInvalidSignature, local, alg)
+}
+
+func signatureMethod(signedInfo *xmlNode) (crypto.Hash, error) {
+	if err := checkAlgorithm(signedInfo, "SignatureMethod", algRSASHA1, algRSASHA256); err != nil {
+		return 0, err
+	}
+
+	alg, _ := signedInfo.find("SignatureMethod").attr("Algorithm")
+	if alg == algRSASHA256 {
+		return crypto.SHA256, nil
+	}
+	return crypto.SHA1, nil
+}
+
+// checkDigest checks that the only Reference of the SignedInfo points to the infNFe element and that
+// its digest matches.
+func checkDigest(signedInfo, infNFe, sig *xmlNode) error {
+	var ref *xmlNode
+	for _, c := range signedInfo.children {
+		if e, ok := c.(*xmlNode); ok && e.name.Local == "Reference" {
+			if ref != nil {
+				return fmt.Errorf("%w: multiple Reference elements", ErrInvalidSignature)
+			}
+			ref = e
+		}
+	}
+	if ref == nil {
+		return fmt.Errorf("%w: missing Reference element", ErrInvalidSignature)
+	}
+
+	id, _ := infNFe.attr("Id")
+	if uri, _ := ref.attr("URI"); id == "" || uri != "#"+id {
+		return fmt.Errorf("%w: the Reference does not point to the infNFe element", ErrInvalidSignature)
+	}
+
+	if transforms := ref.find("Transforms"); transforms != nil {
+		for _, c := range transforms.children {
+			if e, ok := c.(*xmlNode); ok {
+				if alg, _ := e.attr("Algorithm"); alg != algEnveloped && alg != algC14N {
+					return fmt.Errorf("%w: unsupported Transform %q", ErrInvalidSignature, alg)
+				}
+			}
+		}
+	}
+
+	if err := checkAlgorithm(ref, "DigestMethod", algSHA1, algSHA256); err != nil {
+		return err
+	}
+
+	h := sha1.New()
+	if alg, _ := ref.find("DigestMethod").attr("Algorithm"); alg == algSHA256 {
+		h = sha256.New()
+	}
+	h.Write(canonicalize(infNFe, sig))
+
+	value, err := child(ref, "DigestValue")
+	if err != nil {
+		return err
+	}
+
+	want, err := decodeBase64(value.text())
+	if err != nil {
+		return fmt.Errorf("%w: DigestValue: %w", ErrInvalidSignature, err)
+	}
+
+	if subtle.ConstantTimeCompare(want, h.Sum(nil)) != 1 {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, ErrDigestMismatch)
+	}
+
+	return nil
+}
+
+// certificates returns the certificates of the X509Data. The first one is the signer.
+func certificates(sig *xmlNode) ([]*x509.Certificate, error) {
+	keyInfo, err := child(sig, "KeyInfo")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := child(keyInfo, "X509Data")
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []*x509.Certificate
+	for _, c := range data.children {
+		e, ok := c.(*xmlNode)
+		if !ok || e.name.Local != "X509Certificate" {
+			continue
+		}
+
+		der, err := decodeBase64(e.text())
+		if err != nil {
+			return nil, fmt.Errorf("%w: X509Certificate: %w", ErrInvalidSignature, err)
+		}
+
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("%w: X509Certificate: %w", ErrInvalidSignature, err)
+		}
+
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("%w: missing X509Certificate element", ErrInvalidSignature)
+	}
+
+	return certs, nil
+}
+
+// emissao returns the time of emission of the document, or the zero time if it can not be read.
+func emissao(infNFe *xmlNode) time.Time {
+	ide := infNFe.find("ide")
+	if ide == nil {
+		return time.Time{}
+	}
+
+	dhEmi := ide.find("dhEmi")
+	if dhEmi == nil {
+		return time.Time{}
+	}
+
+	t, _ := time.Parse(time.RFC3339, strings.TrimSpace(dhEmi.text()))
+	return t
+}
+
+// decodeBase64 decodes a base64 value of the XML, which may be broken in lines.
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '\n', '\r':
+			return -1
+		}
+		return r
+	}, s)
+	return base64.StdEncoding.DecodeString(s)
+}
@@ -0,0 +1,218 @@
+package nfe
+
+import (
+	"crypto/x509"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/phenpessoa/br"
+)
+
+func testRoots(t testing.TB) *x509.CertPool {
+	t.Helper()
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM([]byte(readTestdata(t, "root.pem"))) {
+		t.Fatal("failed to read root.pem")
+	}
+	return roots
+}
+
+func TestVerify(t *testing.T) {
+	data := readTestdata(t, "nfeProc-signed.xml")
+
+	sig, err := Verify([]byte(data), VerifyOptions{Roots: testRoots(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := br.CNPJ("11222333000181"); sig.CNPJ != want {
+		t.Errorf("\nwant: %s\ngot: %s", want, sig.CNPJ)
+	}
+
+	// The e-CNPJ holds the CPF of the responsible person in 2.16.76.1.3.4, not in 2.16.76.1.3.1.
+	if sig.CPF != "" {
+		t.Errorf("\nwant: \ngot: %s", sig.CPF)
+	}
+
+	if len(sig.Chains) != 1 || len(sig.Chains[0]) != 2 {
+		t.Errorf("unexpected chains: %v", sig.Chains)
+	}
+
+	// The NFe element alone, as sent to the SEFAZ, is verified too.
+	start := strings.Index(data, "<NFe ")
+	end := strings.Index(data, "</NFe>") + len("</NFe>")
+	if _, err := Verify([]byte(data[start:end]), VerifyOptions{}); err != nil {
+		t.Errorf("NFe element: %v", err)
+	}
+
+	// The protocol is not signed by the emitter.
+	tampered := strings.Replace(data, "<nProt>", "<nProt>9", 1)
+	if _, err := Verify([]byte(tampered), VerifyOptions{}); err != nil {
+		t.Errorf("changed protocol: %v", err)
+	}
+}
+
+func TestVerify_Expired(t *testing.T) {
+	// The certificate expired in 2024-06, after the document was emitted.
+	opts := VerifyOptions{
+		Roots:       testRoots(t),
+		CurrentTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	_, err := Verify([]byte(readTestdata(t, "nfeProc-signed.xml")), opts)
+	if !errors.Is(err, ErrInvalidSignature) || !errors.Is(err, ErrUntrustedCertificate) {
+		t.Errorf("\nwant: %s\ngot: %v", ErrUntrustedCertificate, err)
+	}
+}
+
+// wrapped returns the signed document with a tampered copy of the infNFe element appended to the
+// NFe element, after the signed one.
+func wrapped(t testing.TB) string {
+	t.Helper()
+	data := readTestdata(t, "nfeProc-signed.xml")
+	infNFe := data[strings.Index(data, "<infNFe ") : strings.Index(data, "</infNFe>")+len("</infNFe>")]
+	infNFe = strings.Replace(infNFe, "<vNF>252.75</vNF>", "<vNF>2.75</vNF>", 1)
+	return strings.Replace(data, "</NFe>", infNFe+"</NFe>", 1)
+}
+
+func TestVerify_Errors(t *testing.T) {
+	data := readTestdata(t, "nfeProc-signed.xml")
+	signature := data[strings.Index(data, "<Signature ") : strings.Index(data, "</Signature>")+len("</Signature>")]
+	nfe := data[strings.Index(data, "<NFe ") : strings.Index(data, "</NFe>")+len("</NFe>")]
+
+	for _, tc := range []struct {
+		name string
+		data string
+		err  error
+	}{
+		{
+			name: "changed value",
+			data: strings.Replace(data, "<vNF>252.75</vNF>", "<vNF>2.75</vNF>", 1),
+			err:  ErrDigestMismatch,
+		},
+		{
+			name: "added element",
+			data: strings.Replace(data, "<infCpl>", "<infAdFisco>x</infAdFisco><infCpl>", 1),
+			err:  ErrDigestMismatch,
+		},
+		{
+			name: "changed signed info",
+			data: strings.Replace(data, "<SignedInfo>", "<SignedInfo> ", 1),
+		},
+		{
+			name: "changed signature value",
+			data: strings.Replace(data, "<SignatureValue>", "<SignatureValue>AAAA", 1),
+		},
+		{
+			name: "missing signature",
+			data: readTestdata(t, "nfeProc.xml"),
+		},
+		{
+			name: "other reference",
+			data: strings.Replace(data, `URI="#NFe`, `URI="#NFe0`, 1),
+		},
+		{
+			name: "unsupported algorithm",
+			data: strings.Replace(data, "REC-xml-c14n-20010315", "REC-xml-c14n-20010315#WithComments", 1),
+		},
+		{
+			name: "wrapped infNFe",
+			data: wrapped(t),
+		},
+		{
+			name: "multiple signatures",
+			data: strings.Replace(data, "</NFe>", signature+"</NFe>", 1),
+		},
+		{
+			name: "unexpected element",
+			data: strings.Replace(data, "</NFe>", "<infAdic><infCpl>x</infCpl></infAdic></NFe>", 1),
+		},
+		{
+			name: "multiple NFe elements",
+			data: strings.Replace(data, "<protNFe ", nfe+"<protNFe ", 1),
+		},
+		{
+			name: "malformed",
+			data: data[:len(data)/2],
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Verify([]byte(tc.data), VerifyOptions{})
+			if !errors.Is(err, ErrInvalidSignature) {
+				t.Fatalf("\nwant: %s\ngot: %v", ErrInvalidSignature, err)
+			}
+			if tc.err != nil && !errors.Is(err, tc.err) {
+				t.Errorf("\nwant: %s\ngot: %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestParseVerified(t *testing.T) {
+	data := readTestdata(t, "nfeProc-signed.xml")
+
+	got, sig, err := ParseVerified([]byte(data), VerifyOptions{Roots: testRoots(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := br.CNPJ("11222333000181"); sig.CNPJ != want {
+		t.Errorf("\nwant: %s\ngot: %s", want, sig.CNPJ)
+	}
+
+	want, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\nwant: %+v\ngot: %+v", want, got)
+	}
+
+	if got.Total.NF != 25275 || got.Protocolo == nil {
+		t.Errorf("unexpected document: %+v", got)
+	}
+
+	if _, _, err := ParseVerified([]byte(wrapped(t)), VerifyOptions{}); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("\nwant: %s\ngot: %v", ErrInvalidSignature, err)
+	}
+
+	tampered := strings.Replace(data, "<vNF>252.75</vNF>", "<vNF>2.75</vNF>", 1)
+	if _, _, err := ParseVerified([]byte(tampered), VerifyOptions{}); !errors.Is(err, ErrDigestMismatch) {
+		t.Errorf("\nwant: %s\ngot: %v", ErrDigestMismatch, err)
+	}
+}
+
+func TestVerify_UntrustedRoot(t *testing.T) {
+	roots := x509.NewCertPool()
+	_, err := Verify([]byte(readTestdata(t, "nfeProc-signed.xml")), VerifyOptions{Roots: roots})
+	if !errors.Is(err, ErrUntrustedCertificate) {
+		t.Errorf("\nwant: %s\ngot: %v", ErrUntrustedCertificate, err)
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	const data = `<?xml version="1.0"?>
+<a:root xmlns:a="urn:a" xmlns="urn:d" xmlns:b="urn:b"><x b:z="1" y='"&amp;' a:w="2">t&amp;&gt;<![CDATA[<c>]]><!-- c --><e xmlns="urn:d" xmlns:b="urn:b"/><f xmlns=""/></x></a:root>`
+
+	root, err := parseTree([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<x xmlns="urn:d" xmlns:a="urn:a" xmlns:b="urn:b" y="&quot;&amp;" a:w="2" b:z="1">t&amp;&gt;&lt;c&gt;<e></e><f xmlns=""></f></x>`
+	if got := string(canonicalize(root.find("x"), nil)); got != want {
+		t.Errorf("\nwant: %s\ngot: %s", want, got)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	data := []byte(readTestdata(b, "nfeProc-signed.xml"))
+	b.ReportAllocs()
+	for range b.N {
+		_, _ = Verify(data, VerifyOptions{})
+	}
+}
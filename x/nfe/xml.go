@@ -0,0 +1,220 @@
+package nfe
+
+import "encoding/xml"
+
+// The structs of this file mirror the schema of the layout 4.00. Every value is read as a string
+// and converted to the exported types by Parse, so that invalid values are reported with their path.
+
+type xmlNFeProc struct {
+	Versao  string     `xml:"versao,attr"`
+	NFe     xmlNFe     `xml:"NFe"`
+	ProtNFe xmlProtNFe `xml:"protNFe"`
+}
+
+type xmlNFe struct {
+	XMLName xml.Name   `xml:"NFe"`
+	InfNFe  xmlInfNFe  `xml:"infNFe"`
+	InfSupl xmlInfSupl `xml:"infNFeSupl"`
+}
+
+type xmlInfSupl struct {
+	QRCode   string `xml:"qrCode"`
+	URLChave string `xml:"urlChave"`
+}
+
+type xmlInfNFe struct {
+	ID      string      `xml:"Id,attr"`
+	Versao  string      `xml:"versao,attr"`
+	Ide     xmlIde      `xml:"ide"`
+	Emit    xmlEmit     `xml:"emit"`
+	Dest    *xmlDest    `xml:"dest"`
+	Det     []xmlDet    `xml:"det"`
+	Total   xmlTotal    `xml:"total"`
+	InfAdic *xmlInfAdic `xml:"infAdic"`
+}
+
+type xmlIde struct {
+	CUF      string `xml:"cUF"`
+	CNF      string `xml:"cNF"`
+	NatOp    string `xml:"natOp"`
+	Mod      string `xml:"mod"`
+	Serie    string `xml:"serie"`
+	NNF      string `xml:"nNF"`
+	DhEmi    string `xml:"dhEmi"`
+	DhSaiEnt string `xml:"dhSaiEnt"`
+	TpNF     string `xml:"tpNF"`
+	IdDest   string `xml:"idDest"`
+	CMunFG   string `xml:"cMunFG"`
+	TpImp    string `xml:"tpImp"`
+	TpEmis   string `xml:"tpEmis"`
+	CDV      string `xml:"cDV"`
+	TpAmb    string `xml:"tpAmb"`
+	FinNFe   string `xml:"finNFe"`
+	IndFinal string `xml:"indFinal"`
+	IndPres  string `xml:"indPres"`
+	ProcEmi  string `xml:"procEmi"`
+	VerProc  string `xml:"verProc"`
+}
+
+type xmlEmit struct {
+	CNPJ      string       `xml:"CNPJ"`
+	CPF       string       `xml:"CPF"`
+	XNome     string       `xml:"xNome"`
+	XFant     string       `xml:"xFant"`
+	EnderEmit *xmlEndereco `xml:"enderEmit"`
+	IE        string       `xml:"IE"`
+	IEST      string       `xml:"IEST"`
+	IM        string       `xml:"IM"`
+	CNAE      string       `xml:"CNAE"`
+	CRT       string       `xml:"CRT"`
+}
+
+type xmlDest struct {
+	CNPJ          string       `xml:"CNPJ"`
+	CPF           string       `xml:"CPF"`
+	IDEstrangeiro *string      `xml:"idEstrangeiro"`
+	XNome         string       `xml:"xNome"`
+	EnderDest     *xmlEndereco `xml:"enderDest"`
+	IndIEDest     string       `xml:"indIEDest"`
+	IE            string       `xml:"IE"`
+	ISUF          string       `xml:"ISUF"`
+	IM            string       `xml:"IM"`
+	Email         string       `xml:"email"`
+}
+
+type xmlEndereco struct {
+	XLgr    string `xml:"xLgr"`
+	Nro     string `xml:"nro"`
+	XCpl    string `xml:"xCpl"`
+	XBairro string `xml:"xBairro"`
+	CMun    string `xml:"cMun"`
+	XMun    string `xml:"xMun"`
+	UF      string `xml:"UF"`
+	CEP     string `xml:"CEP"`
+	CPais   string `xml:"cPais"`
+	XPais   string `xml:"xPais"`
+	Fone    string `xml:"fone"`
+}
+
+type xmlDet struct {
+	NItem     string     `xml:"nItem,attr"`
+	Prod      xmlProd    `xml:"prod"`
+	Imposto   xmlImposto `xml:"imposto"`
+	InfAdProd string     `xml:"infAdProd"`
+}
+
+type xmlProd struct {
+	CProd    string `xml:"cProd"`
+	CEAN     string `xml:"cEAN"`
+	XProd    string `xml:"xProd"`
+	NCM      string `xml:"NCM"`
+	CEST     string `xml:"CEST"`
+	CFOP     string `xml:"CFOP"`
+	UCom     string `xml:"uCom"`
+	QCom     string `xml:"qCom"`
+	VUnCom   string `xml:"vUnCom"`
+	VProd    string `xml:"vProd"`
+	CEANTrib string `xml:"cEANTrib"`
+	UTrib    string `xml:"uTrib"`
+	QTrib    string `xml:"qTrib"`
+	VUnTrib  string `xml:"vUnTrib"`
+	VFrete   string `xml:"vFrete"`
+	VSeg     string `xml:"vSeg"`
+	VDesc    string `xml:"vDesc"`
+	VOutro   string `xml:"vOutro"`
+	IndTot   string `xml:"indTot"`
+	XPed     string `xml:"xPed"`
+	NItemPed string `xml:"nItemPed"`
+}
+
+type xmlImposto struct {
+	VTotTrib string    `xml:"vTotTrib"`
+	ICMS     *xmlGroup `xml:"ICMS"`
+	IPI      *xmlIPI   `xml:"IPI"`
+	PIS      *xmlGroup `xml:"PIS"`
+	COFINS   *xmlGroup `xml:"COFINS"`
+}
+
+// xmlGroup is a tax whose values are inside a single child named after its CST, such as ICMS00,
+// ICMSSN102 or PISAliq.
+type xmlGroup struct {
+	Group xmlTax `xml:",any"`
+}
+
+type xmlIPI struct {
+	CEnq    string  `xml:"cEnq"`
+	IPITrib *xmlTax `xml:"IPITrib"`
+	IPINT   *xmlTax `xml:"IPINT"`
+}
+
+type xmlTax struct {
+	XMLName xml.Name
+	Orig    string `xml:"orig"`
+	CST     string `xml:"CST"`
+	CSOSN   string `xml:"CSOSN"`
+	ModBC   string `xml:"modBC"`
+	VBC     string `xml:"vBC"`
+	PRedBC  string `xml:"pRedBC"`
+	PICMS   string `xml:"pICMS"`
+	VICMS   string `xml:"vICMS"`
+	PFCP    string `xml:"pFCP"`
+	VFCP    string `xml:"vFCP"`
+	ModBCST string `xml:"modBCST"`
+	PMVAST  string `xml:"pMVAST"`
+	VBCST   string `xml:"vBCST"`
+	PICMSST string `xml:"pICMSST"`
+	VICMSST string `xml:"vICMSST"`
+	PCredSN string `xml:"pCredSN"`
+	VCredSN string `xml:"vCredICMSSN"`
+	PIPI    string `xml:"pIPI"`
+	VIPI    string `xml:"vIPI"`
+	PPIS    string `xml:"pPIS"`
+	VPIS    string `xml:"vPIS"`
+	PCOFINS string `xml:"pCOFINS"`
+	VCOFINS string `xml:"vCOFINS"`
+}
+
+type xmlTotal struct {
+	ICMSTot xmlICMSTot `xml:"ICMSTot"`
+}
+
+type xmlICMSTot struct {
+	VBC        string `xml:"vBC"`
+	VICMS      string `xml:"vICMS"`
+	VICMSDeson string `xml:"vICMSDeson"`
+	VFCP       string `xml:"vFCP"`
+	VBCST      string `xml:"vBCST"`
+	VST        string `xml:"vST"`
+	VFCPST     string `xml:"vFCPST"`
+	VProd      string `xml:"vProd"`
+	VFrete     string `xml:"vFrete"`
+	VSeg       string `xml:"vSeg"`
+	VDesc      string `xml:"vDesc"`
+	VII        string `xml:"vII"`
+	VIPI       string `xml:"vIPI"`
+	VPIS       string `xml:"vPIS"`
+	VCOFINS    string `xml:"vCOFINS"`
+	VOutro     string `xml:"vOutro"`
+	VNF        string `xml:"vNF"`
+	VTotTrib   string `xml:"vTotTrib"`
+}
+
+type xmlInfAdic struct {
+	InfAdFisco string `xml:"infAdFisco"`
+	InfCpl     string `xml:"infCpl"`
+}
+
+type xmlProtNFe struct {
+	InfProt *xmlInfProt `xml:"infProt"`
+}
+
+type xmlInfProt struct {
+	TpAmb    string `xml:"tpAmb"`
+	VerAplic string `xml:"verAplic"`
+	ChNFe    string `xml:"chNFe"`
+	DhRecbto string `xml:"dhRecbto"`
+	NProt    string `xml:"nProt"`
+	DigVal   string `xml:"digVal"`
+	CStat    string `xml:"cStat"`
+	XMotivo  string `xml:"xMotivo"`
+}
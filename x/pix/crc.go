@@ -0,0 +1,20 @@
+package pix
+
+// crc16 calculates the CRC16-CCITT of s, with polynomial 0x1021 and initial value 0xFFFF,
+// formatted as 4 uppercase hexadecimal digits.
+func crc16(s string) string {
+	crc := uint16(0xFFFF)
+	for i := range len(s) {
+		crc ^= uint16(s[i]) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	const hex = "0123456789ABCDEF"
+	return string([]byte{hex[crc>>12], hex[crc>>8&0xF], hex[crc>>4&0xF], hex[crc&0xF]})
+}
@@ -0,0 +1,537 @@
+// Package pix provides functions for building and parsing Pix BR Codes, the EMV-MPM payloads
+// used by static and dynamic Pix QR codes and by the "Pix Copia e Cola".
+package pix
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/phenpessoa/br"
+	"github.com/phenpessoa/br/x/address"
+	"github.com/phenpessoa/br/x/qrcode"
+)
+
+var (
+	// ErrInvalidBRCode is matched by every error returned by this package.
+	ErrInvalidBRCode = errors.New("br: invalid pix br code")
+
+	// ErrMissingField is returned when a mandatory field is absent.
+	ErrMissingField = errors.New("br: missing pix br code field")
+
+	// ErrInvalidField is returned when the value of a field is not allowed.
+	ErrInvalidField = errors.New("br: invalid pix br code field")
+)
+
+// CRCError is the error returned when the CRC of a BR Code does not match its contents.
+type CRCError struct {
+	// Expected is the CRC calculated over the BR Code.
+	Expected string
+
+	// Actual is the CRC present in the BR Code.
+	Actual string
+}
+
+func (e *CRCError) Error() string {
+	return "br: invalid pix br code crc: expected " + e.Expected + ", got " + e.Actual
+}
+
+func (e *CRCError) Unwrap() error {
+	return ErrInvalidBRCode
+}
+
+// TLVError is the error returned when a BR Code is not a well formed sequence of
+// ID, length and value fields.
+type TLVError struct {
+	// Offset is the position in the BR Code where the malformed field starts.
+	Offset int
+
+	// ID is the ID of the malformed field, if it could be read.
+	ID string
+}
+
+func (e *TLVError) Error() string {
+	if e.ID == "" {
+		return fmt.Sprintf("br: malformed pix br code at offset %d", e.Offset)
+	}
+	return fmt.Sprintf("br: malformed pix br code field %s at offset %d", e.ID, e.Offset)
+}
+
+func (e *TLVError) Unwrap() error {
+	return ErrInvalidBRCode
+}
+
+// FieldError is the error returned when a field of a BR Code is missing or has an invalid value.
+//
+// It matches ErrInvalidBRCode and Err with errors.Is.
+type FieldError struct {
+	// ID is the ID of the field, such as "59". Fields of templates are joined by a dot, such as "26.01".
+	ID string
+
+	// Err is either ErrMissingField or ErrInvalidField.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error() + " " + e.ID
+}
+
+func (e *FieldError) Unwrap() []error {
+	return []error{ErrInvalidBRCode, e.Err}
+}
+
+// The IDs of the fields of a BR Code.
+const (
+	idPayloadFormatIndicator      = "00"
+	idPointOfInitiationMethod     = "01"
+	idMerchantAccountInformation  = "26"
+	idMerchantCategoryCode        = "52"
+	idTransactionCurrency         = "53"
+	idTransactionAmount           = "54"
+	idCountryCode                 = "58"
+	idMerchantName                = "59"
+	idMerchantCity                = "60"
+	idPostalCode                  = "61"
+	idAdditionalDataFieldTemplate = "62"
+	idCRC                         = "63"
+
+	idMerchantAccountGUI         = "00"
+	idMerchantAccountKey         = "01"
+	idMerchantAccountDescription = "02"
+	idMerchantAccountURL         = "25"
+
+	idAdditionalDataTxID = "05"
+)
+
+const (
+	payloadFormatIndicator = "01"
+	pointOfInitiationOnce  = "12"
+	gui                    = "br.gov.bcb.pix"
+	currencyBRL            = "986"
+	countryBR              = "BR"
+	noTxID                 = "***"
+)
+
+// The maximum lengths of the fields of a BR Code.
+const (
+	maxFieldLen        = 99
+	maxMerchantNameLen = 25
+	maxMerchantCityLen = 15
+	maxTxIDLen         = 25
+	maxAmountLen       = 13
+)
+
+// BRCode holds the data of a Pix BR Code.
+//
+// A static BR Code has a Key, while a dynamic one has the URL of its payload instead.
+type BRCode struct {
+	// Key is the Pix key that receives the payment of a static BR Code.
+	Key br.PixKey
+
+	// URL is the location of the payload of a dynamic BR Code, without the https:// scheme.
+	URL string
+
+	// Description is an optional message to the payer of a static BR Code.
+	Description string
+
+	// Amount is the amount to be paid, in centavos. A zero Amount lets the payer choose it.
+	Amount int64
+
+	// MerchantName is the name of the receiver, with up to 25 characters.
+	MerchantName string
+
+	// MerchantCity is the city of the receiver, with up to 15 characters.
+	MerchantCity string
+
+	// MerchantCategoryCode is the ISO 18245 category of the receiver. It defaults to 0000.
+	MerchantCategoryCode string
+
+	// PostalCode is the optional CEP of the receiver.
+	PostalCode address.CEP
+
+	// TxID identifies the transaction, with up to 25 alphanumeric characters.
+	// It defaults to ***, which means no identifier.
+	TxID string
+
+	// OneTime reports whether the BR Code can be paid only once.
+	OneTime bool
+}
+
+// IsDynamic reports whether the BR Code is dynamic, that is, whether its payload is fetched from URL.
+func (c BRCode) IsDynamic() bool {
+	return c.URL != ""
+}
+
+// Encode builds the BR Code payload, the string encoded in the QR code and used by "Pix Copia e Cola".
+//
+// The returned error is always a *FieldError.
+func (c BRCode) Encode() (string, error) {
+	account, err := c.merchantAccount()
+	if err != nil {
+		return "", err
+	}
+
+	mcc := c.MerchantCategoryCode
+	if mcc == "" {
+		mcc = "0000"
+	}
+
+	if len(mcc) != 4 || !isNumeric(mcc) {
+		return "", &FieldError{ID: idMerchantCategoryCode, Err: ErrInvalidField}
+	}
+
+	if c.Amount < 0 {
+		return "", &FieldError{ID: idTransactionAmount, Err: ErrInvalidField}
+	}
+
+	if err := checkText(idMerchantName, c.MerchantName, maxMerchantNameLen); err != nil {
+		return "", err
+	}
+
+	if err := checkText(idMerchantCity, c.MerchantCity, maxMerchantCityLen); err != nil {
+		return "", err
+	}
+
+	if c.PostalCode != "" && !c.PostalCode.IsValid() {
+		return "", &FieldError{ID: idPostalCode, Err: ErrInvalidField}
+	}
+
+	txid := c.TxID
+	switch {
+	case txid == "":
+		txid = noTxID
+	case txid == noTxID:
+	case len(txid) > maxTxIDLen || !isAlphaNumeric(txid):
+		return "", &FieldError{ID: idAdditionalDataFieldTemplate + "." + idAdditionalDataTxID, Err: ErrInvalidField}
+	}
+
+	var sb strings.Builder
+	writeField(&sb, idPayloadFormatIndicator, payloadFormatIndicator)
+	if c.OneTime {
+		writeField(&sb, idPointOfInitiationMethod, pointOfInitiationOnce)
+	}
+	writeField(&sb, idMerchantAccountInformation, account)
+	writeField(&sb, idMerchantCategoryCode, mcc)
+	writeField(&sb, idTransactionCurrency, currencyBRL)
+	if c.Amount > 0 {
+		amount := formatAmount(c.Amount)
+		if len(amount) > maxAmountLen {
+			return "", &FieldError{ID: idTransactionAmount, Err: ErrInvalidField}
+		}
+		writeField(&sb, idTransactionAmount, amount)
+	}
+	writeField(&sb, idCountryCode, countryBR)
+	writeField(&sb, idMerchantName, c.MerchantName)
+	writeField(&sb, idMerchantCity, c.MerchantCity)
+	if c.PostalCode != "" {
+		writeField(&sb, idPostalCode, strings.Replace(c.PostalCode.String(), "-", "", 1))
+	}
+	writeField(&sb, idAdditionalDataFieldTemplate, idAdditionalDataTxID+fieldLen(txid)+txid)
+
+	sb.WriteString(idCRC + "04")
+	sb.WriteString(crc16(sb.String()))
+
+	return sb.String(), nil
+}
+
+// QRCode encodes the BR Code payload into a QR code with the given error correction level,
+// ready to be rendered with QRCode.Image or QRCode.SVG.
+func (c BRCode) QRCode(level qrcode.Level) (*qrcode.QRCode, error) {
+	s, err := c.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return qrcode.Encode(s, level)
+}
+
+// merchantAccount builds the value of the merchant account information template.
+func (c BRCode) merchantAccount() (string, error) {
+	id := func(sub string) string {
+		return idMerchantAccountInformation + "." + sub
+	}
+
+	var sb strings.Builder
+	writeField(&sb, idMerchantAccountGUI, gui)
+
+	switch {
+	case c.Key != "" && c.URL != "":
+		return "", &FieldError{ID: id(idMerchantAccountURL), Err: ErrInvalidField}
+	case c.Key != "":
+		key := c.Key.String()
+		if key == "" {
+			return "", &FieldError{ID: id(idMerchantAccountKey), Err: ErrInvalidField}
+		}
+		writeField(&sb, idMerchantAccountKey, key)
+	case c.URL != "":
+		if c.Description != "" {
+			return "", &FieldError{ID: id(idMerchantAccountDescription), Err: ErrInvalidField}
+		}
+		url := strings.TrimPrefix(c.URL, "https://")
+		if !isPrintable(url) || strings.Contains(url, "://") {
+			return "", &FieldError{ID: id(idMerchantAccountURL), Err: ErrInvalidField}
+		}
+		writeField(&sb, idMerchantAccountURL, url)
+	default:
+		return "", &FieldError{ID: id(idMerchantAccountKey), Err: ErrMissingField}
+	}
+
+	if c.Description != "" {
+		if !isPrintable(c.Description) {
+			return "", &FieldError{ID: id(idMerchantAccountDescription), Err: ErrInvalidField}
+		}
+		writeField(&sb, idMerchantAccountDescription, c.Description)
+	}
+
+	if sb.Len() > maxFieldLen {
+		return "", &FieldError{ID: idMerchantAccountInformation, Err: ErrInvalidField}
+	}
+
+	return sb.String(), nil
+}
+
+// Parse parses a BR Code payload, verifying its CRC.
+//
+// The returned error is a *CRCError, a *TLVError or a *FieldError.
+func Parse(s string) (BRCode, error) {
+	fields, err := parseTLV(s, 0)
+	if err != nil {
+		return BRCode{}, err
+	}
+
+	if len(fields) == 0 || fields[0].id != idPayloadFormatIndicator {
+		return BRCode{}, &FieldError{ID: idPayloadFormatIndicator, Err: ErrMissingField}
+	}
+
+	if last := fields[len(fields)-1]; last.id != idCRC {
+		return BRCode{}, &FieldError{ID: idCRC, Err: ErrMissingField}
+	} else if len(last.value) != 4 {
+		return BRCode{}, &FieldError{ID: idCRC, Err: ErrInvalidField}
+	} else if expected := crc16(s[:len(s)-4]); !strings.EqualFold(expected, last.value) {
+		return BRCode{}, &CRCError{Expected: expected, Actual: last.value}
+	}
+
+	var (
+		c                                         BRCode
+		hasAccount, hasCurrency, hasName, hasCity bool
+	)
+
+	for _, f := range fields {
+		switch f.id {
+		case idPayloadFormatIndicator:
+			if f.value != payloadFormatIndicator {
+				return BRCode{}, &FieldError{ID: f.id, Err: ErrInvalidField}
+			}
+		case idPointOfInitiationMethod:
+			c.OneTime = f.value == pointOfInitiationOnce
+		case idMerchantCategoryCode:
+			c.MerchantCategoryCode = f.value
+		case idTransactionCurrency:
+			if f.value != currencyBRL {
+				return BRCode{}, &FieldError{ID: f.id, Err: ErrInvalidField}
+			}
+			hasCurrency = true
+		case idTransactionAmount:
+			amount, ok := parseAmount(f.value)
+			if !ok {
+				return BRCode{}, &FieldError{ID: f.id, Err: ErrInvalidField}
+			}
+			c.Amount = amount
+		case idCountryCode:
+			if f.value != countryBR {
+				return BRCode{}, &FieldError{ID: f.id, Err: ErrInvalidField}
+			}
+		case idMerchantName:
+			c.MerchantName = f.value
+			hasName = true
+		case idMerchantCity:
+			c.MerchantCity = f.value
+			hasCity = true
+		case idPostalCode:
+			c.PostalCode = address.CEP(f.value)
+		case idAdditionalDataFieldTemplate:
+			sub, err := parseTLV(f.value, f.offset+4)
+			if err != nil {
+				return BRCode{}, err
+			}
+			for _, sf := range sub {
+				if sf.id == idAdditionalDataTxID {
+					c.TxID = sf.value
+				}
+			}
+		default:
+			// Merchant account information templates go from 26 to 51 and may belong to other arrangements.
+			if f.id < idMerchantAccountInformation || f.id > "51" || hasAccount {
+				continue
+			}
+
+			ok, err := c.parseMerchantAccount(f)
+			if err != nil {
+				return BRCode{}, err
+			}
+			hasAccount = ok
+		}
+	}
+
+	switch {
+	case !hasAccount:
+		return BRCode{}, &FieldError{ID: idMerchantAccountInformation, Err: ErrMissingField}
+	case c.MerchantCategoryCode == "":
+		return BRCode{}, &FieldError{ID: idMerchantCategoryCode, Err: ErrMissingField}
+	case !hasCurrency:
+		return BRCode{}, &FieldError{ID: idTransactionCurrency, Err: ErrMissingField}
+	case !hasName:
+		return BRCode{}, &FieldError{ID: idMerchantName, Err: ErrMissingField}
+	case !hasCity:
+		return BRCode{}, &FieldError{ID: idMerchantCity, Err: ErrMissingField}
+	}
+
+	return c, nil
+}
+
+// parseMerchantAccount reads a merchant account information template into c,
+// reporting whether it belongs to Pix.
+func (c *BRCode) parseMerchantAccount(f field) (bool, error) {
+	sub, err := parseTLV(f.value, f.offset+4)
+	if err != nil {
+		return false, err
+	}
+
+	if len(sub) == 0 || sub[0].id != idMerchantAccountGUI || !strings.EqualFold(sub[0].value, gui) {
+		return false, nil
+	}
+
+	for _, sf := range sub[1:] {
+		switch sf.id {
+		case idMerchantAccountKey:
+			c.Key = br.PixKey(sf.value)
+			if !c.Key.IsValid() {
+				return false, &FieldError{ID: f.id + "." + sf.id, Err: ErrInvalidField}
+			}
+		case idMerchantAccountDescription:
+			c.Description = sf.value
+		case idMerchantAccountURL:
+			c.URL = sf.value
+		}
+	}
+
+	if c.Key == "" && c.URL == "" {
+		return false, &FieldError{ID: f.id + "." + idMerchantAccountKey, Err: ErrMissingField}
+	}
+
+	return true, nil
+}
+
+type field struct {
+	id     string
+	value  string
+	offset int
+}
+
+// parseTLV splits s into its fields. Offset is the position of s in the whole BR Code, used in errors.
+func parseTLV(s string, offset int) ([]field, error) {
+	var fields []field
+
+	for i := 0; i < len(s); {
+		if len(s)-i < 4 || !isNumeric(s[i:i+4]) {
+			var id string
+			if len(s)-i >= 2 {
+				id = s[i : i+2]
+			}
+			return nil, &TLVError{Offset: offset + i, ID: id}
+		}
+
+		id := s[i : i+2]
+		n, _ := strconv.Atoi(s[i+2 : i+4])
+		if len(s)-i-4 < n {
+			return nil, &TLVError{Offset: offset + i, ID: id}
+		}
+
+		fields = append(fields, field{id: id, value: s[i+4 : i+4+n], offset: offset + i})
+		i += 4 + n
+	}
+
+	return fields, nil
+}
+
+func writeField(sb *strings.Builder, id, value string) {
+	sb.WriteString(id)
+	sb.WriteString(fieldLen(value))
+	sb.WriteString(value)
+}
+
+func fieldLen(value string) string {
+	return string([]byte{byte(len(value)/10) + '0', byte(len(value)%10) + '0'})
+}
+
+// checkText makes sure a mandatory text field is present, printable and not too long.
+func checkText(id, value string, maxLen int) error {
+	switch {
+	case value == "":
+		return &FieldError{ID: id, Err: ErrMissingField}
+	case len(value) > maxLen || !isPrintable(value):
+		return &FieldError{ID: id, Err: ErrInvalidField}
+	default:
+		return nil
+	}
+}
+
+// formatAmount formats an amount in centavos with 2 decimal places, such as 1234.50.
+func formatAmount(amount int64) string {
+	return fmt.Sprintf("%d.%02d", amount/100, amount%100)
+}
+
+// parseAmount parses an amount with up to 2 decimal places, such as 10, 10.5 or 10.50, into centavos.
+func parseAmount(s string) (int64, bool) {
+	if s == "" || len(s) > maxAmountLen {
+		return 0, false
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" || len(frac) > 2 || !isNumeric(whole) || !isNumeric(frac) {
+		return 0, false
+	}
+
+	var amount int64
+	for i := range len(whole) {
+		amount = amount*10 + int64(whole[i]-'0')
+	}
+
+	for i := range 2 {
+		amount *= 10
+		if i < len(frac) {
+			amount += int64(frac[i] - '0')
+		}
+	}
+
+	return amount, amount > 0
+}
+
+func isNumeric(s string) bool {
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphaNumeric(s string) bool {
+	for i := range len(s) {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// isPrintable checks whether s only has printable ASCII characters, as required by EMV.
+func isPrintable(s string) bool {
+	for i := range len(s) {
+		if s[i] < ' ' || s[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
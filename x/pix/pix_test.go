@@ -0,0 +1,271 @@
+package pix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/phenpessoa/br"
+	"github.com/phenpessoa/br/x/qrcode"
+)
+
+// bcbExample is the static BR Code example of the Manual de Padrões para Iniciação do Pix.
+const bcbExample = "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+
+func BenchmarkParse(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		_, _ = Parse(bcbExample)
+	}
+}
+
+func TestParse(t *testing.T) {
+	c, err := Parse(bcbExample)
+	if err != nil {
+		t.Fatalf("failed to parse br code: %v", err)
+	}
+
+	want := BRCode{
+		Key:                  "123e4567-e12b-12d1-a456-426655440000",
+		MerchantName:         "Fulano de Tal",
+		MerchantCity:         "BRASILIA",
+		MerchantCategoryCode: "0000",
+		TxID:                 "***",
+	}
+
+	if c != want {
+		t.Errorf("\nwant: %+v\ngot: %+v", want, c)
+	}
+
+	if c.IsDynamic() {
+		t.Error("static br code reported as dynamic")
+	}
+
+	got, err := c.Encode()
+	if err != nil {
+		t.Fatalf("failed to encode br code: %v", err)
+	}
+
+	if got != bcbExample {
+		t.Errorf("\nwant: %s\ngot: %s", bcbExample, got)
+	}
+}
+
+func TestBRCode_Encode(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		code BRCode
+		url  string
+	}{
+		{
+			name: "static with amount and description",
+			code: BRCode{
+				Key:          "+55 (11) 91234-5678",
+				Description:  "Pedido 42",
+				Amount:       123450,
+				MerchantName: "Fulano de Tal",
+				MerchantCity: "SAO PAULO",
+				PostalCode:   "01310-100",
+				TxID:         "PEDIDO42",
+			},
+		},
+		{
+			name: "static with cpf key",
+			code: BRCode{
+				Key:          "529.982.247-25",
+				Amount:       1,
+				MerchantName: "Fulano de Tal",
+				MerchantCity: "BRASILIA",
+			},
+		},
+		{
+			name: "dynamic",
+			code: BRCode{
+				URL:          "https://pix.example.com/qr/v2/9d36b84fc70b478fb95c12729b90ca25",
+				MerchantName: "Fulano de Tal",
+				MerchantCity: "BRASILIA",
+				OneTime:      true,
+			},
+			url: "pix.example.com/qr/v2/9d36b84fc70b478fb95c12729b90ca25",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := tc.code.Encode()
+			if err != nil {
+				t.Fatalf("failed to encode br code: %v", err)
+			}
+
+			c, err := Parse(s)
+			if err != nil {
+				t.Fatalf("failed to parse encoded br code %s: %v", s, err)
+			}
+
+			if c.Key.String() != tc.code.Key.String() ||
+				c.URL != tc.url ||
+				c.Description != tc.code.Description ||
+				c.Amount != tc.code.Amount ||
+				c.MerchantName != tc.code.MerchantName ||
+				c.MerchantCity != tc.code.MerchantCity ||
+				c.PostalCode.String() != tc.code.PostalCode.String() ||
+				c.OneTime != tc.code.OneTime ||
+				c.IsDynamic() != tc.code.IsDynamic() {
+				t.Errorf("\nencoded: %+v\nparsed: %+v", tc.code, c)
+			}
+		})
+	}
+}
+
+func TestBRCode_EncodeErrors(t *testing.T) {
+	valid := BRCode{
+		Key:          br.PixKey("fulano@example.com"),
+		MerchantName: "Fulano de Tal",
+		MerchantCity: "BRASILIA",
+	}
+
+	for _, tc := range []struct {
+		name string
+		edit func(c *BRCode)
+		id   string
+		err  error
+	}{
+		{name: "no key", edit: func(c *BRCode) { c.Key = "" }, id: "26.01", err: ErrMissingField},
+		{name: "invalid key", edit: func(c *BRCode) { c.Key = "11912345678" }, id: "26.01", err: ErrInvalidField},
+		{name: "key and url", edit: func(c *BRCode) { c.URL = "pix.example.com/qr" }, id: "26.25", err: ErrInvalidField},
+		{name: "account too long", edit: func(c *BRCode) { c.Description = string(make([]byte, 70)) }, id: "26.02", err: ErrInvalidField},
+		{name: "negative amount", edit: func(c *BRCode) { c.Amount = -1 }, id: "54", err: ErrInvalidField},
+		{name: "no name", edit: func(c *BRCode) { c.MerchantName = "" }, id: "59", err: ErrMissingField},
+		{name: "name too long", edit: func(c *BRCode) { c.MerchantName = "Fulano de Tal da Silva Sauro" }, id: "59", err: ErrInvalidField},
+		{name: "accented city", edit: func(c *BRCode) { c.MerchantCity = "SÃO PAULO" }, id: "60", err: ErrInvalidField},
+		{name: "invalid txid", edit: func(c *BRCode) { c.TxID = "pedido-42" }, id: "62.05", err: ErrInvalidField},
+		{name: "invalid mcc", edit: func(c *BRCode) { c.MerchantCategoryCode = "12" }, id: "52", err: ErrInvalidField},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := valid
+			tc.edit(&c)
+
+			_, err := c.Encode()
+
+			var fe *FieldError
+			if !errors.As(err, &fe) {
+				t.Fatalf("expected a *FieldError, got %v", err)
+			}
+
+			if fe.ID != tc.id || !errors.Is(err, tc.err) || !errors.Is(err, ErrInvalidBRCode) {
+				t.Errorf("\nwant: %s %v\ngot: %s %v", tc.id, tc.err, fe.ID, fe.Err)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	withCRC := func(s string) string {
+		s += "6304"
+		return s + crc16(s)
+	}
+
+	t.Run("crc", func(t *testing.T) {
+		_, err := Parse(bcbExample[:len(bcbExample)-4] + "0000")
+
+		var ce *CRCError
+		if !errors.As(err, &ce) {
+			t.Fatalf("expected a *CRCError, got %v", err)
+		}
+
+		if ce.Expected != "1D3D" || ce.Actual != "0000" || !errors.Is(err, ErrInvalidBRCode) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("lowercase crc", func(t *testing.T) {
+		if _, err := Parse(bcbExample[:len(bcbExample)-4] + "1d3d"); err != nil {
+			t.Errorf("failed to parse br code with lowercase crc: %v", err)
+		}
+	})
+
+	for _, tc := range []struct {
+		name   string
+		s      string
+		offset int
+		id     string
+	}{
+		{name: "truncated", s: bcbExample[:len(bcbExample)-10], offset: 118, id: "62"},
+		{name: "invalid length", s: "0002012X", offset: 6, id: "2X"},
+		{name: "invalid id", s: "0002010X", offset: 6, id: "0X"},
+		{name: "invalid template", s: withCRC("00020126040099"), offset: 10, id: "00"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(tc.s)
+
+			var te *TLVError
+			if !errors.As(err, &te) {
+				t.Fatalf("expected a *TLVError, got %v", err)
+			}
+
+			if te.Offset != tc.offset || te.ID != tc.id || !errors.Is(err, ErrInvalidBRCode) {
+				t.Errorf("\nwant: %s at %d\ngot: %s at %d", tc.id, tc.offset, te.ID, te.Offset)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		name string
+		s    string
+		id   string
+		err  error
+	}{
+		{name: "no crc", s: "000201", id: "63", err: ErrMissingField},
+		{name: "no account", s: withCRC("0002015204000053039865802BR5913Fulano de Tal6008BRASILIA"), id: "26", err: ErrMissingField},
+		{name: "other arrangement only", s: withCRC("00020126180014br.gov.bcb.xyz5204000053039865802BR5913Fulano de Tal6008BRASILIA"), id: "26", err: ErrMissingField},
+		{name: "invalid key", s: withCRC("00020126330014br.gov.bcb.pix01111191234567852040000530398654041.005802BR5913Fulano de Tal6008BRASILIA"), id: "26.01", err: ErrInvalidField},
+		{name: "invalid currency", s: withCRC("00020126360014br.gov.bcb.pix0114+55119123456785204000053038405802BR5913Fulano de Tal6008BRASILIA"), id: "53", err: ErrInvalidField},
+		{name: "invalid amount", s: withCRC("00020126360014br.gov.bcb.pix0114+55119123456785204000053039865405-1.005802BR5913Fulano de Tal6008BRASILIA"), id: "54", err: ErrInvalidField},
+		{name: "no name", s: withCRC("00020126360014br.gov.bcb.pix0114+5511912345678520400005303986580" + "2BR6008BRASILIA"), id: "59", err: ErrMissingField},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(tc.s)
+
+			var fe *FieldError
+			if !errors.As(err, &fe) {
+				t.Fatalf("expected a *FieldError, got %v", err)
+			}
+
+			if fe.ID != tc.id || !errors.Is(err, tc.err) || !errors.Is(err, ErrInvalidBRCode) {
+				t.Errorf("\nwant: %s %v\ngot: %s %v", tc.id, tc.err, fe.ID, fe.Err)
+			}
+		})
+	}
+}
+
+func TestParse_UppercaseGUI(t *testing.T) {
+	s := "00020126360014BR.GOV.BCB.PIX0114+551191234567852040000530398654045.505802BR5913Fulano de Tal6008BRASILIA6304"
+	s += crc16(s)
+
+	c, err := Parse(s)
+	if err != nil {
+		t.Fatalf("failed to parse br code: %v", err)
+	}
+
+	if c.Key != "+5511912345678" || c.Amount != 550 {
+		t.Errorf("unexpected br code: %+v", c)
+	}
+}
+
+func TestBRCode_QRCode(t *testing.T) {
+	c := BRCode{
+		Key:          "52998224725",
+		MerchantName: "Fulano de Tal",
+		MerchantCity: "BRASILIA",
+	}
+
+	q, err := c.QRCode(qrcode.M)
+	if err != nil {
+		t.Fatalf("failed to encode qr code: %v", err)
+	}
+
+	if q.Level() != qrcode.M || q.Version() == 0 {
+		t.Errorf("unexpected qr code: version %d level %s", q.Version(), q.Level())
+	}
+
+	if _, err := (BRCode{}).QRCode(qrcode.M); !errors.Is(err, ErrMissingField) {
+		t.Errorf("expected ErrMissingField, got %v", err)
+	}
+}
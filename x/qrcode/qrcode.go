@@ -0,0 +1,618 @@
+// Package qrcode provides a QR code encoder, written in pure Go, for rendering payloads such as
+// Pix BR Codes to images and SVG.
+//
+// Data is always encoded in byte mode and the smallest version that fits it is chosen.
+package qrcode
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// ErrDataTooLong is returned when the data does not fit in a QR code of version 40.
+var ErrDataTooLong = errors.New("br: data too long for a qr code")
+
+// Level is the error correction level of a QR code.
+type Level uint8
+
+const (
+	// L recovers about 7% of the QR code.
+	L Level = iota
+
+	// M recovers about 15% of the QR code.
+	M
+
+	// Q recovers about 25% of the QR code.
+	Q
+
+	// H recovers about 30% of the QR code.
+	H
+)
+
+// String returns the name of the Level, such as M.
+func (l Level) String() string {
+	switch l {
+	case L:
+		return "L"
+	case M:
+		return "M"
+	case Q:
+		return "Q"
+	case H:
+		return "H"
+	default:
+		return ""
+	}
+}
+
+// formatBits returns the 2 bits that identify the Level in the format information.
+func (l Level) formatBits() int {
+	return [...]int{L: 1, M: 0, Q: 3, H: 2}[l]
+}
+
+const (
+	minVersion = 1
+	maxVersion = 40
+
+	// quietZone is the amount of light modules around the QR code required by the specification.
+	quietZone = 4
+)
+
+// eccCodewordsPerBlock holds the amount of error correction codewords of each block, by level and version.
+var eccCodewordsPerBlock = [4][maxVersion + 1]int{
+	L: {0, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	M: {0, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	Q: {0, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	H: {0, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+// numBlocks holds the amount of error correction blocks, by level and version.
+var numBlocks = [4][maxVersion + 1]int{
+	L: {0, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	M: {0, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	Q: {0, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	H: {0, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// QRCode is an encoded QR code, a square grid of dark and light modules.
+type QRCode struct {
+	version int
+	level   Level
+	size    int
+
+	modules    []bool
+	isFunction []bool
+}
+
+// Encode encodes data in byte mode into the smallest QR code that fits it with the given error correction level.
+func Encode(data string, level Level) (*QRCode, error) {
+	if level > H {
+		level = H
+	}
+
+	version := minVersion
+	for ; ; version++ {
+		if version > maxVersion {
+			return nil, ErrDataTooLong
+		}
+
+		if dataBits(version, len(data)) <= numDataCodewords(version, level)*8 {
+			break
+		}
+	}
+
+	q := &QRCode{
+		version:    version,
+		level:      level,
+		size:       version*4 + 17,
+		modules:    make([]bool, (version*4+17)*(version*4+17)),
+		isFunction: make([]bool, (version*4+17)*(version*4+17)),
+	}
+
+	q.drawFunctionPatterns()
+	q.drawCodewords(q.addECCAndInterleave(q.dataCodewords(data)))
+
+	best, minPenalty := 0, -1
+	for mask := range 8 {
+		q.applyMask(mask)
+		q.drawFormatBits(mask)
+		if penalty := q.penalty(); minPenalty < 0 || penalty < minPenalty {
+			best, minPenalty = mask, penalty
+		}
+		// Masks are their own inverse.
+		q.applyMask(mask)
+	}
+
+	q.applyMask(best)
+	q.drawFormatBits(best)
+
+	return q, nil
+}
+
+// Version returns the version of the QR code, from 1 to 40.
+func (q *QRCode) Version() int {
+	return q.version
+}
+
+// Level returns the error correction level of the QR code.
+func (q *QRCode) Level() Level {
+	return q.level
+}
+
+// Size returns the amount of modules on each side of the QR code, without the quiet zone.
+func (q *QRCode) Size() int {
+	return q.size
+}
+
+// Module reports whether the module at the given coordinates is dark.
+// The top left module is at (0, 0) and coordinates outside of the QR code are light.
+func (q *QRCode) Module(x, y int) bool {
+	if x < 0 || y < 0 || x >= q.size || y >= q.size {
+		return false
+	}
+	return q.modules[y*q.size+x]
+}
+
+// Image renders the QR code, with its quiet zone, using scale pixels for each module.
+func (q *QRCode) Image(scale int) image.Image {
+	scale = max(scale, 1)
+	side := (q.size + quietZone*2) * scale
+
+	img := image.NewPaletted(image.Rect(0, 0, side, side), color.Palette{color.White, color.Black})
+	for y := range q.size {
+		for x := range q.size {
+			if !q.Module(x, y) {
+				continue
+			}
+
+			for py := range scale {
+				row := img.Pix[((y+quietZone)*scale+py)*img.Stride:]
+				for px := range scale {
+					row[(x+quietZone)*scale+px] = 1
+				}
+			}
+		}
+	}
+
+	return img
+}
+
+// SVG renders the QR code, with its quiet zone, as an SVG document using scale pixels for each module.
+func (q *QRCode) SVG(scale int) string {
+	scale = max(scale, 1)
+	side := strconv.Itoa(q.size + quietZone*2)
+	pixels := strconv.Itoa((q.size + quietZone*2) * scale)
+
+	var sb strings.Builder
+	sb.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" version="1.1" viewBox="0 0 ` + side + ` ` + side + `"`)
+	sb.WriteString(` width="` + pixels + `" height="` + pixels + `" shape-rendering="crispEdges">`)
+	sb.WriteString(`<rect width="100%" height="100%" fill="#FFFFFF"/><path fill="#000000" d="`)
+
+	for y := range q.size {
+		for x := 0; x < q.size; x++ {
+			if !q.Module(x, y) {
+				continue
+			}
+
+			run := 1
+			for q.Module(x+run, y) {
+				run++
+			}
+
+			sb.WriteString("M" + strconv.Itoa(x+quietZone) + "," + strconv.Itoa(y+quietZone))
+			sb.WriteString("h" + strconv.Itoa(run) + "v1h-" + strconv.Itoa(run) + "z")
+			x += run
+		}
+	}
+
+	sb.WriteString(`"/></svg>`)
+	return sb.String()
+}
+
+func (q *QRCode) set(x, y int, dark bool) {
+	q.modules[y*q.size+x] = dark
+	q.isFunction[y*q.size+x] = true
+}
+
+// drawFunctionPatterns draws the timing, finder and alignment patterns, and reserves the
+// area of the format and version information.
+func (q *QRCode) drawFunctionPatterns() {
+	for i := range q.size {
+		q.set(6, i, i%2 == 0)
+		q.set(i, 6, i%2 == 0)
+	}
+
+	q.drawFinderPattern(3, 3)
+	q.drawFinderPattern(q.size-4, 3)
+	q.drawFinderPattern(3, q.size-4)
+
+	positions := alignmentPatternPositions(q.version)
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			// The corners with finder patterns.
+			if i == 0 && j == 0 || i == 0 && j == last || i == last && j == 0 {
+				continue
+			}
+			q.drawAlignmentPattern(x, y)
+		}
+	}
+
+	q.drawFormatBits(0)
+	q.drawVersion()
+}
+
+// drawFinderPattern draws a finder pattern and its separator centered at (x, y).
+func (q *QRCode) drawFinderPattern(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= q.size || yy >= q.size {
+				continue
+			}
+
+			dist := max(abs(dx), abs(dy))
+			q.set(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// drawAlignmentPattern draws an alignment pattern centered at (x, y).
+func (q *QRCode) drawAlignmentPattern(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			q.set(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormatBits draws both copies of the format information, with the level and mask, and the dark module.
+func (q *QRCode) drawFormatBits(mask int) {
+	bits := formatBits(q.level, mask)
+
+	for i := range 6 {
+		q.set(8, i, bit(bits, i))
+	}
+	q.set(8, 7, bit(bits, 6))
+	q.set(8, 8, bit(bits, 7))
+	q.set(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		q.set(14-i, 8, bit(bits, i))
+	}
+
+	for i := range 8 {
+		q.set(q.size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		q.set(8, q.size-15+i, bit(bits, i))
+	}
+
+	q.set(8, q.size-8, true)
+}
+
+// drawVersion draws both copies of the version information, present from version 7 on.
+func (q *QRCode) drawVersion() {
+	if q.version < 7 {
+		return
+	}
+
+	bits := versionBits(q.version)
+	for i := range 18 {
+		a, b := q.size-11+i%3, i/3
+		q.set(a, b, bit(bits, i))
+		q.set(b, a, bit(bits, i))
+	}
+}
+
+// formatBits calculates the 15 bits of the format information, protected by a BCH code and masked.
+func formatBits(level Level, mask int) int {
+	data := level.formatBits()<<3 | mask
+	rem := data
+	for range 10 {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// versionBits calculates the 18 bits of the version information, protected by a BCH code.
+func versionBits(version int) int {
+	rem := version
+	for range 12 {
+		rem = rem<<1 ^ (rem>>11)*0x1F25
+	}
+	return version<<12 | rem
+}
+
+// alignmentPatternPositions returns the coordinates of the centers of the alignment patterns,
+// used both as rows and columns.
+func alignmentPatternPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+
+	n := version/7 + 2
+	step := (version*8 + n*3 + 5) / (n*4 - 4) * 2
+
+	positions := make([]int, n)
+	positions[0] = 6
+	for i, pos := n-1, version*4+10; i > 0; i, pos = i-1, pos-step {
+		positions[i] = pos
+	}
+
+	return positions
+}
+
+// numRawDataModules returns the amount of modules available for data and error correction,
+// including the remainder bits.
+func numRawDataModules(version int) int {
+	n := (16*version+128)*version + 64
+	if version >= 2 {
+		align := version/7 + 2
+		n -= (25*align-10)*align - 55
+		if version >= 7 {
+			n -= 36
+		}
+	}
+	return n
+}
+
+// numDataCodewords returns the amount of data codewords of a version and level.
+func numDataCodewords(version int, level Level) int {
+	return numRawDataModules(version)/8 - eccCodewordsPerBlock[level][version]*numBlocks[level][version]
+}
+
+// charCountBits returns the size of the character count indicator of byte mode.
+func charCountBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// dataBits returns the amount of bits used by n bytes encoded in byte mode, with the mode and count indicators.
+func dataBits(version, n int) int {
+	if n >= 1<<charCountBits(version) {
+		return 1 << 30
+	}
+	return 4 + charCountBits(version) + n*8
+}
+
+// dataCodewords encodes data in byte mode, adding the terminator and the padding.
+func (q *QRCode) dataCodewords(data string) []byte {
+	capacity := numDataCodewords(q.version, q.level)
+
+	var bb bitBuffer
+	bb.append(0b0100, 4)
+	bb.append(len(data), charCountBits(q.version))
+	for i := range len(data) {
+		bb.append(int(data[i]), 8)
+	}
+
+	bb.append(0, min(4, capacity*8-bb.n))
+	bb.append(0, (8-bb.n%8)%8)
+
+	for pad := 0xEC; len(bb.b) < capacity; pad ^= 0xEC ^ 0x11 {
+		bb.append(pad, 8)
+	}
+
+	return bb.b
+}
+
+// addECCAndInterleave splits the data into blocks, calculates their error correction codewords
+// and interleaves them.
+func (q *QRCode) addECCAndInterleave(data []byte) []byte {
+	var (
+		blocks        = numBlocks[q.level][q.version]
+		eccLen        = eccCodewordsPerBlock[q.level][q.version]
+		raw           = numRawDataModules(q.version) / 8
+		numShort      = blocks - raw%blocks
+		shortBlockLen = raw / blocks
+		divisor       = rsDivisor(eccLen)
+	)
+
+	out := make([][]byte, blocks)
+	for i, k := 0, 0; i < blocks; i++ {
+		n := shortBlockLen - eccLen
+		if i >= numShort {
+			n++
+		}
+
+		block := make([]byte, 0, shortBlockLen+1)
+		block = append(block, data[k:k+n]...)
+		k += n
+
+		ecc := rsRemainder(block, divisor)
+		if i < numShort {
+			// Placeholder, so all the blocks have the same length.
+			block = append(block, 0)
+		}
+
+		out[i] = append(block, ecc...)
+	}
+
+	result := make([]byte, 0, raw)
+	for i := range len(out[0]) {
+		for j, block := range out {
+			if i != shortBlockLen-eccLen || j >= numShort {
+				result = append(result, block[i])
+			}
+		}
+	}
+
+	return result
+}
+
+// drawCodewords places the codewords in the zigzag order, from the bottom right corner.
+func (q *QRCode) drawCodewords(data []byte) {
+	var i int
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			// Skips the vertical timing pattern.
+			right = 5
+		}
+
+		for vert := range q.size {
+			for j := range 2 {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = q.size - 1 - vert
+				}
+
+				if q.isFunction[y*q.size+x] || i >= len(data)*8 {
+					continue
+				}
+
+				q.modules[y*q.size+x] = bit(int(data[i>>3]), 7-i&7)
+				i++
+			}
+		}
+	}
+}
+
+// applyMask flips the data modules selected by the mask pattern.
+func (q *QRCode) applyMask(mask int) {
+	for y := range q.size {
+		for x := range q.size {
+			if !q.isFunction[y*q.size+x] && maskBit(mask, x, y) {
+				q.modules[y*q.size+x] = !q.modules[y*q.size+x]
+			}
+		}
+	}
+}
+
+func maskBit(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// The weights of the penalty rules used to choose the mask.
+const (
+	penaltyN1 = 3
+	penaltyN2 = 3
+	penaltyN3 = 40
+	penaltyN4 = 10
+)
+
+// finderLike is the 1:1:3:1:1 pattern, followed by 4 light modules, penalized by rule 3.
+var finderLike = [11]bool{true, false, true, true, true, false, true, false, false, false, false}
+
+// penalty scores the QR code with the rules of the specification. Lower is better.
+func (q *QRCode) penalty() int {
+	var score, dark int
+
+	at := func(x, y int, transpose bool) bool {
+		if transpose {
+			return q.modules[x*q.size+y]
+		}
+		return q.modules[y*q.size+x]
+	}
+
+	for _, transpose := range []bool{false, true} {
+		for y := range q.size {
+			run := 0
+			for x := range q.size {
+				if x > 0 && at(x, y, transpose) == at(x-1, y, transpose) {
+					run++
+				} else {
+					run = 1
+				}
+
+				switch {
+				case run == 5:
+					score += penaltyN1
+				case run > 5:
+					score++
+				}
+
+				if x+len(finderLike) > q.size {
+					continue
+				}
+
+				forward, backward := true, true
+				for i, want := range finderLike {
+					forward = forward && at(x+i, y, transpose) == want
+					backward = backward && at(x+len(finderLike)-1-i, y, transpose) == want
+				}
+
+				if forward {
+					score += penaltyN3
+				}
+
+				if backward {
+					score += penaltyN3
+				}
+			}
+		}
+	}
+
+	for y := range q.size {
+		for x := range q.size {
+			c := q.modules[y*q.size+x]
+			if c {
+				dark++
+			}
+
+			if x < q.size-1 && y < q.size-1 &&
+				c == q.modules[y*q.size+x+1] &&
+				c == q.modules[(y+1)*q.size+x] &&
+				c == q.modules[(y+1)*q.size+x+1] {
+				score += penaltyN2
+			}
+		}
+	}
+
+	total := q.size * q.size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	score += k * penaltyN4
+
+	return score
+}
+
+type bitBuffer struct {
+	b []byte
+	n int
+}
+
+// append appends the length lowest bits of v, from the most significant one.
+func (bb *bitBuffer) append(v, length int) {
+	for i := length - 1; i >= 0; i-- {
+		if bb.n%8 == 0 {
+			bb.b = append(bb.b, 0)
+		}
+		if bit(v, i) {
+			bb.b[len(bb.b)-1] |= 1 << (7 - bb.n%8)
+		}
+		bb.n++
+	}
+}
+
+func bit(v, i int) bool {
+	return v>>i&1 != 0
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
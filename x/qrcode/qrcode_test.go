@@ -0,0 +1,378 @@
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// pixPayloads are BR Codes of the Manual de Padrões para Iniciação do Pix and from the pix package tests.
+var pixPayloads = []struct {
+	payload string
+	level   Level
+	version int
+}{
+	{
+		payload: "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D",
+		level:   M,
+		version: 8,
+	},
+	{
+		payload: "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D",
+		level:   L,
+		version: 7,
+	},
+	{
+		payload: "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D",
+		level:   H,
+		version: 11,
+	},
+	{
+		payload: "00020101021226760014br.gov.bcb.pix2554pix.example.com/qr/v2/9d36b84fc70b478fb95c12729b90ca255204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63047EC0",
+		level:   Q,
+		version: 11,
+	},
+}
+
+func BenchmarkEncode(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		_, _ = Encode(pixPayloads[0].payload, M)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	for _, tc := range pixPayloads {
+		t.Run(tc.level.String(), func(t *testing.T) {
+			q, err := Encode(tc.payload, tc.level)
+			if err != nil {
+				t.Fatalf("failed to encode: %v", err)
+			}
+
+			if q.Version() != tc.version || q.Level() != tc.level || q.Size() != tc.version*4+17 {
+				t.Errorf("\nwant: version %d level %s\ngot: version %d level %s size %d", tc.version, tc.level, q.Version(), q.Level(), q.Size())
+			}
+
+			checkFinderPatterns(t, q)
+
+			got, err := decode(q)
+			if err != nil {
+				t.Fatalf("failed to decode: %v", err)
+			}
+
+			if got != tc.payload {
+				t.Errorf("\nwant: %s\ngot: %s", tc.payload, got)
+			}
+		})
+	}
+}
+
+func TestEncode_AllVersions(t *testing.T) {
+	for level := L; level <= H; level++ {
+		for version := minVersion; version <= maxVersion; version++ {
+			// The largest payload that fits in the version.
+			n := (numDataCodewords(version, level)*8 - 4 - charCountBits(version)) / 8
+			payload := strings.Repeat("0123456789abcdef", n/16+1)[:n]
+
+			q, err := Encode(payload, level)
+			if err != nil {
+				t.Fatalf("%d-%s: failed to encode: %v", version, level, err)
+			}
+
+			if q.Version() != version {
+				t.Fatalf("%d-%s: got version %d", version, level, q.Version())
+			}
+
+			var dataModules int
+			for _, f := range q.isFunction {
+				if !f {
+					dataModules++
+				}
+			}
+
+			if dataModules != numRawDataModules(version) {
+				t.Fatalf("%d-%s: want %d data modules, got %d", version, level, numRawDataModules(version), dataModules)
+			}
+
+			got, err := decode(q)
+			if err != nil {
+				t.Fatalf("%d-%s: failed to decode: %v", version, level, err)
+			}
+
+			if got != payload {
+				t.Fatalf("%d-%s: decoded payload does not match", version, level)
+			}
+		}
+	}
+}
+
+func TestEncode_Capacity(t *testing.T) {
+	// The byte mode capacities of the specification.
+	for _, tc := range []struct {
+		version  int
+		capacity [4]int
+	}{
+		{version: 1, capacity: [4]int{17, 14, 11, 7}},
+		{version: 2, capacity: [4]int{32, 26, 20, 14}},
+		{version: 7, capacity: [4]int{154, 122, 86, 64}},
+		{version: 10, capacity: [4]int{271, 213, 151, 119}},
+		{version: 40, capacity: [4]int{2953, 2331, 1663, 1273}},
+	} {
+		for level := L; level <= H; level++ {
+			capacity := tc.capacity[level]
+			bits := numDataCodewords(tc.version, level) * 8
+
+			if dataBits(tc.version, capacity) > bits || dataBits(tc.version, capacity+1) <= bits {
+				t.Errorf("%d-%s: capacity is not %d", tc.version, level, capacity)
+			}
+		}
+	}
+
+	if _, err := Encode(strings.Repeat("a", 2954), L); !errors.Is(err, ErrDataTooLong) {
+		t.Errorf("expected ErrDataTooLong, got %v", err)
+	}
+}
+
+func TestReedSolomon(t *testing.T) {
+	// The data codewords of HELLO WORLD in a 1-M QR code.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	if got := rsRemainder(data, rsDivisor(len(want))); !bytes.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot: %v", want, got)
+	}
+}
+
+func TestFormatAndVersionBits(t *testing.T) {
+	for _, tc := range []struct {
+		level Level
+		want  int
+	}{
+		{level: L, want: 0b111011111000100},
+		{level: M, want: 0b101010000010010},
+		{level: Q, want: 0b011010101011111},
+		{level: H, want: 0b001011010001001},
+	} {
+		if got := formatBits(tc.level, 0); got != tc.want {
+			t.Errorf("%s: want format bits %015b, got %015b", tc.level, tc.want, got)
+		}
+	}
+
+	if got, want := versionBits(7), 0b000111110010010100; got != want {
+		t.Errorf("want version bits %018b, got %018b", want, got)
+	}
+}
+
+func TestAlignmentPatternPositions(t *testing.T) {
+	for _, tc := range []struct {
+		version int
+		want    []int
+	}{
+		{version: 1, want: nil},
+		{version: 2, want: []int{6, 18}},
+		{version: 7, want: []int{6, 22, 38}},
+		{version: 32, want: []int{6, 34, 60, 86, 112, 138}},
+		{version: 40, want: []int{6, 30, 58, 86, 114, 142, 170}},
+	} {
+		if got := alignmentPatternPositions(tc.version); !slices.Equal(got, tc.want) {
+			t.Errorf("version %d: want %v, got %v", tc.version, tc.want, got)
+		}
+	}
+}
+
+func TestQRCode_Image(t *testing.T) {
+	q, err := Encode(pixPayloads[0].payload, M)
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+
+	const scale = 3
+	img := q.Image(scale)
+
+	side := (q.Size() + quietZone*2) * scale
+	if b := img.Bounds(); b.Dx() != side || b.Dy() != side {
+		t.Fatalf("want image of %dx%d, got %v", side, side, b)
+	}
+
+	for y := range side {
+		for x := range side {
+			dark := q.Module(x/scale-quietZone, y/scale-quietZone)
+			if got := color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y == 0; got != dark {
+				t.Fatalf("pixel (%d, %d): want dark %v, got %v", x, y, dark, got)
+			}
+		}
+	}
+}
+
+func TestQRCode_SVG(t *testing.T) {
+	q, err := Encode(pixPayloads[0].payload, M)
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+
+	svg := q.SVG(4)
+
+	for _, want := range []string{
+		`<svg xmlns="http://www.w3.org/2000/svg"`,
+		`viewBox="0 0 57 57"`,
+		`width="228" height="228"`,
+		// The top row of the top left finder pattern.
+		`M4,4h7v1h-7z`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("svg does not contain %s", want)
+		}
+	}
+
+	if !strings.HasSuffix(svg, "</svg>") {
+		t.Error("svg is not closed")
+	}
+}
+
+// checkFinderPatterns makes sure the 3 finder patterns are drawn at the corners.
+func checkFinderPatterns(t *testing.T, q *QRCode) {
+	t.Helper()
+
+	for _, corner := range [][2]int{{0, 0}, {q.Size() - 7, 0}, {0, q.Size() - 7}} {
+		for dy := range 7 {
+			for dx := range 7 {
+				dist := max(abs(dx-3), abs(dy-3))
+				if q.Module(corner[0]+dx, corner[1]+dy) != (dist != 2) {
+					t.Fatalf("finder pattern at %v is wrong at (%d, %d)", corner, dx, dy)
+				}
+			}
+		}
+	}
+}
+
+// decode reads the data back from a QR code, checking its format information and error correction codewords.
+func decode(q *QRCode) (string, error) {
+	var format int
+	for i := range 6 {
+		format |= b2i(q.Module(8, i)) << i
+	}
+	format |= b2i(q.Module(8, 7)) << 6
+	format |= b2i(q.Module(8, 8)) << 7
+	format |= b2i(q.Module(7, 8)) << 8
+	for i := 9; i < 15; i++ {
+		format |= b2i(q.Module(14-i, 8)) << i
+	}
+
+	mask := -1
+	for m := range 8 {
+		if formatBits(q.level, m) == format {
+			mask = m
+		}
+	}
+
+	if mask < 0 {
+		return "", errors.New("format information does not match the level")
+	}
+
+	var second int
+	for i := range 8 {
+		second |= b2i(q.Module(q.size-1-i, 8)) << i
+	}
+	for i := 8; i < 15; i++ {
+		second |= b2i(q.Module(8, q.size-15+i)) << i
+	}
+
+	if second != format {
+		return "", errors.New("format information copies differ")
+	}
+
+	raw := make([]byte, numRawDataModules(q.version)/8)
+	var i int
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+
+		for vert := range q.size {
+			for j := range 2 {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = q.size - 1 - vert
+				}
+
+				if q.isFunction[y*q.size+x] || i >= len(raw)*8 {
+					continue
+				}
+
+				if q.Module(x, y) != maskBit(mask, x, y) {
+					raw[i>>3] |= 1 << (7 - i&7)
+				}
+				i++
+			}
+		}
+	}
+
+	var (
+		blocks        = numBlocks[q.level][q.version]
+		eccLen        = eccCodewordsPerBlock[q.level][q.version]
+		numShort      = blocks - len(raw)%blocks
+		shortBlockLen = len(raw) / blocks
+		divisor       = rsDivisor(eccLen)
+	)
+
+	deinterleaved := make([][]byte, blocks)
+	var k int
+	for i := range shortBlockLen + 1 {
+		for j := range blocks {
+			if i == shortBlockLen-eccLen && j < numShort {
+				// Short blocks have no codeword here.
+				deinterleaved[j] = append(deinterleaved[j], 0)
+				continue
+			}
+			deinterleaved[j] = append(deinterleaved[j], raw[k])
+			k++
+		}
+	}
+
+	var data []byte
+	for j, block := range deinterleaved {
+		n := shortBlockLen - eccLen
+		if j >= numShort {
+			n++
+		}
+
+		ecc := block[len(block)-eccLen:]
+		if !bytes.Equal(rsRemainder(block[:n], divisor), ecc) {
+			return "", errors.New("error correction codewords do not match")
+		}
+
+		data = append(data, block[:n]...)
+	}
+
+	read := func(pos, n int) int {
+		var v int
+		for i := range n {
+			v = v<<1 | int(data[(pos+i)>>3]>>(7-(pos+i)&7)&1)
+		}
+		return v
+	}
+
+	if read(0, 4) != 0b0100 {
+		return "", errors.New("not byte mode")
+	}
+
+	count := read(4, charCountBits(q.version))
+	pos := 4 + charCountBits(q.version)
+
+	out := make([]byte, count)
+	for i := range out {
+		out[i] = byte(read(pos+i*8, 8))
+	}
+
+	return string(out), nil
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}